@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/christophergyman/claude-quick/internal/auth"
+	"github.com/christophergyman/claude-quick/internal/config"
+	"github.com/christophergyman/claude-quick/internal/constants"
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/tmux"
+	"github.com/christophergyman/claude-quick/internal/util"
+)
+
+// runAttach is the non-interactive launcher behind --attach: it finds the
+// project, brings its container up, creates the session if it doesn't exist,
+// then replaces this process with the tmux attach. It only returns on error.
+func runAttach(cfg *config.Config, project, session string) error {
+	name := devcontainer.SanitizeSessionName(session)
+	if name == "" {
+		return fmt.Errorf("invalid session name %q", session)
+	}
+
+	if err := devcontainer.CheckCLI(); err != nil {
+		return err
+	}
+
+	instance, err := findAttachInstance(cfg, project)
+	if err != nil {
+		return err
+	}
+
+	// Credentials are written before the container starts, as in the TUI
+	result := cfg.Auth.Resolve(instance.Name)
+	if len(result.Credentials) > 0 {
+		if err := auth.WriteCredentialFile(instance.Path, result.Credentials); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to write credentials: %v\n", err)
+		}
+	}
+	for _, failure := range result.Failed {
+		fmt.Fprintf(os.Stderr, "Warning: credential %s not resolved: %v\n", failure.Credential.Name, failure.Err)
+	}
+
+	timeout := constants.DefaultContainerTimeout * time.Second
+	if cfg.ContainerTimeout > 0 {
+		timeout = time.Duration(cfg.ContainerTimeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	fmt.Fprintf(os.Stderr, "Starting %s...\n", instance.DisplayName())
+	if err := devcontainer.UpContext(ctx, instance.Path); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("container for %s did not start within %s", instance.DisplayName(), timeout)
+		}
+		return fmt.Errorf("failed to start container for %s: %w", instance.DisplayName(), err)
+	}
+
+	if !devcontainer.HasTmux(instance.Path) {
+		return errors.New("tmux not found in container. Please install tmux in your devcontainer")
+	}
+
+	lines, err := devcontainer.ListTmuxSessions(instance.Path)
+	if err != nil {
+		return err
+	}
+	sessions := tmux.ParseSessions(lines)
+	if !hasSession(sessions, name) {
+		launchCmd := attachLaunchCommand(cfg, instance)
+		if cfg.IsLaunchCommandFirstOnly() && len(sessions) > 0 {
+			launchCmd = ""
+		}
+		opts := devcontainer.TmuxSessionOptions{
+			LaunchCommand: launchCmd,
+			WindowName:    cfg.TmuxWindowName,
+			StartDir:      cfg.TmuxStartDir,
+			Env:           cfg.SessionEnv,
+		}
+		if err := devcontainer.CreateTmuxSession(instance.Path, name, opts); err != nil {
+			return err
+		}
+	}
+
+	return devcontainer.ExecInteractive(instance.Path, devcontainer.AttachArgs(name, cfg.IsTmuxAttachMouse()))
+}
+
+// findAttachInstance resolves project as a path to a devcontainer project,
+// or else as a project name among the instances in the search paths
+func findAttachInstance(cfg *config.Config, project string) (*devcontainer.ContainerInstance, error) {
+	if info, err := os.Stat(util.ExpandPath(project)); err == nil && info.IsDir() {
+		return devcontainer.LoadInstance(project)
+	}
+
+	instances := devcontainer.DiscoverInstances(cfg.SearchPaths, cfg.MaxDepth, cfg.ExcludedDirs)
+	instance, err := devcontainer.FindInstance(instances, project)
+	if errors.Is(err, devcontainer.ErrProjectNotFound) {
+		return nil, fmt.Errorf("%w in search paths %v", err, cfg.SearchPaths)
+	}
+	return instance, err
+}
+
+// attachLaunchCommand returns the launch command for a new session in
+// instance, resolved the same way as in the TUI
+func attachLaunchCommand(cfg *config.Config, instance *devcontainer.ContainerInstance) string {
+	globalDefault := cfg.LaunchCommand
+	if globalDefault == "" && cfg.IsUsePostAttachCommand() {
+		globalDefault = instance.PostAttachCommand
+	}
+	return cfg.Auth.ResolveLaunchCommand(instance.Name, globalDefault)
+}
+
+// hasSession reports whether sessions contains one called name
+func hasSession(sessions []tmux.Session, name string) bool {
+	for _, s := range sessions {
+		if s.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/christophergyman/claude-quick/internal/config"
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
+)
+
+// codeWorkspaceExt marks an export path that gets a VS Code workspace file
+// rather than the JSON list
+const codeWorkspaceExt = ".code-workspace"
+
+// exportedWorkspace is one discovered instance in the JSON export
+type exportedWorkspace struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	ConfigPath string `json:"configPath"`
+	Branch     string `json:"branch,omitempty"`
+}
+
+// codeWorkspace is the subset of a VS Code .code-workspace file the export fills in
+type codeWorkspace struct {
+	Folders []codeWorkspaceFolder `json:"folders"`
+}
+
+type codeWorkspaceFolder struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// runExport is the non-interactive export behind --export-workspaces: it
+// discovers instances as the dashboard does and prints them as JSON, or writes
+// them to outPath. A path ending in .code-workspace gets a VS Code workspace.
+func runExport(cfg *config.Config, outPath string) error {
+	// Check the destination first so a bad path fails before a long discovery
+	if outPath != "" {
+		if err := checkWritable(outPath); err != nil {
+			return err
+		}
+	}
+
+	instances := devcontainer.DiscoverInstances(cfg.SearchPaths, cfg.MaxDepth, cfg.ExcludedDirs)
+	var data []byte
+	var err error
+	if strings.HasSuffix(outPath, codeWorkspaceExt) {
+		data, err = json.MarshalIndent(workspaceFile(instances), "", "  ")
+	} else {
+		data, err = json.MarshalIndent(workspaceList(instances), "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode export: %w", err)
+	}
+	data = append(data, '\n')
+
+	if outPath == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(outPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d project(s) to %s\n", len(instances), outPath)
+	return nil
+}
+
+// workspaceList converts instances to the JSON export entries
+func workspaceList(instances []devcontainer.ContainerInstance) []exportedWorkspace {
+	list := make([]exportedWorkspace, 0, len(instances))
+	for _, instance := range instances {
+		entry := exportedWorkspace{
+			Name:       instance.DisplayName(),
+			Path:       instance.Path,
+			ConfigPath: instance.ConfigPath,
+		}
+		if instance.Worktree != nil {
+			entry.Branch = instance.Worktree.Branch
+		}
+		list = append(list, entry)
+	}
+	return list
+}
+
+// workspaceFile converts instances to a VS Code multi-root workspace
+func workspaceFile(instances []devcontainer.ContainerInstance) codeWorkspace {
+	ws := codeWorkspace{Folders: make([]codeWorkspaceFolder, 0, len(instances))}
+	for _, instance := range instances {
+		ws.Folders = append(ws.Folders, codeWorkspaceFolder{Name: instance.DisplayName(), Path: instance.Path})
+	}
+	return ws
+}
+
+// checkWritable reports why path can't be written: its directory is missing
+// or read-only, or path is a directory or a read-only file
+func checkWritable(path string) error {
+	if info, err := os.Stat(path); err == nil {
+		if info.IsDir() {
+			return fmt.Errorf("%s is a directory", path)
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return fmt.Errorf("cannot write %s: %w", path, err)
+		}
+		return f.Close()
+	}
+
+	dir := filepath.Dir(path)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("directory %s does not exist", dir)
+	}
+	f, err := os.CreateTemp(dir, ".claude-quick-export-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s: %w", dir, err)
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
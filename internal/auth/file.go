@@ -1,33 +1,182 @@
 package auth
 
 import (
+	"bufio"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 )
 
-// CredFileName is the name of the credential file written to project directories.
+// CredFileName is the default name of the credential file written to project directories.
 const CredFileName = ".claude-quick-auth"
 
+// CredentialFormat defines how credentials are laid out in the credential file.
+type CredentialFormat string
+
+const (
+	// FormatExport writes shell lines (export NAME='value') that can be sourced.
+	FormatExport CredentialFormat = "export"
+	// FormatDotenv writes NAME="value" lines for dotenv loaders.
+	FormatDotenv CredentialFormat = "dotenv"
+	// FormatNetrc writes a netrc entry per credential: the name is the machine
+	// and the value is the password, or login:password.
+	FormatNetrc CredentialFormat = "netrc"
+)
+
+// ValidCredentialFormats contains all valid credential file formats.
+var ValidCredentialFormats = map[CredentialFormat]bool{
+	FormatExport: true,
+	FormatDotenv: true,
+	FormatNetrc:  true,
+}
+
+// credFileHeader starts every export and dotenv credential file, marking it
+// as one claude-quick may overwrite or remove
+const credFileHeader = "# Generated by claude-quick"
+
+// netrcMarker starts every netrc credential file in place of the comment
+// header. The .invalid host never resolves, so readers ignore the entry.
+const netrcMarker = "machine claude-quick.invalid password generated"
+
+// The credential file written to and read from each project directory
+var (
+	credFileName = CredFileName
+	credFormat   = FormatExport
+)
+
+// SetCredentialFile sets the credential file's name, relative to the project
+// directory, and its format. Blank values keep the defaults.
+func SetCredentialFile(name string, format CredentialFormat) {
+	credFileName = CredFileName
+	if name != "" {
+		credFileName = filepath.Clean(name)
+	}
+	credFormat = FormatExport
+	if format != "" {
+		credFormat = format
+	}
+}
+
+// ValidateCredentialFile checks a credential file name and format from the config.
+// The name must stay inside the project directory so the container can see it.
+func ValidateCredentialFile(name string, format CredentialFormat) error {
+	if format != "" && !ValidCredentialFormats[format] {
+		return fmt.Errorf("credential_file_format: invalid format %q (must be export, dotenv, or netrc)", format)
+	}
+	if name == "" {
+		return nil
+	}
+	clean := filepath.Clean(name)
+	if filepath.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(os.PathSeparator)) {
+		return fmt.Errorf("credential_file_name: %q must be a path inside the project directory", name)
+	}
+	return nil
+}
+
+// ValidateNetrcMachines checks that every credential in cfg is named after a
+// host, as the netrc format requires: netrc readers look entries up by the
+// host they connect to, so an entry named GITHUB_TOKEN would never be used.
+func ValidateNetrcMachines(cfg Config) error {
+	check := func(field string, creds []Credential) error {
+		for i, cred := range creds {
+			if !isHostName(cred.Name) {
+				return fmt.Errorf("%s[%d]: name %q must be a host name such as github.com with credential_file_format netrc", field, i, cred.Name)
+			}
+		}
+		return nil
+	}
+	if err := check("auth.credentials", cfg.Credentials); err != nil {
+		return err
+	}
+	for _, projName := range slices.Sorted(maps.Keys(cfg.Projects)) {
+		if err := check("auth.projects."+projName+".credentials", cfg.Projects[projName].Credentials); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isHostName reports whether name looks like a host name: letters, digits,
+// dots and hyphens, with at least one dot (or localhost)
+func isHostName(name string) bool {
+	if name != "localhost" && !strings.Contains(name, ".") {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-') {
+			return false
+		}
+	}
+	return !strings.HasPrefix(name, ".") && !strings.HasSuffix(name, ".")
+}
+
 // WriteCredentialFile writes the resolved credentials to a file in the project directory.
-// The file is written with restricted permissions (0600) and can be sourced by shells.
+// The file is written with restricted permissions (0600) in the configured format;
+// the default export format can be sourced by shells. An existing file that
+// claude-quick didn't write (e.g. the project's own .env) is left alone.
 func WriteCredentialFile(projectPath string, creds map[string]string) error {
 	if len(creds) == 0 {
 		return nil
 	}
 
-	filePath := filepath.Join(projectPath, CredFileName)
+	filePath := CredentialFilePath(projectPath)
+	if exists, owned := credentialFileOwned(filePath); exists && !owned {
+		return fmt.Errorf("refusing to overwrite %s: it wasn't written by claude-quick", filePath)
+	}
+	// Create the directories of a nested credential_file_name, but not the project itself
+	if dir := filepath.Dir(filePath); dir != filepath.Clean(projectPath) {
+		if _, err := os.Stat(projectPath); err != nil {
+			return fmt.Errorf("failed to write credential file: %w", err)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to write credential file: %w", err)
+		}
+	}
+
+	// Write in name order so the file is identical between starts
+	names := make([]string, 0, len(creds))
+	for name := range creds {
+		names = append(names, name)
+	}
+	slices.Sort(names)
 
 	var buf strings.Builder
-	buf.WriteString("# Generated by claude-quick - DO NOT COMMIT\n")
-	buf.WriteString("# This file contains authentication credentials\n")
-	buf.WriteString("# Source this file: source .claude-quick-auth\n\n")
-
-	for name, value := range creds {
-		// Escape single quotes in value by ending the quote, adding escaped quote, and starting new quote
-		escaped := strings.ReplaceAll(value, "'", "'\"'\"'")
-		buf.WriteString(fmt.Sprintf("export %s='%s'\n", name, escaped))
+	switch credFormat {
+	case FormatDotenv:
+		buf.WriteString(credFileHeader + " - DO NOT COMMIT\n")
+		buf.WriteString("# This file contains authentication credentials\n\n")
+		for _, name := range names {
+			value := creds[name]
+			buf.WriteString(fmt.Sprintf("%s=%s\n", name, strconv.Quote(value)))
+		}
+	case FormatNetrc:
+		// No comment header: not every netrc reader skips comments
+		buf.WriteString(netrcMarker + "\n")
+		for _, name := range names {
+			value := creds[name]
+			if strings.ContainsAny(value, " \t\r\n") {
+				return fmt.Errorf("credential %s contains whitespace, which netrc can't hold", name)
+			}
+			if login, password, ok := strings.Cut(value, ":"); ok {
+				buf.WriteString(fmt.Sprintf("machine %s login %s password %s\n", name, login, password))
+			} else {
+				buf.WriteString(fmt.Sprintf("machine %s password %s\n", name, value))
+			}
+		}
+	default:
+		buf.WriteString(credFileHeader + " - DO NOT COMMIT\n")
+		buf.WriteString("# This file contains authentication credentials\n")
+		buf.WriteString(fmt.Sprintf("# Source this file: source %s\n\n", credFileName))
+		for _, name := range names {
+			value := creds[name]
+			// Escape single quotes in value by ending the quote, adding escaped quote, and starting new quote
+			escaped := strings.ReplaceAll(value, "'", "'\"'\"'")
+			buf.WriteString(fmt.Sprintf("export %s='%s'\n", name, escaped))
+		}
 	}
 
 	// Write with restricted permissions (600 = owner read/write only)
@@ -38,16 +187,148 @@ func WriteCredentialFile(projectPath string, creds map[string]string) error {
 	return nil
 }
 
+// ReadCredentialFile parses the credential file in a project directory and
+// returns name/value pairs. A missing or unreadable file, or one claude-quick
+// didn't write (e.g. the project's own .env), yields an empty map.
+func ReadCredentialFile(projectPath string) map[string]string {
+	result := make(map[string]string)
+
+	filePath := CredentialFilePath(projectPath)
+	if _, owned := credentialFileOwned(filePath); !owned {
+		return result
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return result // File doesn't exist or can't be read
+	}
+	defer file.Close()
+
+	if credFormat == FormatNetrc {
+		parseNetrc(file, result)
+		delete(result, "claude-quick.invalid")
+		return result
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip comments and empty lines
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if credFormat == FormatDotenv {
+			// Parse NAME="value" format
+			if name, value, ok := strings.Cut(line, "="); ok && name != "" {
+				if unquoted, err := strconv.Unquote(value); err == nil {
+					value = unquoted
+				}
+				result[name] = value
+			}
+			continue
+		}
+
+		// Parse "export NAME='value'" format
+		if strings.HasPrefix(line, "export ") {
+			line = strings.TrimPrefix(line, "export ")
+			if idx := strings.Index(line, "="); idx > 0 {
+				name := line[:idx]
+				value := line[idx+1:]
+
+				// Remove only the outermost quotes (not all leading/trailing quote chars)
+				// strings.Trim would remove ALL matching chars, corrupting escaped values
+				if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+					value = value[1 : len(value)-1]
+				}
+
+				// Handle escaped single quotes: 'val'"'"'ue' -> val'ue
+				// The pattern '\"'\"' is shell escaping for a literal single quote
+				value = strings.ReplaceAll(value, "'\"'\"'", "'")
+
+				result[name] = value
+			}
+		}
+	}
+
+	return result
+}
+
+// parseNetrc reads machine entries into result, joining a login back onto
+// its password as written by WriteCredentialFile
+func parseNetrc(file *os.File, result map[string]string) {
+	scanner := bufio.NewScanner(file)
+	scanner.Split(bufio.ScanWords)
+
+	var machine, login, password string
+	flush := func() {
+		if machine == "" {
+			return
+		}
+		if login != "" {
+			result[machine] = login + ":" + password
+		} else {
+			result[machine] = password
+		}
+		machine, login, password = "", "", ""
+	}
+	for scanner.Scan() {
+		switch scanner.Text() {
+		case "machine":
+			flush()
+			if scanner.Scan() {
+				machine = scanner.Text()
+			}
+		case "login":
+			if scanner.Scan() {
+				login = scanner.Text()
+			}
+		case "password":
+			if scanner.Scan() {
+				password = scanner.Text()
+			}
+		}
+	}
+	flush()
+}
+
 // CleanupCredentialFile removes the credential file from a project directory.
+// A file claude-quick didn't write is left alone.
 func CleanupCredentialFile(projectPath string) error {
-	filePath := filepath.Join(projectPath, CredFileName)
+	filePath := CredentialFilePath(projectPath)
+	if exists, owned := credentialFileOwned(filePath); exists && !owned {
+		return fmt.Errorf("refusing to remove %s: it wasn't written by claude-quick", filePath)
+	}
 	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to remove credential file: %w", err)
 	}
 	return nil
 }
 
+// HasCredentialFile reports whether a project directory holds a credential
+// file, i.e. whether claude-quick has started its container since it was last stopped.
+func HasCredentialFile(projectPath string) bool {
+	_, owned := credentialFileOwned(CredentialFilePath(projectPath))
+	return owned
+}
+
+// credentialFileOwned reports whether filePath exists and, if so, whether it
+// starts with the header or marker claude-quick writes (in any format, so a
+// file from before a format change still counts). An unreadable file is
+// treated as not owned.
+func credentialFileOwned(filePath string) (exists, owned bool) {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return false, false
+	}
+	if err != nil {
+		return true, false
+	}
+	content := string(data)
+	return true, strings.HasPrefix(content, credFileHeader) || strings.HasPrefix(content, netrcMarker)
+}
+
 // CredentialFilePath returns the path to the credential file for a project.
 func CredentialFilePath(projectPath string) string {
-	return filepath.Join(projectPath, CredFileName)
+	return filepath.Join(projectPath, credFileName)
 }
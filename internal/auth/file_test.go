@@ -136,6 +136,46 @@ func TestWriteCredentialFile_MultipleCredentials(t *testing.T) {
 	}
 }
 
+func TestWriteCredentialFile_StableOrder(t *testing.T) {
+	creds := map[string]string{
+		"ZETA_TOKEN":   "z",
+		"API_KEY":      "a",
+		"GITHUB_TOKEN": "g",
+		"MID_SECRET":   "m",
+	}
+
+	var first string
+	for i := 0; i < 10; i++ {
+		tmpDir := t.TempDir()
+		if err := WriteCredentialFile(tmpDir, creds); err != nil {
+			t.Fatalf("WriteCredentialFile() returned error: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, CredFileName))
+		if err != nil {
+			t.Fatalf("failed to read credential file: %v", err)
+		}
+		if i == 0 {
+			first = string(content)
+			continue
+		}
+		if string(content) != first {
+			t.Fatalf("write %d differs from the first:\n%s\nvs\n%s", i, content, first)
+		}
+	}
+
+	var names []string
+	for _, line := range strings.Split(first, "\n") {
+		if rest, ok := strings.CutPrefix(line, "export "); ok {
+			name, _, _ := strings.Cut(rest, "=")
+			names = append(names, name)
+		}
+	}
+	want := []string{"API_KEY", "GITHUB_TOKEN", "MID_SECRET", "ZETA_TOKEN"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("credential order = %v, want %v", names, want)
+	}
+}
+
 func TestWriteCredentialFile_QuoteEscaping(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "test-cred-*")
 	if err != nil {
@@ -234,7 +274,7 @@ func TestCleanupCredentialFile_Exists(t *testing.T) {
 
 	// Create the file first
 	filePath := filepath.Join(tmpDir, CredFileName)
-	if err := os.WriteFile(filePath, []byte("test"), 0600); err != nil {
+	if err := WriteCredentialFile(tmpDir, map[string]string{"TEST": "value"}); err != nil {
 		t.Fatalf("failed to create test file: %v", err)
 	}
 
@@ -271,3 +311,185 @@ func TestWriteCredentialFile_NonexistentDir(t *testing.T) {
 		t.Error("WriteCredentialFile() should return error for non-existent directory")
 	}
 }
+
+func TestCredentialFile_LeavesForeignFileAlone(t *testing.T) {
+	SetCredentialFile(".env", FormatDotenv)
+	defer SetCredentialFile("", "")
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, ".env")
+	original := "DATABASE_URL=postgres://localhost/app\n"
+	if err := os.WriteFile(filePath, []byte(original), 0644); err != nil {
+		t.Fatalf("failed to create test file: %v", err)
+	}
+
+	if err := WriteCredentialFile(tmpDir, map[string]string{"TOKEN": "x"}); err == nil {
+		t.Error("WriteCredentialFile() should refuse to overwrite the project's own .env")
+	}
+	if err := CleanupCredentialFile(tmpDir); err == nil {
+		t.Error("CleanupCredentialFile() should refuse to remove the project's own .env")
+	}
+	if HasCredentialFile(tmpDir) {
+		t.Error("HasCredentialFile() = true for a file claude-quick didn't write")
+	}
+	// Nor is it injected into tmux sessions as credentials
+	if got := ReadCredentialFile(tmpDir); len(got) != 0 {
+		t.Errorf("ReadCredentialFile() = %v, want nothing from the project's own .env", got)
+	}
+	if content, _ := os.ReadFile(filePath); string(content) != original {
+		t.Errorf(".env = %q, want it unchanged", content)
+	}
+}
+
+func TestWriteCredentialFile_NestedName(t *testing.T) {
+	SetCredentialFile("config/creds/.netrc", FormatNetrc)
+	defer SetCredentialFile("", "")
+
+	tmpDir := t.TempDir()
+	if err := WriteCredentialFile(tmpDir, map[string]string{"github.com": "token"}); err != nil {
+		t.Fatalf("WriteCredentialFile() returned error: %v", err)
+	}
+	if got := ReadCredentialFile(tmpDir); len(got) != 1 || got["github.com"] != "token" {
+		t.Errorf("ReadCredentialFile() = %v, want only github.com", got)
+	}
+	// Rewriting replaces the file claude-quick wrote
+	if err := WriteCredentialFile(tmpDir, map[string]string{"github.com": "other"}); err != nil {
+		t.Errorf("WriteCredentialFile() over its own file returned error: %v", err)
+	}
+	if err := CleanupCredentialFile(tmpDir); err != nil || HasCredentialFile(tmpDir) {
+		t.Errorf("CleanupCredentialFile() = %v, want the file removed", err)
+	}
+}
+
+func TestHasCredentialFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if HasCredentialFile(tmpDir) {
+		t.Error("HasCredentialFile() = true before the file is written")
+	}
+	if err := WriteCredentialFile(tmpDir, map[string]string{"TOKEN": "x"}); err != nil {
+		t.Fatalf("WriteCredentialFile() returned error: %v", err)
+	}
+	if !HasCredentialFile(tmpDir) {
+		t.Error("HasCredentialFile() = false after the file is written")
+	}
+}
+
+func TestCredentialFile_Formats(t *testing.T) {
+	defer SetCredentialFile("", "")
+
+	creds := map[string]string{"github.com": "octocat:ghp_secret", "API_KEY": `it's "quoted"`}
+	tests := []struct {
+		format CredentialFormat
+		name   string
+		want   []string
+	}{
+		{FormatExport, "", []string{`export API_KEY='it'"'"'s "quoted"'`}},
+		{FormatDotenv, ".env", []string{`API_KEY="it's \"quoted\""`, `github.com="octocat:ghp_secret"`}},
+		{FormatNetrc, ".netrc", []string{"machine github.com login octocat password ghp_secret"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			SetCredentialFile(tt.name, tt.format)
+			want := creds
+			if tt.format == FormatNetrc {
+				want = map[string]string{"github.com": "octocat:ghp_secret", "host": "token"}
+			}
+			tmpDir := t.TempDir()
+			if err := WriteCredentialFile(tmpDir, want); err != nil {
+				t.Fatalf("WriteCredentialFile() returned error: %v", err)
+			}
+
+			fileName := tt.name
+			if fileName == "" {
+				fileName = CredFileName
+			}
+			content, err := os.ReadFile(filepath.Join(tmpDir, fileName))
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", fileName, err)
+			}
+			for _, line := range tt.want {
+				if !strings.Contains(string(content), line) {
+					t.Errorf("file content = %q, should contain %q", content, line)
+				}
+			}
+
+			// Values read back unchanged, so tmux injection works in every format
+			got := ReadCredentialFile(tmpDir)
+			if len(got) != len(want) {
+				t.Errorf("ReadCredentialFile() = %v, want %v", got, want)
+			}
+			for name, value := range want {
+				if got[name] != value {
+					t.Errorf("ReadCredentialFile()[%s] = %q, want %q", name, got[name], value)
+				}
+			}
+
+			if err := CleanupCredentialFile(tmpDir); err != nil {
+				t.Fatalf("CleanupCredentialFile() returned error: %v", err)
+			}
+			if HasCredentialFile(tmpDir) {
+				t.Errorf("CleanupCredentialFile() left %s behind", fileName)
+			}
+		})
+	}
+}
+
+func TestWriteCredentialFile_NetrcRejectsWhitespace(t *testing.T) {
+	SetCredentialFile("", FormatNetrc)
+	defer SetCredentialFile("", "")
+
+	if err := WriteCredentialFile(t.TempDir(), map[string]string{"host": "two words"}); err == nil {
+		t.Error("WriteCredentialFile() should reject a netrc value with whitespace")
+	}
+}
+
+func TestValidateNetrcMachines(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"host names", Config{
+			Credentials: []Credential{{Name: "github.com"}, {Name: "localhost"}},
+			Projects:    map[string]ProjectAuth{"app": {Credentials: []Credential{{Name: "registry.example-corp.io"}}}},
+		}, false},
+		{"env style name", Config{Credentials: []Credential{{Name: "GITHUB_TOKEN"}}}, true},
+		{"single label", Config{Credentials: []Credential{{Name: "TOKEN"}}}, true},
+		{"project env style name", Config{
+			Projects: map[string]ProjectAuth{"app": {Credentials: []Credential{{Name: "API_KEY"}}}},
+		}, true},
+		{"trailing dot", Config{Credentials: []Credential{{Name: "github.com."}}}, true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateNetrcMachines(tt.cfg)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: ValidateNetrcMachines() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateCredentialFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  CredentialFormat
+		wantErr bool
+	}{
+		{"", "", false},
+		{".env", FormatDotenv, false},
+		{"config/.netrc", FormatNetrc, false},
+		{".env", "yaml", true},
+		{"/home/user/.netrc", "", true},
+		{"../outside", "", true},
+		{".", "", true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateCredentialFile(tt.name, tt.format)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateCredentialFile(%q, %q) error = %v, wantErr %v", tt.name, tt.format, err, tt.wantErr)
+		}
+	}
+}
@@ -15,6 +15,14 @@ type ResolveResult struct {
 	Credentials map[string]string
 	// Errors contains resolution errors keyed by credential name.
 	Errors map[string]error
+	// Failed lists the credentials that failed to resolve, in config order.
+	Failed []CredentialError
+}
+
+// CredentialError pairs a credential with the reason it failed to resolve.
+type CredentialError struct {
+	Credential Credential
+	Err        error
 }
 
 // HasErrors returns true if any credentials failed to resolve.
@@ -58,6 +66,7 @@ func (c *Config) Resolve(projectName string) *ResolveResult {
 		value, err := resolveCredential(cred)
 		if err != nil {
 			result.Errors[cred.Name] = err
+			result.Failed = append(result.Failed, CredentialError{Credential: cred, Err: err})
 			continue
 		}
 		if value != "" {
@@ -247,6 +247,32 @@ func TestConfig_Resolve_FileSource_NotFound(t *testing.T) {
 	}
 }
 
+func TestConfig_Resolve_Failed(t *testing.T) {
+	os.Unsetenv("NONEXISTENT_TEST_VAR")
+	t.Setenv("SET_TEST_VAR", "value")
+
+	config := &Config{
+		Credentials: []Credential{
+			{Name: "MISSING_FILE", Source: SourceFile, Value: "/nonexistent/path/to/credential"},
+			{Name: "PRESENT", Source: SourceEnv, Value: "SET_TEST_VAR"},
+			{Name: "MISSING_ENV", Source: SourceEnv, Value: "NONEXISTENT_TEST_VAR"},
+		},
+	}
+
+	result := config.Resolve("anyproject")
+
+	if len(result.Failed) != 2 {
+		t.Fatalf("len(Failed) = %d, want 2", len(result.Failed))
+	}
+	if result.Failed[0].Credential.Name != "MISSING_FILE" || result.Failed[1].Credential.Name != "MISSING_ENV" {
+		t.Errorf("Failed should list credentials in config order, got %s, %s",
+			result.Failed[0].Credential.Name, result.Failed[1].Credential.Name)
+	}
+	if result.Failed[1].Credential.Source != SourceEnv || result.Failed[1].Err == nil {
+		t.Errorf("Failed entry should carry the source and error, got %+v", result.Failed[1])
+	}
+}
+
 func TestConfig_Resolve_FileSource_WithTilde(t *testing.T) {
 	// Create a temp file in a known location
 	tmpDir, err := os.MkdirTemp("", "test-tilde-*")
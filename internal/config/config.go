@@ -3,8 +3,11 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/christophergyman/claude-quick/internal/auth"
 	"github.com/christophergyman/claude-quick/internal/constants"
@@ -22,6 +25,15 @@ const (
 	ConfigSourceDefault                        // No config file found, using defaults
 )
 
+// ValueSource describes where a setting's effective value came from
+type ValueSource string
+
+const (
+	ValueFromFile    ValueSource = "file"    // Set in the config file
+	ValueFromDefault ValueSource = "default" // Not set (or blank/invalid), so the built-in default applies
+	ValueFromProfile ValueSource = "profile" // Search paths of the active profile
+)
+
 // configInfo holds information about the resolved config location
 var configInfo struct {
 	Path   string
@@ -31,15 +43,126 @@ var configInfo struct {
 // Config holds the application configuration
 type Config struct {
 	SearchPaths        []string      `yaml:"search_paths"`
+	DefaultProfile     string        `yaml:"default_profile,omitempty"`
 	MaxDepth           int           `yaml:"max_depth"`
 	ExcludedDirs       []string      `yaml:"excluded_dirs"`
+	FastDiscovery      *bool         `yaml:"fast_discovery,omitempty"`
+	FollowSymlinks     *bool         `yaml:"follow_symlinks,omitempty"`
+	GitOnly            *bool         `yaml:"git_only,omitempty"`
+	MaxInstances       int           `yaml:"max_instances,omitempty"`
 	DefaultSessionName string        `yaml:"default_session_name"`
+	SessionFromBranch  *bool         `yaml:"session_name_from_branch,omitempty"`
 	ContainerTimeout   int           `yaml:"container_timeout_seconds"`
+	UpArgs             []string      `yaml:"devcontainer_up_args,omitempty"`
+	OverrideConfig     string        `yaml:"devcontainer_override_config,omitempty"`
 	LaunchCommand      string        `yaml:"launch_command,omitempty"`
+	LaunchFirstOnly    *bool         `yaml:"launch_command_first_only,omitempty"`
+	PostCreateCommand  string        `yaml:"worktree_post_create_command,omitempty"`
+	UsePostAttach      *bool         `yaml:"use_post_attach_command,omitempty"`
+	TmuxWindowName     string        `yaml:"tmux_window_name,omitempty"`
+	PresetSessions     []string      `yaml:"preset_sessions,omitempty"`
+	TmuxStartDir       string        `yaml:"tmux_start_dir,omitempty"`
+	TmuxAttachMouse    *bool         `yaml:"tmux_attach_mouse,omitempty"`
 	DarkMode           *bool         `yaml:"dark_mode,omitempty"`
+	PersistTheme       *bool         `yaml:"persist_theme_toggle,omitempty"`
+	SuppressLegacyWarn *bool         `yaml:"suppress_legacy_warning,omitempty"`
+	Theme              ThemeConfig   `yaml:"theme,omitempty"`
 	AutoPushWorktree   *bool         `yaml:"auto_push_worktree,omitempty"`
+	WorktreePushRemote string        `yaml:"worktree_push_remote,omitempty"`
+	NestWorktreeDirs   *bool         `yaml:"nest_worktree_dirs,omitempty"`
+	DeleteBranch       *bool         `yaml:"delete_branch_with_worktree,omitempty"`
+	ReservedBranches   []string      `yaml:"reserved_branches,omitempty"`
+	AutoAttach         *bool         `yaml:"auto_attach_after_create,omitempty"`
+	AutoAttachSingle   *bool         `yaml:"auto_attach_single_session,omitempty"`
+	PreserveTilde      *bool         `yaml:"preserve_tilde,omitempty"`
+	ContainerLabelKey  string        `yaml:"container_label_key,omitempty"`
+	Favorites          []string      `yaml:"favorites,omitempty"`
+	DashboardLayout    string        `yaml:"dashboard_layout,omitempty"`
+	GroupWorktrees     *bool         `yaml:"group_worktrees,omitempty"`
+	FullPaths          *bool         `yaml:"full_paths,omitempty"`
+	ShowWorktrees      *bool         `yaml:"show_worktrees,omitempty"`
+	ShowSessionCounts  *bool         `yaml:"show_session_counts,omitempty"`
+	ShowLastCommit     *bool         `yaml:"show_last_commit,omitempty"`
+	CredentialFile     string        `yaml:"credential_file_name,omitempty"`
+	CredentialFormat   string        `yaml:"credential_file_format,omitempty"`
+	FocusRefresh       int           `yaml:"refresh_on_focus_seconds,omitempty"`
+	ContainerShell     string        `yaml:"container_shell,omitempty"`
 	Auth               auth.Config   `yaml:"auth,omitempty"`
 	GitHub             github.Config `yaml:"github,omitempty"`
+
+	// ProjectAliases maps project paths to names shown instead of the directory name
+	ProjectAliases map[string]string `yaml:"project_aliases,omitempty"`
+
+	// Profiles are named sets of search paths used instead of search_paths
+	// when selected with --profile, default_profile or the profile switcher
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+
+	// SessionEnv holds non-secret environment variables set in new tmux sessions
+	SessionEnv map[string]string `yaml:"session_env,omitempty"`
+
+	activeProfile   string          // Profile whose search paths are in SearchPaths ("" for none)
+	baseSearchPaths []string        // The top-level search_paths while a profile is active
+	fileKeys        map[string]bool // Top-level keys whose file value is in effect
+}
+
+// Profile is a named set of search paths (e.g., "work" and "personal")
+type Profile struct {
+	SearchPaths []string `yaml:"search_paths"`
+}
+
+// ThemeConfig holds optional color overrides merged onto the base dark/light palette.
+// Each value is a hex color string (e.g., "#E07A5F"); empty values keep the base color.
+type ThemeConfig struct {
+	Orange    string `yaml:"orange,omitempty"`
+	Primary   string `yaml:"primary,omitempty"`
+	Dim       string `yaml:"dim,omitempty"`
+	Success   string `yaml:"success,omitempty"`
+	Warning   string `yaml:"warning,omitempty"`
+	Error     string `yaml:"error,omitempty"`
+	Separator string `yaml:"separator,omitempty"`
+}
+
+// Sanitize clears any color that is not a valid hex string and returns
+// a warning for each value that was dropped
+func (t *ThemeConfig) Sanitize() []string {
+	fields := []struct {
+		name  string
+		value *string
+	}{
+		{"orange", &t.Orange},
+		{"primary", &t.Primary},
+		{"dim", &t.Dim},
+		{"success", &t.Success},
+		{"warning", &t.Warning},
+		{"error", &t.Error},
+		{"separator", &t.Separator},
+	}
+
+	var warnings []string
+	for _, f := range fields {
+		if *f.value == "" || IsValidHexColor(*f.value) {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("theme.%s: invalid hex color %q (ignored)", f.name, *f.value))
+		*f.value = ""
+	}
+	return warnings
+}
+
+// IsValidHexColor reports whether s is a hex color in #RGB or #RRGGBB form
+func IsValidHexColor(s string) bool {
+	if len(s) != 4 && len(s) != 7 {
+		return false
+	}
+	if s[0] != '#' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if !((r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')) {
+			return false
+		}
+	}
+	return true
 }
 
 // DefaultExcludedDirs returns the default directories to exclude from scanning
@@ -55,10 +178,31 @@ func DefaultConfig() *Config {
 		ExcludedDirs:       DefaultExcludedDirs(),
 		DefaultSessionName: constants.DefaultSessionName,
 		ContainerTimeout:   constants.DefaultContainerTimeout,
+		ContainerLabelKey:  constants.DefaultContainerLabelKey,
+		WorktreePushRemote: constants.DefaultWorktreePushRemote,
+		DashboardLayout:    constants.DashboardLayoutComfortable,
 		GitHub:             github.DefaultConfig(),
 	}
 }
 
+// DetectLikelyProjectRoots returns common code directories (e.g., ~/projects) that
+// exist in the user's home directory, in tilde form. Returns nil if none exist.
+func DetectLikelyProjectRoots() []string {
+	return detectProjectRoots(util.HomeDir())
+}
+
+// detectProjectRoots returns the common project roots that exist under homeDir
+func detectProjectRoots(homeDir string) []string {
+	var roots []string
+	for _, name := range constants.CommonProjectRoots() {
+		info, err := os.Stat(filepath.Join(homeDir, name))
+		if err == nil && info.IsDir() {
+			roots = append(roots, "~/"+name)
+		}
+	}
+	return roots
+}
+
 // executableDir returns the directory containing the resolved executable
 // (follows symlinks to find the real location)
 func executableDir() (string, error) {
@@ -116,7 +260,8 @@ func configPath() (string, ConfigSource) {
 // Load reads the configuration from the config file
 // Falls back to defaults if the file doesn't exist
 // Prints deprecation warning if using legacy location
-func Load() (*Config, error) {
+// profile selects a profile's search paths; "" uses default_profile, if set
+func Load(profile string) (*Config, error) {
 	cfg := DefaultConfig()
 
 	path, source := configPath()
@@ -131,53 +276,147 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
-	// Print deprecation warning if using legacy location
-	if source == ConfigSourceLegacy {
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	cfg.fileKeys = topLevelKeys(data)
+
+	// Print deprecation warning if using legacy location, unless silenced
+	// in the legacy file itself
+	if source == ConfigSourceLegacy && !cfg.IsSuppressLegacyWarning() {
 		targetPath := path
 		if execDir, err := executableDir(); err == nil {
 			targetPath = filepath.Join(execDir, "claude-quick.yaml")
 		}
 		fmt.Fprintf(os.Stderr, "Warning: Config loaded from deprecated location %s\n", path)
-		fmt.Fprintf(os.Stderr, "         Consider moving to %s\n\n", targetPath)
+		fmt.Fprintf(os.Stderr, "         Run claude-quick --migrate-config to copy it to %s,\n", targetPath)
+		fmt.Fprintf(os.Stderr, "         or set suppress_legacy_warning: true to hide this warning\n\n")
 	}
 
-	if err := yaml.Unmarshal(data, cfg); err != nil {
-		return nil, err
-	}
+	// Expand ~ in paths and drop duplicates so discovery doesn't walk the
+	// same directories twice. Nested paths are kept: their own max_depth
+	// can reach deeper than the parent's walk, or below an excluded dir.
+	cfg.SearchPaths = NormalizeSearchPaths(cfg.SearchPaths, false)
 
-	// Expand ~ in paths
-	for i, p := range cfg.SearchPaths {
-		cfg.SearchPaths[i] = util.ExpandPath(p)
+	// Use the requested (or default) profile's search paths over the base ones
+	if profile == "" {
+		profile = cfg.DefaultProfile
+	}
+	if err := cfg.ApplyProfile(profile); err != nil {
+		return nil, err
 	}
 
 	// Ensure reasonable defaults
 	if cfg.MaxDepth <= 0 {
 		cfg.MaxDepth = constants.DefaultMaxDepth
+		cfg.usedDefault("max_depth")
+	}
+	if cfg.MaxInstances < 0 {
+		cfg.MaxInstances = 0 // No limit
+	}
+	if cfg.FocusRefresh < 0 {
+		cfg.FocusRefresh = 0 // Off
 	}
 
 	// Use default excluded dirs if none specified
 	if len(cfg.ExcludedDirs) == 0 {
 		cfg.ExcludedDirs = DefaultExcludedDirs()
+		cfg.usedDefault("excluded_dirs")
 	}
 
 	// Ensure default session name
 	if cfg.DefaultSessionName == "" {
 		cfg.DefaultSessionName = constants.DefaultSessionName
+		cfg.usedDefault("default_session_name")
 	}
 
 	// Ensure reasonable timeout (minimum 30 seconds, max 30 minutes)
 	if cfg.ContainerTimeout <= 0 {
 		cfg.ContainerTimeout = constants.DefaultContainerTimeout
+		cfg.usedDefault("container_timeout_seconds")
 	} else if cfg.ContainerTimeout < constants.MinContainerTimeout {
 		cfg.ContainerTimeout = constants.MinContainerTimeout
 	} else if cfg.ContainerTimeout > constants.MaxContainerTimeout {
 		cfg.ContainerTimeout = constants.MaxContainerTimeout
 	}
 
+	// Fall back to the standard devcontainer label if the key is blank or malformed
+	labelKey, ok := resolveContainerLabelKey(cfg.ContainerLabelKey)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Warning: container_label_key %q is invalid, using %s\n", cfg.ContainerLabelKey, labelKey)
+	}
+	if !ok || strings.TrimSpace(cfg.ContainerLabelKey) == "" {
+		cfg.usedDefault("container_label_key")
+	}
+	cfg.ContainerLabelKey = labelKey
+
+	// Drop blank and repeated preset session names
+	cfg.PresetSessions = normalizeNames(cfg.PresetSessions)
+	cfg.ReservedBranches = normalizeNames(cfg.ReservedBranches)
+
+	// Drop session env vars that a shell couldn't reference
+	for name := range cfg.SessionEnv {
+		if !isEnvName(name) {
+			fmt.Fprintf(os.Stderr, "Warning: session_env: %q is not a valid variable name and was ignored\n", name)
+			delete(cfg.SessionEnv, name)
+		}
+	}
+
+	// Push new worktree branches to origin unless configured otherwise
+	cfg.WorktreePushRemote = strings.TrimSpace(cfg.WorktreePushRemote)
+	if cfg.WorktreePushRemote == "" {
+		cfg.WorktreePushRemote = constants.DefaultWorktreePushRemote
+		cfg.usedDefault("worktree_push_remote")
+	}
+
+	// An absolute override config must exist; a relative one is looked up
+	// in each project and only applies where it's present
+	cfg.OverrideConfig = strings.TrimSpace(cfg.OverrideConfig)
+	if cfg.OverrideConfig != "" {
+		cfg.OverrideConfig = util.ExpandPath(cfg.OverrideConfig)
+		if filepath.IsAbs(cfg.OverrideConfig) {
+			if info, err := os.Stat(cfg.OverrideConfig); err != nil || info.IsDir() {
+				return nil, fmt.Errorf("devcontainer_override_config: %s is not a file", cfg.OverrideConfig)
+			}
+		}
+	}
+
+	// Drop extra devcontainer up flags that would conflict with the ones we set
+	reserved := reservedUpFlags
+	if cfg.OverrideConfig != "" {
+		reserved = append(slices.Clone(reserved), "--override-config")
+	}
+	upArgs, dropped := filterUpArgs(cfg.UpArgs, reserved)
+	for _, flag := range dropped {
+		fmt.Fprintf(os.Stderr, "Warning: devcontainer_up_args: %s is set by claude-quick and was ignored\n", flag)
+	}
+	cfg.UpArgs = upArgs
+
+	// Fall back to the comfortable layout for blank or unknown values
+	layout, ok := resolveDashboardLayout(cfg.DashboardLayout)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Warning: dashboard_layout %q is not recognized, using %s\n", cfg.DashboardLayout, layout)
+		cfg.usedDefault("dashboard_layout")
+	}
+	cfg.DashboardLayout = layout
+
+	// Drop invalid theme colors so the base palette is used instead
+	for _, w := range cfg.Theme.Sanitize() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+
 	// Validate auth configuration
 	if err := cfg.Auth.Validate(); err != nil {
 		return nil, err
 	}
+	if err := auth.ValidateCredentialFile(cfg.CredentialFile, auth.CredentialFormat(cfg.CredentialFormat)); err != nil {
+		return nil, err
+	}
+	if auth.CredentialFormat(cfg.CredentialFormat) == auth.FormatNetrc {
+		if err := auth.ValidateNetrcMachines(cfg.Auth); err != nil {
+			return nil, err
+		}
+	}
 
 	// Ensure GitHub config has sensible defaults
 	if cfg.GitHub.MaxIssues <= 0 {
@@ -198,10 +437,86 @@ func Load() (*Config, error) {
 	if cfg.GitHub.LabelDescription == "" {
 		cfg.GitHub.LabelDescription = constants.DefaultLabelDescription
 	}
+	if cfg.GitHub.FetchTimeoutSeconds <= 0 {
+		cfg.GitHub.FetchTimeoutSeconds = constants.DefaultGitHubTimeout
+	}
 
 	return cfg, nil
 }
 
+// MigrateLegacyConfig copies the legacy config file to the executable
+// directory, where it takes priority from then on. The file is copied
+// verbatim so comments survive; the legacy file is left in place.
+// Returns the new path.
+func MigrateLegacyConfig() (string, error) {
+	legacyPath := legacyConfigPath()
+	if _, err := os.Stat(legacyPath); err != nil {
+		return "", fmt.Errorf("no legacy config found at %s", legacyPath)
+	}
+	execDir, err := executableDir()
+	if err != nil {
+		return "", err
+	}
+	newPath := filepath.Join(execDir, "claude-quick.yaml")
+	if err := migrateConfig(legacyPath, newPath); err != nil {
+		return "", err
+	}
+	configInfo.Path = newPath
+	configInfo.Source = ConfigSourceExecutable
+	return newPath, nil
+}
+
+// InitConfig writes a default config next to the executable, for setup
+// without the wizard (scripts, dotfiles). Search paths are the common project
+// roots found in the home directory, as the wizard suggests. An existing
+// config is only replaced when force is set. Returns the path written.
+func InitConfig(force bool) (string, error) {
+	path, err := GetExecutableDirConfigPath()
+	if err != nil {
+		return "", err
+	}
+	if existing, source := configPath(); source != ConfigSourceDefault && !force {
+		return "", fmt.Errorf("config already exists at %s (use --force to replace it)", existing)
+	}
+	if err := writeInitialConfig(path, util.HomeDir()); err != nil {
+		return "", err
+	}
+	configInfo.Path = path
+	configInfo.Source = ConfigSourceExecutable
+	return path, nil
+}
+
+// writeInitialConfig saves the default config to path, searching the
+// project roots under homeDir (or all of homeDir if there are none)
+func writeInitialConfig(path, homeDir string) error {
+	cfg := DefaultConfig()
+	cfg.SearchPaths = []string{homeDir}
+	if roots := detectProjectRoots(homeDir); len(roots) > 0 {
+		cfg.SearchPaths = roots
+	}
+	return Save(cfg, path)
+}
+
+// migrateConfig copies the config at from to to, refusing to overwrite an existing file
+func migrateConfig(from, to string) error {
+	data, err := os.ReadFile(from)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	file, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("config already exists at %s", to)
+		}
+		return fmt.Errorf("failed to create config file: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return file.Close()
+}
+
 // ConfigPath returns the path where the config file is/should be located
 func ConfigPath() string {
 	if configInfo.Path != "" {
@@ -221,7 +536,17 @@ func IsUsingLegacyConfig() bool {
 	return configInfo.Source == ConfigSourceLegacy
 }
 
-// IsDarkMode returns the dark mode setting, defaulting to true if not set
+// IsSuppressLegacyWarning returns whether the warning about loading config from
+// the legacy ~/.config location is hidden
+func (c *Config) IsSuppressLegacyWarning() bool {
+	if c.SuppressLegacyWarn == nil {
+		return false // Default: warn until the config is migrated
+	}
+	return *c.SuppressLegacyWarn
+}
+
+// IsDarkMode returns the dark mode setting, defaulting to true if not set.
+// The TUI detects the terminal background instead when it is unset.
 func (c *Config) IsDarkMode() bool {
 	if c.DarkMode == nil {
 		return true // Default to dark mode for backwards compatibility
@@ -229,6 +554,33 @@ func (c *Config) IsDarkMode() bool {
 	return *c.DarkMode
 }
 
+// IsPersistThemeToggle returns whether toggling the theme with t saves the
+// new dark_mode value to the config file
+func (c *Config) IsPersistThemeToggle() bool {
+	if c.PersistTheme == nil {
+		return true // Default: the toggle survives restarts
+	}
+	return *c.PersistTheme
+}
+
+// IsShowSessionCounts returns whether the dashboard counts tmux sessions in
+// running containers on every status refresh
+func (c *Config) IsShowSessionCounts() bool {
+	if c.ShowSessionCounts == nil {
+		return true // Default: counts are shown next to running containers
+	}
+	return *c.ShowSessionCounts
+}
+
+// IsShowLastCommit returns whether the dashboard shows how long ago each git
+// instance's last commit was made
+func (c *Config) IsShowLastCommit() bool {
+	if c.ShowLastCommit == nil {
+		return true // Default: show it
+	}
+	return *c.ShowLastCommit
+}
+
 // IsAutoPushWorktree returns whether to auto-push new worktree branches upstream
 func (c *Config) IsAutoPushWorktree() bool {
 	if c.AutoPushWorktree == nil {
@@ -237,6 +589,360 @@ func (c *Config) IsAutoPushWorktree() bool {
 	return *c.AutoPushWorktree
 }
 
+// AutoPushRemote returns the remote new worktree branches are pushed to,
+// or "" when auto-push is disabled
+func (c *Config) AutoPushRemote() string {
+	if !c.IsAutoPushWorktree() {
+		return ""
+	}
+	if c.WorktreePushRemote == "" {
+		return constants.DefaultWorktreePushRemote
+	}
+	return c.WorktreePushRemote
+}
+
+// IsNestWorktreeDirs returns whether worktree directories mirror the branch
+// hierarchy (repo-worktrees/feature/auth) instead of being flattened (repo-feature-auth)
+func (c *Config) IsNestWorktreeDirs() bool {
+	if c.NestWorktreeDirs == nil {
+		return false // Default: flat sibling directories
+	}
+	return *c.NestWorktreeDirs
+}
+
+// IsDeleteBranchWithWorktree returns whether deleting a worktree also deletes
+// its local branch (git worktree remove on its own keeps the branch)
+func (c *Config) IsDeleteBranchWithWorktree() bool {
+	if c.DeleteBranch == nil {
+		return false // Default: keep the branch
+	}
+	return *c.DeleteBranch
+}
+
+// IsTmuxAttachMouse returns whether mouse mode is turned on for a session
+// when attaching to it, for scrolling its history with the wheel
+func (c *Config) IsTmuxAttachMouse() bool {
+	if c.TmuxAttachMouse == nil {
+		return false // Default: leave the session's mouse setting alone
+	}
+	return *c.TmuxAttachMouse
+}
+
+// IsFastDiscovery returns whether discovery only looks at each search path and
+// its immediate children instead of walking down to max_depth
+func (c *Config) IsFastDiscovery() bool {
+	if c.FastDiscovery == nil {
+		return false // Default: recursive walk
+	}
+	return *c.FastDiscovery
+}
+
+// IsFollowSymlinks returns whether discovery descends into symlinked directories
+func (c *Config) IsFollowSymlinks() bool {
+	if c.FollowSymlinks == nil {
+		return false // Default: skip symlinks, as filepath.WalkDir does
+	}
+	return *c.FollowSymlinks
+}
+
+// IsShowWorktrees returns whether every git worktree gets its own dashboard
+// entry; when false only main repositories are listed
+func (c *Config) IsShowWorktrees() bool {
+	if c.ShowWorktrees == nil {
+		return true // Default: one instance per worktree
+	}
+	return *c.ShowWorktrees
+}
+
+// IsGitOnly returns whether discovery skips projects outside git repositories
+func (c *Config) IsGitOnly() bool {
+	if c.GitOnly == nil {
+		return false // Default: list non-git projects too
+	}
+	return *c.GitOnly
+}
+
+// IsAutoAttachAfterCreate returns whether to create and attach to the default
+// session after auto-starting a worktree created from an issue
+func (c *Config) IsAutoAttachAfterCreate() bool {
+	if c.AutoAttach == nil {
+		return false // Default: stop at the session list
+	}
+	return *c.AutoAttach
+}
+
+// IsAutoAttachSingleSession returns whether pressing enter on a running
+// container with exactly one tmux session attaches to it directly
+func (c *Config) IsAutoAttachSingleSession() bool {
+	if c.AutoAttachSingle == nil {
+		return false // Default: always show the session list
+	}
+	return *c.AutoAttachSingle
+}
+
+// IsUsePostAttachCommand returns whether a project's devcontainer.json
+// postAttachCommand is used as the launch command when none is configured
+func (c *Config) IsUsePostAttachCommand() bool {
+	if c.UsePostAttach == nil {
+		return false // Default: only configured launch commands run
+	}
+	return *c.UsePostAttach
+}
+
+// IsLaunchCommandFirstOnly returns whether the launch command only runs in the first
+// session of a container, leaving later sessions as a plain shell
+func (c *Config) IsLaunchCommandFirstOnly() bool {
+	if c.LaunchFirstOnly == nil {
+		return false // Default: run the launch command in every new session
+	}
+	return *c.LaunchFirstOnly
+}
+
+// IsSessionNameFromBranch returns whether the default session of a worktree
+// is named after its branch instead of DefaultSessionName
+func (c *Config) IsSessionNameFromBranch() bool {
+	if c.SessionFromBranch == nil {
+		return false // Default: always use DefaultSessionName
+	}
+	return *c.SessionFromBranch
+}
+
+// resolveContainerLabelKey returns the label key to use for container lookup.
+// Blank keys silently use the default; keys containing "=" or spaces can't form a
+// valid docker label filter, so they also use the default and report ok=false.
+func resolveContainerLabelKey(key string) (string, bool) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return constants.DefaultContainerLabelKey, true
+	}
+	if strings.ContainsAny(key, "= \t") {
+		return constants.DefaultContainerLabelKey, false
+	}
+	return key, true
+}
+
+// reservedUpFlags are devcontainer up flags claude-quick sets itself
+var reservedUpFlags = []string{"--workspace-folder", "--mount"}
+
+// filterUpArgs removes reserved flags (and their values) from extra
+// devcontainer up args, returning the remaining args and the dropped flags
+func filterUpArgs(args, reserved []string) (kept, dropped []string) {
+	for i := 0; i < len(args); i++ {
+		name, _, hasValue := strings.Cut(args[i], "=")
+		if !slices.Contains(reserved, name) {
+			kept = append(kept, args[i])
+			continue
+		}
+		dropped = append(dropped, name)
+		// Skip the separate value of "--flag value"
+		if !hasValue && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			i++
+		}
+	}
+	return kept, dropped
+}
+
+// resolveDashboardLayout returns the dashboard layout to use.
+// Blank values silently use the comfortable layout; unknown values also do and report ok=false.
+func resolveDashboardLayout(layout string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(layout)) {
+	case "", constants.DashboardLayoutComfortable:
+		return constants.DashboardLayoutComfortable, true
+	case constants.DashboardLayoutCompact:
+		return constants.DashboardLayoutCompact, true
+	}
+	return constants.DashboardLayoutComfortable, false
+}
+
+// IsGroupWorktrees returns whether worktrees of the same repository are shown together
+func (c *Config) IsGroupWorktrees() bool {
+	if c.GroupWorktrees == nil {
+		return false // Default: discovery order
+	}
+	return *c.GroupWorktrees
+}
+
+// IsFullPaths returns whether the dashboard shows paths untruncated, even if
+// lines overflow the terminal width
+func (c *Config) IsFullPaths() bool {
+	if c.FullPaths == nil {
+		return false // Default: truncate paths to fit the width
+	}
+	return *c.FullPaths
+}
+
+// IsCompactDashboard returns whether the dashboard renders one line per instance
+func (c *Config) IsCompactDashboard() bool {
+	return c.DashboardLayout == constants.DashboardLayoutCompact
+}
+
+// IsFavorite returns whether the project at path is pinned to the top of the dashboard
+func (c *Config) IsFavorite(path string) bool {
+	for _, f := range c.Favorites {
+		if f == path {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleFavorite pins or unpins the project at path and returns whether it is now a favorite
+func (c *Config) ToggleFavorite(path string) bool {
+	for i, f := range c.Favorites {
+		if f == path {
+			c.Favorites = append(c.Favorites[:i:i], c.Favorites[i+1:]...)
+			return false
+		}
+	}
+	c.Favorites = append(c.Favorites, path)
+	return true
+}
+
+// IsPreserveTilde returns whether saved search paths keep the ~ form
+// instead of being stored as expanded absolute paths
+func (c *Config) IsPreserveTilde() bool {
+	if c.PreserveTilde == nil {
+		return false // Default: store expanded paths
+	}
+	return *c.PreserveTilde
+}
+
+// ProfileNames returns the names of the configured profiles in sorted order
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Source reports where the effective value of a top-level setting (by its
+// YAML key) came from. Only meaningful for configs returned by Load.
+func (c *Config) Source(key string) ValueSource {
+	if key == "search_paths" && c.activeProfile != "" {
+		return ValueFromProfile
+	}
+	if c.fileKeys[key] {
+		return ValueFromFile
+	}
+	return ValueFromDefault
+}
+
+// FileKeys returns the sorted top-level keys whose values come from the config file
+func (c *Config) FileKeys() []string {
+	return slices.Sorted(maps.Keys(c.fileKeys))
+}
+
+// usedDefault records that the built-in default replaced a key's file value
+func (c *Config) usedDefault(key string) {
+	delete(c.fileKeys, key)
+}
+
+// topLevelKeys returns the keys set to a non-null value at the top of a YAML document
+func topLevelKeys(data []byte) map[string]bool {
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+	keys := make(map[string]bool, len(raw))
+	for key, node := range raw {
+		if node.Tag != "!!null" {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// ActiveProfile returns the name of the profile whose search paths are in
+// use, or "" when the top-level search_paths are
+func (c *Config) ActiveProfile() string {
+	return c.activeProfile
+}
+
+// BaseSearchPaths returns the top-level search_paths, whichever profile is active
+func (c *Config) BaseSearchPaths() []string {
+	if c.activeProfile == "" {
+		return c.SearchPaths
+	}
+	return c.baseSearchPaths
+}
+
+// ApplyProfile switches SearchPaths to the named profile's search paths,
+// or back to the top-level search_paths when name is "".
+// Returns an error naming the configured profiles if name is unknown.
+func (c *Config) ApplyProfile(name string) error {
+	if name == "" {
+		c.SearchPaths = c.BaseSearchPaths()
+		c.activeProfile, c.baseSearchPaths = "", nil
+		return nil
+	}
+	profile, ok := c.Profiles[name]
+	if !ok && len(c.Profiles) == 0 {
+		return fmt.Errorf("unknown profile %q: no profiles are configured", name)
+	}
+	if !ok {
+		return fmt.Errorf("unknown profile %q (configured: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+	c.baseSearchPaths = c.BaseSearchPaths()
+	c.SearchPaths = NormalizeSearchPaths(profile.SearchPaths, false)
+	c.activeProfile = name
+	return nil
+}
+
+// NormalizeSearchPaths returns the canonical form of a search path list:
+// ~ is expanded, each path is cleaned, and empty entries and duplicates are dropped.
+// If preserveTilde is true, paths under the home directory are stored as ~/...
+func NormalizeSearchPaths(paths []string, preserveTilde bool) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, p := range paths {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		p = filepath.Clean(util.ExpandPath(p))
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		if preserveTilde {
+			p = util.CollapsePath(p)
+		}
+		normalized = append(normalized, p)
+	}
+	return normalized
+}
+
+// normalizeNames trims names (preset sessions, reserved branches), dropping blanks and duplicates
+func normalizeNames(names []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		out = append(out, name)
+	}
+	return out
+}
+
+// isEnvName reports whether name is a valid environment variable name
+// (letters, digits and underscores, not starting with a digit)
+func isEnvName(name string) bool {
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return false
+	}
+	for _, r := range name {
+		if r != '_' && (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
 // ConfigExists returns true if a config file exists (either new or legacy location)
 func ConfigExists() bool {
 	_, source := configPath()
@@ -254,6 +960,7 @@ func GetExecutableDirConfigPath() (string, error) {
 }
 
 // Save writes the configuration to the specified path
+// Search paths are normalized (see NormalizeSearchPaths) before writing
 func Save(cfg *Config, path string) error {
 	// Ensure parent directory exists
 	dir := filepath.Dir(path)
@@ -261,8 +968,18 @@ func Save(cfg *Config, path string) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
+	// Store search paths in canonical form (without modifying the caller's config).
+	// While a profile is active its paths are saved to the profile, not search_paths.
+	out := *cfg
+	out.SearchPaths = NormalizeSearchPaths(cfg.SearchPaths, cfg.IsPreserveTilde())
+	if cfg.activeProfile != "" {
+		out.Profiles = maps.Clone(cfg.Profiles)
+		out.Profiles[cfg.activeProfile] = Profile{SearchPaths: out.SearchPaths}
+		out.SearchPaths = NormalizeSearchPaths(cfg.baseSearchPaths, cfg.IsPreserveTilde())
+	}
+
 	// Marshal config to YAML
-	data, err := yaml.Marshal(cfg)
+	data, err := yaml.Marshal(&out)
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
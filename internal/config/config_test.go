@@ -3,9 +3,13 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/christophergyman/claude-quick/internal/constants"
+	"github.com/christophergyman/claude-quick/internal/util"
+	"gopkg.in/yaml.v3"
 )
 
 func TestDefaultExcludedDirs(t *testing.T) {
@@ -98,7 +102,7 @@ func TestLoad_NonexistentFile(t *testing.T) {
 	// This test assumes the config file doesn't exist in the test environment
 	// or will use a temp directory
 
-	cfg, err := Load()
+	cfg, err := Load("")
 
 	// Should not error for non-existent file
 	if err != nil {
@@ -276,7 +280,7 @@ func TestConfigPath_Priority(t *testing.T) {
 
 func TestGetConfigSource(t *testing.T) {
 	// After Load() is called, GetConfigSource should return a valid source
-	_, err := Load()
+	_, err := Load("")
 	if err != nil {
 		t.Logf("Load() returned error (may be expected): %v", err)
 	}
@@ -296,3 +300,763 @@ func TestIsUsingLegacyConfig(t *testing.T) {
 	isLegacy := IsUsingLegacyConfig()
 	t.Logf("IsUsingLegacyConfig() = %v", isLegacy)
 }
+
+func TestIsValidHexColor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"#E07A5F", true},
+		{"#e07a5f", true},
+		{"#FFF", true},
+		{"E07A5F", false},
+		{"#E07A5", false},
+		{"#GGGGGG", false},
+		{"", false},
+		{"red", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := IsValidHexColor(tt.input); got != tt.expected {
+				t.Errorf("IsValidHexColor(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestThemeConfig_Sanitize(t *testing.T) {
+	theme := ThemeConfig{
+		Orange:  "#FF8800",
+		Success: "green",
+		Error:   "#12345",
+	}
+
+	warnings := theme.Sanitize()
+
+	if len(warnings) != 2 {
+		t.Errorf("Sanitize() returned %d warnings, want 2: %v", len(warnings), warnings)
+	}
+	if theme.Orange != "#FF8800" {
+		t.Errorf("Orange = %q, valid color should be kept", theme.Orange)
+	}
+	if theme.Success != "" {
+		t.Errorf("Success = %q, invalid color should be cleared", theme.Success)
+	}
+	if theme.Error != "" {
+		t.Errorf("Error = %q, invalid color should be cleared", theme.Error)
+	}
+}
+
+func TestDetectProjectRoots(t *testing.T) {
+	home := t.TempDir()
+	for _, dir := range []string{"projects", "work"} {
+		if err := os.Mkdir(filepath.Join(home, dir), 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", dir, err)
+		}
+	}
+	// A file with a candidate name should not be treated as a root
+	if err := os.WriteFile(filepath.Join(home, "src"), []byte("x"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	roots := detectProjectRoots(home)
+
+	expected := []string{"~/projects", "~/work"}
+	if len(roots) != len(expected) {
+		t.Fatalf("detectProjectRoots() = %v, want %v", roots, expected)
+	}
+	for i := range expected {
+		if roots[i] != expected[i] {
+			t.Errorf("roots[%d] = %q, want %q", i, roots[i], expected[i])
+		}
+	}
+}
+
+func TestDetectProjectRoots_None(t *testing.T) {
+	if roots := detectProjectRoots(t.TempDir()); len(roots) != 0 {
+		t.Errorf("detectProjectRoots() = %v, want none", roots)
+	}
+}
+
+func TestConfig_IsPersistThemeToggle(t *testing.T) {
+	tests := []struct {
+		name     string
+		persist  *bool
+		expected bool
+	}{
+		{"nil defaults to true", nil, true},
+		{"explicit true", boolPtr(true), true},
+		{"explicit false", boolPtr(false), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{PersistTheme: tt.persist}
+			if got := cfg.IsPersistThemeToggle(); got != tt.expected {
+				t.Errorf("IsPersistThemeToggle() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_IsShowSessionCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		show     *bool
+		expected bool
+	}{
+		{"nil defaults to true", nil, true},
+		{"explicit true", boolPtr(true), true},
+		{"explicit false", boolPtr(false), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{ShowSessionCounts: tt.show}
+			if got := cfg.IsShowSessionCounts(); got != tt.expected {
+				t.Errorf("IsShowSessionCounts() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_IsDeleteBranchWithWorktree(t *testing.T) {
+	tests := []struct {
+		name     string
+		del      *bool
+		expected bool
+	}{
+		{"nil defaults to false", nil, false},
+		{"explicit true", boolPtr(true), true},
+		{"explicit false", boolPtr(false), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{DeleteBranch: tt.del}
+			if got := cfg.IsDeleteBranchWithWorktree(); got != tt.expected {
+				t.Errorf("IsDeleteBranchWithWorktree() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_IsTmuxAttachMouse(t *testing.T) {
+	tests := []struct {
+		name     string
+		mouse    *bool
+		expected bool
+	}{
+		{"nil defaults to false", nil, false},
+		{"explicit true", boolPtr(true), true},
+		{"explicit false", boolPtr(false), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{TmuxAttachMouse: tt.mouse}
+			if got := cfg.IsTmuxAttachMouse(); got != tt.expected {
+				t.Errorf("IsTmuxAttachMouse() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_IsFastDiscovery(t *testing.T) {
+	tests := []struct {
+		name     string
+		fast     *bool
+		expected bool
+	}{
+		{"nil defaults to false", nil, false},
+		{"explicit true", boolPtr(true), true},
+		{"explicit false", boolPtr(false), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{FastDiscovery: tt.fast}
+			if got := cfg.IsFastDiscovery(); got != tt.expected {
+				t.Errorf("IsFastDiscovery() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_IsFollowSymlinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		follow   *bool
+		expected bool
+	}{
+		{"nil defaults to false", nil, false},
+		{"explicit true", boolPtr(true), true},
+		{"explicit false", boolPtr(false), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{FollowSymlinks: tt.follow}
+			if got := cfg.IsFollowSymlinks(); got != tt.expected {
+				t.Errorf("IsFollowSymlinks() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_IsFullPaths(t *testing.T) {
+	if (&Config{}).IsFullPaths() {
+		t.Error("IsFullPaths() should default to false")
+	}
+	if !(&Config{FullPaths: boolPtr(true)}).IsFullPaths() {
+		t.Error("IsFullPaths() = false, want true when set")
+	}
+}
+
+func TestConfig_IsShowWorktrees(t *testing.T) {
+	tests := []struct {
+		name     string
+		show     *bool
+		expected bool
+	}{
+		{"nil defaults to true", nil, true},
+		{"explicit true", boolPtr(true), true},
+		{"explicit false", boolPtr(false), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{ShowWorktrees: tt.show}
+			if got := cfg.IsShowWorktrees(); got != tt.expected {
+				t.Errorf("IsShowWorktrees() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_IsAutoAttachSingleSession(t *testing.T) {
+	tests := []struct {
+		name     string
+		single   *bool
+		expected bool
+	}{
+		{"nil defaults to false", nil, false},
+		{"explicit true", boolPtr(true), true},
+		{"explicit false", boolPtr(false), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{AutoAttachSingle: tt.single}
+			if got := cfg.IsAutoAttachSingleSession(); got != tt.expected {
+				t.Errorf("IsAutoAttachSingleSession() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_IsAutoAttachAfterCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		autoAttach *bool
+		expected   bool
+	}{
+		{"nil defaults to false", nil, false},
+		{"explicit true", boolPtr(true), true},
+		{"explicit false", boolPtr(false), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{AutoAttach: tt.autoAttach}
+			if got := cfg.IsAutoAttachAfterCreate(); got != tt.expected {
+				t.Errorf("IsAutoAttachAfterCreate() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_AutoPushRemote(t *testing.T) {
+	tests := []struct {
+		name     string
+		autoPush *bool
+		remote   string
+		expected string
+	}{
+		{"defaults to origin", nil, "", "origin"},
+		{"configured remote", nil, "upstream", "upstream"},
+		{"disabled", boolPtr(false), "upstream", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{AutoPushWorktree: tt.autoPush, WorktreePushRemote: tt.remote}
+			if got := cfg.AutoPushRemote(); got != tt.expected {
+				t.Errorf("AutoPushRemote() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_IsLaunchCommandFirstOnly(t *testing.T) {
+	tests := []struct {
+		name      string
+		firstOnly *bool
+		expected  bool
+	}{
+		{"nil defaults to false", nil, false},
+		{"explicit true", boolPtr(true), true},
+		{"explicit false", boolPtr(false), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{LaunchFirstOnly: tt.firstOnly}
+			if got := cfg.IsLaunchCommandFirstOnly(); got != tt.expected {
+				t.Errorf("IsLaunchCommandFirstOnly() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeSearchPaths(t *testing.T) {
+	home := util.HomeDir()
+
+	tests := []struct {
+		name          string
+		paths         []string
+		preserveTilde bool
+		want          []string
+	}{
+		{
+			name:  "expands and cleans",
+			paths: []string{"~/projects/", "/opt//code/../src"},
+			want:  []string{filepath.Join(home, "projects"), "/opt/src"},
+		},
+		{
+			name:  "drops empty and duplicate entries",
+			paths: []string{"", "  ", "~/projects", filepath.Join(home, "projects"), "~/projects/"},
+			want:  []string{filepath.Join(home, "projects")},
+		},
+		{
+			name:          "preserves tilde under home",
+			paths:         []string{filepath.Join(home, "projects"), "~/code/", "/opt/src"},
+			preserveTilde: true,
+			want:          []string{"~/projects", "~/code", "/opt/src"},
+		},
+		{
+			name:  "empty list",
+			paths: nil,
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeSearchPaths(tt.paths, tt.preserveTilde)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizeSearchPaths(%q) = %q, want %q", tt.paths, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSave_NormalizesSearchPaths(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "claude-quick.yaml")
+	cfg := &Config{SearchPaths: []string{"~/projects", "~/projects/", ""}}
+
+	if err := Save(cfg, path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	// Caller's config is left untouched
+	if len(cfg.SearchPaths) != 3 {
+		t.Errorf("Save() modified caller's SearchPaths: %q", cfg.SearchPaths)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	if got := strings.Count(string(data), filepath.Join(util.HomeDir(), "projects")); got != 1 {
+		t.Errorf("saved config lists the search path %d times, want 1:\n%s", got, data)
+	}
+}
+
+func TestConfig_ApplyProfile(t *testing.T) {
+	cfg := &Config{
+		SearchPaths: []string{"/base"},
+		Profiles: map[string]Profile{
+			"work":     {SearchPaths: []string{"/work", "/work/", "/clients"}},
+			"personal": {SearchPaths: []string{"/personal"}},
+		},
+	}
+
+	if err := cfg.ApplyProfile("work"); err != nil {
+		t.Fatalf("ApplyProfile(work) error = %v", err)
+	}
+	if want := []string{"/work", "/clients"}; !reflect.DeepEqual(cfg.SearchPaths, want) {
+		t.Errorf("SearchPaths = %q, want %q", cfg.SearchPaths, want)
+	}
+	if cfg.ActiveProfile() != "work" {
+		t.Errorf("ActiveProfile() = %q, want work", cfg.ActiveProfile())
+	}
+
+	// Switching between profiles keeps the original base paths
+	if err := cfg.ApplyProfile("personal"); err != nil {
+		t.Fatalf("ApplyProfile(personal) error = %v", err)
+	}
+	if want := []string{"/base"}; !reflect.DeepEqual(cfg.BaseSearchPaths(), want) {
+		t.Errorf("BaseSearchPaths() = %q, want %q", cfg.BaseSearchPaths(), want)
+	}
+
+	if err := cfg.ApplyProfile(""); err != nil {
+		t.Fatalf("ApplyProfile(\"\") error = %v", err)
+	}
+	if want := []string{"/base"}; !reflect.DeepEqual(cfg.SearchPaths, want) || cfg.ActiveProfile() != "" {
+		t.Errorf("SearchPaths = %q, profile = %q, want the base paths", cfg.SearchPaths, cfg.ActiveProfile())
+	}
+
+	err := cfg.ApplyProfile("missing")
+	if err == nil || !strings.Contains(err.Error(), "personal, work") {
+		t.Errorf("ApplyProfile(missing) error = %v, want one listing the profiles", err)
+	}
+}
+
+func TestSave_ActiveProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "claude-quick.yaml")
+	cfg := &Config{
+		SearchPaths: []string{"/base"},
+		Profiles:    map[string]Profile{"work": {SearchPaths: []string{"/work"}}},
+	}
+	if err := cfg.ApplyProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+	cfg.SearchPaths = append(cfg.SearchPaths, "/added")
+
+	if err := Save(cfg, path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got := cfg.Profiles["work"].SearchPaths; len(got) != 1 {
+		t.Errorf("Save() modified caller's profiles: %q", got)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved Config
+	if err := yaml.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"/base"}; !reflect.DeepEqual(saved.SearchPaths, want) {
+		t.Errorf("saved search_paths = %q, want %q", saved.SearchPaths, want)
+	}
+	if want := []string{"/work", "/added"}; !reflect.DeepEqual(saved.Profiles["work"].SearchPaths, want) {
+		t.Errorf("saved work profile = %q, want %q", saved.Profiles["work"].SearchPaths, want)
+	}
+}
+
+func TestNormalizeNames(t *testing.T) {
+	got := normalizeNames([]string{" main ", "", "logs", "main", "  "})
+	if want := []string{"main", "logs"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeNames() = %q, want %q", got, want)
+	}
+}
+
+func TestLoad_DedupesSearchPaths(t *testing.T) {
+	home := util.HomeDir()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	path := legacyConfigPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := "search_paths:\n" +
+		"  - ~/projects\n" +
+		"  - " + filepath.Join(home, "projects") + "\n" +
+		"  - ~/projects/client\n" +
+		"  - /opt/src\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if resolved, _ := configPath(); resolved != path {
+		t.Skip("a config next to the test binary takes precedence")
+	}
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	// The nested path stays: it may be walked deeper than its parent
+	want := []string{filepath.Join(home, "projects"), filepath.Join(home, "projects", "client"), "/opt/src"}
+	if !reflect.DeepEqual(cfg.SearchPaths, want) {
+		t.Errorf("SearchPaths = %q, want %q", cfg.SearchPaths, want)
+	}
+}
+
+func TestLoad_ValueSources(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	path := legacyConfigPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if resolved, _ := configPath(); resolved != path {
+		t.Skip("a config next to the test binary takes precedence")
+	}
+	data := "suppress_legacy_warning: true\n" +
+		"search_paths: [" + dir + "]\n" +
+		"max_depth: 0\n" + // Falls back to the default
+		"container_timeout_seconds: 120\n" +
+		"dashboard_layout: wide\n" + // Unrecognized
+		"launch_command:\n" + // Null
+		"profiles:\n  work:\n    search_paths: [" + dir + "]\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	tests := map[string]ValueSource{
+		"search_paths":              ValueFromFile,
+		"container_timeout_seconds": ValueFromFile,
+		"max_depth":                 ValueFromDefault,
+		"dashboard_layout":          ValueFromDefault,
+		"launch_command":            ValueFromDefault,
+		"default_session_name":      ValueFromDefault,
+	}
+	for key, want := range tests {
+		if got := cfg.Source(key); got != want {
+			t.Errorf("Source(%q) = %q, want %q", key, got, want)
+		}
+	}
+	want := []string{"container_timeout_seconds", "profiles", "search_paths", "suppress_legacy_warning"}
+	if got := cfg.FileKeys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("FileKeys() = %v, want %v", got, want)
+	}
+
+	// The active profile supplies the search paths
+	if err := cfg.ApplyProfile("work"); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Source("search_paths"); got != ValueFromProfile {
+		t.Errorf("Source(search_paths) = %q with a profile, want %q", got, ValueFromProfile)
+	}
+}
+
+func TestLoad_OverrideConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	path := legacyConfigPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if resolved, _ := configPath(); resolved != path {
+		t.Skip("a config next to the test binary takes precedence")
+	}
+	load := func(data string) (*Config, error) {
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return Load("")
+	}
+
+	missing := filepath.Join(dir, "missing.json")
+	if _, err := load("devcontainer_override_config: " + missing + "\n"); err == nil {
+		t.Error("Load() should fail when an absolute override config doesn't exist")
+	}
+
+	// Relative paths are checked per project, so they load as-is, and the
+	// override's own flag is dropped from the extra up args
+	cfg, err := load("devcontainer_override_config: .devcontainer/local.json\n" +
+		"devcontainer_up_args: [--override-config, /other.json, --log-level, debug]\n")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.OverrideConfig != ".devcontainer/local.json" {
+		t.Errorf("OverrideConfig = %q, want .devcontainer/local.json", cfg.OverrideConfig)
+	}
+	if want := []string{"--log-level", "debug"}; !reflect.DeepEqual(cfg.UpArgs, want) {
+		t.Errorf("UpArgs = %q, want %q", cfg.UpArgs, want)
+	}
+}
+
+func TestIsEnvName(t *testing.T) {
+	tests := map[string]bool{
+		"RUST_LOG": true,
+		"_private": true,
+		"node18":   true,
+		"":         false,
+		"1ST":      false,
+		"MY-VAR":   false,
+		"A B":      false,
+	}
+	for name, want := range tests {
+		if got := isEnvName(name); got != want {
+			t.Errorf("isEnvName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestWriteInitialConfig(t *testing.T) {
+	home := t.TempDir()
+	path := filepath.Join(t.TempDir(), "claude-quick.yaml")
+
+	// Without any common project roots the whole home directory is searched
+	if err := writeInitialConfig(path, home); err != nil {
+		t.Fatalf("writeInitialConfig() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("written config doesn't parse: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.SearchPaths, []string{home}) || cfg.MaxDepth != constants.DefaultMaxDepth {
+		t.Errorf("config = %+v, want the defaults searching %s", cfg, home)
+	}
+
+	// Detected roots replace the home directory
+	root := constants.CommonProjectRoots()[0]
+	if err := os.Mkdir(filepath.Join(home, root), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeInitialConfig(path, home); err != nil {
+		t.Fatalf("writeInitialConfig() error = %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if !strings.Contains(string(data), root) || strings.Contains(string(data), "- "+home+"\n") {
+		t.Errorf("expected only the detected root %s in search_paths:\n%s", root, data)
+	}
+}
+
+func TestResolveContainerLabelKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		want   string
+		wantOK bool
+	}{
+		{"empty uses default", "", constants.DefaultContainerLabelKey, true},
+		{"whitespace uses default", "   ", constants.DefaultContainerLabelKey, true},
+		{"custom key", "com.example.workspace", "com.example.workspace", true},
+		{"trims spaces", " com.example.workspace ", "com.example.workspace", true},
+		{"equals sign rejected", "a=b", constants.DefaultContainerLabelKey, false},
+		{"inner space rejected", "my label", constants.DefaultContainerLabelKey, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := resolveContainerLabelKey(tt.key)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("resolveContainerLabelKey(%q) = (%q, %v), want (%q, %v)", tt.key, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestResolveDashboardLayout(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout string
+		want   string
+		wantOK bool
+	}{
+		{"empty uses comfortable", "", constants.DashboardLayoutComfortable, true},
+		{"comfortable", "comfortable", constants.DashboardLayoutComfortable, true},
+		{"compact", "compact", constants.DashboardLayoutCompact, true},
+		{"case and spaces ignored", " Compact ", constants.DashboardLayoutCompact, true},
+		{"unknown uses comfortable", "dense", constants.DashboardLayoutComfortable, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := resolveDashboardLayout(tt.layout)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("resolveDashboardLayout(%q) = (%q, %v), want (%q, %v)", tt.layout, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestConfig_ToggleFavorite(t *testing.T) {
+	cfg := &Config{}
+
+	if !cfg.ToggleFavorite("/src/a") || !cfg.IsFavorite("/src/a") {
+		t.Error("first toggle should pin the project")
+	}
+	cfg.ToggleFavorite("/src/b")
+	if cfg.ToggleFavorite("/src/a") || cfg.IsFavorite("/src/a") {
+		t.Error("second toggle should unpin the project")
+	}
+	if !reflect.DeepEqual(cfg.Favorites, []string{"/src/b"}) {
+		t.Errorf("Favorites = %q, want [/src/b]", cfg.Favorites)
+	}
+}
+
+func TestFilterUpArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantKept    []string
+		wantDropped []string
+	}{
+		{"no conflicts", []string{"--gpu-availability", "all", "--cache-from", "img"}, []string{"--gpu-availability", "all", "--cache-from", "img"}, nil},
+		{"separate value", []string{"--workspace-folder", "/x", "--gpu-availability", "all"}, []string{"--gpu-availability", "all"}, []string{"--workspace-folder"}},
+		{"inline value", []string{"--mount=type=bind,source=/a,target=/a", "--log-level", "debug"}, []string{"--log-level", "debug"}, []string{"--mount"}},
+		{"flag without value", []string{"--mount", "--remove-existing-container"}, []string{"--remove-existing-container"}, []string{"--mount"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, dropped := filterUpArgs(tt.args, reservedUpFlags)
+			if !reflect.DeepEqual(kept, tt.wantKept) || !reflect.DeepEqual(dropped, tt.wantDropped) {
+				t.Errorf("filterUpArgs() = %q, %q; want %q, %q", kept, dropped, tt.wantKept, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestMigrateConfig(t *testing.T) {
+	dir := t.TempDir()
+	from := filepath.Join(dir, "config.yaml")
+	to := filepath.Join(dir, "claude-quick.yaml")
+	content := "# my settings\nsearch_paths:\n  - ~/projects\n"
+	if err := os.WriteFile(from, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write legacy config: %v", err)
+	}
+
+	if err := migrateConfig(from, to); err != nil {
+		t.Fatalf("migrateConfig() error = %v", err)
+	}
+	data, err := os.ReadFile(to)
+	if err != nil {
+		t.Fatalf("failed to read migrated config: %v", err)
+	}
+	if string(data) != content {
+		t.Errorf("migrated config = %q, want an exact copy %q", data, content)
+	}
+
+	// An existing config is never overwritten
+	if err := migrateConfig(from, to); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("migrateConfig() onto an existing file = %v, want already exists error", err)
+	}
+}
+
+func TestConfig_IsSuppressLegacyWarning(t *testing.T) {
+	cfg := &Config{}
+	if err := yaml.Unmarshal([]byte("suppress_legacy_warning: true\n"), cfg); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	if !cfg.IsSuppressLegacyWarning() {
+		t.Error("IsSuppressLegacyWarning() = false, want true when set")
+	}
+	if (&Config{}).IsSuppressLegacyWarning() {
+		t.Error("IsSuppressLegacyWarning() should default to false")
+	}
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// discoverySnapshotPath returns the file holding the instance paths found by
+// the last discovery run over each set of search paths
+func discoverySnapshotPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "claude-quick", "snapshot.json"), nil
+}
+
+// snapshotKey identifies a set of search paths in the snapshot file
+func snapshotKey(searchPaths []string) string {
+	return strings.Join(searchPaths, string(os.PathListSeparator))
+}
+
+// readDiscoverySnapshots loads the snapshot file, empty if it is missing or unreadable
+func readDiscoverySnapshots() map[string][]string {
+	all := make(map[string][]string)
+	path, err := discoverySnapshotPath()
+	if err != nil {
+		return all
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &all)
+	}
+	return all
+}
+
+// LoadDiscoverySnapshot returns the instance paths found by the last
+// discovery run over searchPaths, as a set; ok is false if there was none
+func LoadDiscoverySnapshot(searchPaths []string) (paths map[string]bool, ok bool) {
+	list, ok := readDiscoverySnapshots()[snapshotKey(searchPaths)]
+	if !ok {
+		return nil, false
+	}
+	paths = make(map[string]bool, len(list))
+	for _, p := range list {
+		paths[p] = true
+	}
+	return paths, true
+}
+
+// SaveDiscoverySnapshot records the instance paths found over searchPaths,
+// replacing the previous snapshot for them
+func SaveDiscoverySnapshot(searchPaths, paths []string) error {
+	path, err := discoverySnapshotPath()
+	if err != nil {
+		return err
+	}
+	all := readDiscoverySnapshots()
+	all[snapshotKey(searchPaths)] = slices.Sorted(slices.Values(paths))
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to save discovery snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to save discovery snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiscoverySnapshot(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	searchPaths := []string{"/src", "/work"}
+
+	if _, ok := LoadDiscoverySnapshot(searchPaths); ok {
+		t.Fatal("LoadDiscoverySnapshot() found a snapshot before any was saved")
+	}
+	if err := SaveDiscoverySnapshot(searchPaths, []string{"/src/b", "/src/a"}); err != nil {
+		t.Fatalf("SaveDiscoverySnapshot() error = %v", err)
+	}
+	if err := SaveDiscoverySnapshot([]string{"/other"}, []string{"/other/c"}); err != nil {
+		t.Fatalf("SaveDiscoverySnapshot() error = %v", err)
+	}
+
+	want := map[string]bool{"/src/a": true, "/src/b": true}
+	if got, ok := LoadDiscoverySnapshot(searchPaths); !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadDiscoverySnapshot() = %v, %v; want %v, true", got, ok, want)
+	}
+
+	// A run that found nothing is still a snapshot
+	if err := SaveDiscoverySnapshot(searchPaths, nil); err != nil {
+		t.Fatalf("SaveDiscoverySnapshot() error = %v", err)
+	}
+	if got, ok := LoadDiscoverySnapshot(searchPaths); !ok || len(got) != 0 {
+		t.Errorf("LoadDiscoverySnapshot() = %v, %v; want an empty snapshot", got, ok)
+	}
+}
@@ -7,6 +7,9 @@ const (
 	DefaultContainerTimeout = 300  // Default timeout for container operations
 	MinContainerTimeout     = 30   // Minimum allowed timeout
 	MaxContainerTimeout     = 1800 // Maximum allowed timeout (30 minutes)
+	DockerDaemonTimeout     = 10   // Timeout for the startup docker daemon check
+	PostCreateTimeout       = 600  // Timeout for worktree_post_create_command
+	LastCommitTimeout       = 5    // Timeout for reading a worktree's last commit time on refresh
 )
 
 // Discovery constants
@@ -14,10 +17,28 @@ const (
 	DefaultMaxDepth = 3 // Default directory search depth
 )
 
+// Container identification constants
+const (
+	DefaultContainerLabelKey = "devcontainer.local_folder" // Docker label holding a container's workspace folder
+)
+
+// Worktree constants
+const (
+	DefaultWorktreePushRemote = "origin" // Remote new worktree branches are pushed to
+)
+
+// Dashboard layout constants
+const (
+	DashboardLayoutComfortable = "comfortable" // Two lines per instance with spacing between entries
+	DashboardLayoutCompact     = "compact"     // One line per instance with no spacing
+)
+
 // Text input UI constants
 const (
-	TextInputCharLimit = 50 // Character limit for text input fields
-	TextInputWidth     = 30 // Width of text input fields in characters
+	TextInputCharLimit = 50  // Character limit for text input fields
+	TextInputWidth     = 30  // Width of text input fields in characters
+	CloneURLCharLimit  = 256 // Character limit for the repository URL input
+	CloneURLInputWidth = 60  // Width of the repository URL input in characters
 )
 
 // Display constants
@@ -25,6 +46,38 @@ const (
 	SHATruncateLength      = 7  // Length for truncated git SHA display
 	DefaultPathTruncateLen = 40 // Default max length for path display
 	PathTruncatePadding    = 6  // Padding to subtract from width for path display
+	MinCompactPathWidth    = 12 // Narrowest inline path shown in the compact dashboard
+	ContainerLogTailLines  = 500 // Log lines loaded by the in-TUI viewer when no pager is available
+	ScrollViewChrome       = 12 // Lines used by header/footer around scrollable lists
+	MinScrollViewRows      = 5  // Minimum visible rows in scrollable lists
+	MaxListedSessions      = 5  // Beyond this many, confirm dialogs summarize sessions as a count
+	MaxConfigViewBytes     = 1 << 20 // devcontainer.json bytes shown by the viewer; the rest is cut off
+)
+
+// Transient status message constants
+const (
+	FlashMessageSeconds = 2 // How long a transient status message (e.g., "copied") stays visible
+)
+
+// Theme constants
+const (
+	ThemeSaveDelayMs = 500 // A theme toggle is saved once no further toggle arrives within this delay
+)
+
+// Quick stop constants
+const (
+	StopDoublePressMs = 400 // A second x within this window of the first stops without confirming
+)
+
+// Container shell constants
+const (
+	DefaultContainerShell  = "/bin/bash" // Shell opened by the shell key when container_shell is unset
+	FallbackContainerShell = "/bin/sh"   // Used when the configured shell isn't in the container
+)
+
+// Activity log constants
+const (
+	MaxEventLogEntries = 200 // Oldest events are dropped beyond this many
 )
 
 // Devcontainer file and directory names
@@ -53,6 +106,12 @@ const (
 	DefaultBranchUnknown     = "unknown"
 )
 
+// Worktree directory layout
+const (
+	NestedWorktreeDirSuffix = "-worktrees" // Parent dir for nested worktrees (e.g., repo-worktrees/feature/auth)
+	MaxWorktreePathSuffix   = 100          // Highest numeric suffix tried when a worktree dir name collides
+)
+
 // DefaultExcludedDirs returns the default directories to exclude from scanning
 func DefaultExcludedDirs() []string {
 	return []string{
@@ -68,6 +127,18 @@ func DefaultExcludedDirs() []string {
 	}
 }
 
+// CommonProjectRoots returns home-relative directory names commonly used for code,
+// checked on first run to pre-populate search paths
+func CommonProjectRoots() []string {
+	return []string{
+		"code",
+		"projects",
+		"src",
+		"dev",
+		"work",
+	}
+}
+
 // GitHub integration constants
 const (
 	DefaultMaxIssues        = 50                                  // Maximum number of issues to fetch
@@ -76,4 +147,7 @@ const (
 	DefaultInProgressLabel  = "in-progress"                       // Default label for issues being worked on
 	DefaultLabelColor       = "fbca04"                            // Yellow color for in-progress label
 	DefaultLabelDescription = "Issue is being actively worked on" // Description for auto-created label
+	DefaultGitHubTimeout    = 30                                  // Seconds to wait for gh before giving up
+	GitHubAuthCheckTimeout  = 10                                  // Seconds to wait for gh auth status
+	MinIssueTitleWidth      = 10                                  // Issue titles are never cut shorter than this
 )
@@ -93,6 +93,7 @@ func TestConstants(t *testing.T) {
 		{"TextInputWidth", TextInputWidth, 1, 100},
 		{"SHATruncateLength", SHATruncateLength, 4, 40},
 		{"DefaultPathTruncateLen", DefaultPathTruncateLen, 10, 200},
+		{"DockerDaemonTimeout", DockerDaemonTimeout, 1, DefaultContainerTimeout},
 	}
 
 	for _, tt := range tests {
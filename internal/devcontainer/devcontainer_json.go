@@ -0,0 +1,134 @@
+package devcontainer
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// devcontainerJSON holds the devcontainer.json fields claude-quick reads
+type devcontainerJSON struct {
+	PostAttachCommand json.RawMessage `json:"postAttachCommand"`
+	WorkspaceFolder   string          `json:"workspaceFolder"`
+}
+
+// ReadPostAttachCommand returns the postAttachCommand from a devcontainer.json
+// as a single shell command line. Array commands are joined with spaces;
+// object (parallel) commands and unreadable files return "".
+func ReadPostAttachCommand(configPath string) string {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return ""
+	}
+	var cfg devcontainerJSON
+	if err := json.Unmarshal(stripJSONC(data), &cfg); err != nil {
+		return ""
+	}
+	return commandLine(cfg.PostAttachCommand)
+}
+
+// readWorkspaceFolder returns the workspaceFolder from a devcontainer.json,
+// "" if it doesn't set one; ok is false if the file can't be read or parsed.
+func readWorkspaceFolder(configPath string) (folder string, ok bool) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return "", false
+	}
+	var cfg devcontainerJSON
+	if err := json.Unmarshal(stripJSONC(data), &cfg); err != nil {
+		return "", false
+	}
+	return cfg.WorkspaceFolder, true
+}
+
+// commandLine converts a lifecycle command (string or argument array) to a shell command line
+func commandLine(raw json.RawMessage) string {
+	var command string
+	if err := json.Unmarshal(raw, &command); err == nil {
+		return strings.TrimSpace(command)
+	}
+	var args []string
+	if err := json.Unmarshal(raw, &args); err == nil {
+		quoted := make([]string, len(args))
+		for i, arg := range args {
+			quoted[i] = shellQuote(arg)
+		}
+		return strings.Join(quoted, " ")
+	}
+	return ""
+}
+
+// shellQuote single-quotes arg if it contains characters the shell would interpret
+func shellQuote(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\$`|&;<>()*?[]{}~#!") {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// stripJSONC converts JSON with comments (as used by devcontainer.json) to
+// plain JSON by removing // and /* */ comments and trailing commas.
+// String contents are left untouched.
+func stripJSONC(data []byte) []byte {
+	var out bytes.Buffer
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			out.WriteByte(c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out.WriteByte(data[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out.WriteByte(c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out.WriteByte('\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++ // Skip the closing '/'
+		case c == ',' && nextSignificant(data, i+1) != 0 && strings.IndexByte("]}", nextSignificant(data, i+1)) >= 0:
+			// Drop trailing comma
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.Bytes()
+}
+
+// nextSignificant returns the next non-whitespace byte outside comments
+// starting at i, or 0 at the end of data
+func nextSignificant(data []byte, i int) byte {
+	for i < len(data) {
+		switch {
+		case data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r':
+			i++
+		case data[i] == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+		case data[i] == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i += 2
+		default:
+			return data[i]
+		}
+	}
+	return 0
+}
@@ -0,0 +1,56 @@
+package devcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadPostAttachCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"string", `{"postAttachCommand": "npm run dev"}`, "npm run dev"},
+		{"array", `{"postAttachCommand": ["bash", "-c", "echo hi"]}`, "bash -c 'echo hi'"},
+		{"object is ignored", `{"postAttachCommand": {"server": "npm start", "watch": "npm run watch"}}`, ""},
+		{"missing", `{"name": "app"}`, ""},
+		{
+			"comments and trailing commas",
+			`{
+	// Started when a session attaches
+	"name": "app // not a comment",
+	/* block
+	   comment */
+	"postAttachCommand": "make dev", // trailing
+	"features": {"ghcr.io/x": {},},
+}`,
+			"make dev",
+		},
+		{"invalid json", `{"postAttachCommand": `, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "devcontainer.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write devcontainer.json: %v", err)
+			}
+			if got := ReadPostAttachCommand(path); got != tt.want {
+				t.Errorf("ReadPostAttachCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := ReadPostAttachCommand(filepath.Join(t.TempDir(), "missing.json")); got != "" {
+		t.Errorf("ReadPostAttachCommand(missing file) = %q, want empty", got)
+	}
+}
+
+func TestStripJSONC_PreservesStrings(t *testing.T) {
+	in := `{"url": "https://example.com/a,b", "glob": "/* not a comment */", "esc": "quote \" // still string"}`
+	if got := string(stripJSONC([]byte(in))); got != in {
+		t.Errorf("stripJSONC() = %q, want strings untouched %q", got, in)
+	}
+}
@@ -1,64 +1,246 @@
 package devcontainer
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/christophergyman/claude-quick/internal/constants"
+	"github.com/christophergyman/claude-quick/internal/util"
 )
 
 // devcontainerFoundFunc is a callback invoked when a devcontainer.json is found
 // configPath is the full path to devcontainer.json
 // projectPath is the root directory of the project
-type devcontainerFoundFunc func(configPath, projectPath string)
+// Returning false stops the walk, so discovery can end early once it has enough
+type devcontainerFoundFunc func(configPath, projectPath string) bool
+
+// fastDiscovery limits discovery to each search path and its immediate children
+var fastDiscovery bool
+
+// SetFastDiscovery switches discovery between the recursive walk down to
+// max_depth and a single-level scan for flat layouts (~/code/*/)
+func SetFastDiscovery(enabled bool) {
+	fastDiscovery = enabled
+}
+
+// hideWorktrees limits discovery to one instance per repository, its main worktree
+var hideWorktrees bool
+
+// SetShowWorktrees sets whether discovery adds an instance for every git
+// worktree (the default) or only for each repository's main worktree
+func SetShowWorktrees(show bool) {
+	hideWorktrees = !show
+}
+
+// gitOnly makes discovery skip projects that aren't in a git repository
+var gitOnly bool
+
+// SetGitOnly sets whether discovery skips devcontainer projects outside git
+// repositories (templates, examples) instead of listing them without a worktree
+func SetGitOnly(enabled bool) {
+	gitOnly = enabled
+}
+
+// followSymlinks makes discovery descend into symlinked directories
+var followSymlinks bool
+
+// SetFollowSymlinks sets whether discovery descends into symlinked
+// directories, which filepath.WalkDir otherwise skips
+func SetFollowSymlinks(enabled bool) {
+	followSymlinks = enabled
+}
 
 // walkDevcontainerDirs walks through search paths looking for devcontainer.json files
-// and invokes the callback for each one found
-func walkDevcontainerDirs(searchPaths []string, maxDepth int, excludedDirs []string, onFound devcontainerFoundFunc) {
+// and invokes onFound for each one found. onWalk, if not nil, is called for
+// every directory entry visited so callers can report progress.
+func walkDevcontainerDirs(searchPaths []string, maxDepth int, excludedDirs []string, onWalk func(), onFound devcontainerFoundFunc) {
 	// Build exclusion set for O(1) lookup
 	excludeSet := make(map[string]bool, len(excludedDirs))
 	for _, dir := range excludedDirs {
 		excludeSet[dir] = true
 	}
 
+	if onWalk == nil {
+		onWalk = func() {}
+	}
+
+	if fastDiscovery {
+		scanDevcontainerDirs(searchPaths, excludeSet, onWalk, onFound)
+		return
+	}
+
+	// Real paths of the trees walked so far. A followed symlink into one of
+	// them is skipped, which stops cycles and duplicate instances.
+	var walked []string
+	stopped := false
+
 	for _, searchPath := range searchPaths {
-		filepath.WalkDir(searchPath, func(path string, d fs.DirEntry, err error) error {
-			if err != nil {
-				return nil // Skip directories we can't read
-			}
+		if stopped {
+			return
+		}
+		// walk visits the real directory root, reporting paths under
+		// logicalRoot so projects keep the path the user reaches them by
+		var walk func(root, logicalRoot string)
+		walk = func(root, logicalRoot string) {
+			filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+				if stopped {
+					return fs.SkipAll
+				}
+				if err != nil {
+					return nil // Skip directories we can't read
+				}
+				onWalk()
+				if root != logicalRoot {
+					rel, _ := filepath.Rel(root, path)
+					path = filepath.Join(logicalRoot, rel)
+				}
 
-			// Skip hidden directories (except .devcontainer)
-			if d.IsDir() && strings.HasPrefix(d.Name(), ".") && d.Name() != constants.DevcontainerDir {
-				return fs.SkipDir
-			}
+				// A followed symlink counts as the directory it points to, under
+				// the symlink's name
+				name := filepath.Base(path)
+				isDir := d.IsDir()
+				target := ""
+				if followSymlinks && d.Type()&fs.ModeSymlink != 0 {
+					target = symlinkedDir(path)
+					isDir = target != ""
+				}
 
-			// Skip excluded directories
-			if d.IsDir() && excludeSet[d.Name()] {
-				return fs.SkipDir
-			}
+				// Skip hidden directories (except .devcontainer)
+				if isDir && strings.HasPrefix(name, ".") && name != constants.DevcontainerDir {
+					return fs.SkipDir
+				}
 
-			// Check depth
-			relPath, _ := filepath.Rel(searchPath, path)
-			depth := strings.Count(relPath, string(os.PathSeparator))
-			if depth > maxDepth {
-				return fs.SkipDir
-			}
+				// Skip excluded directories
+				if isDir && excludeSet[name] {
+					return fs.SkipDir
+				}
 
-			// Look for devcontainer.json
-			if d.Name() == constants.DevcontainerConfigFile {
-				dir := filepath.Dir(path)
+				// Check depth
+				relPath, _ := filepath.Rel(searchPath, path)
+				depth := strings.Count(relPath, string(os.PathSeparator))
+				if depth > maxDepth {
+					return fs.SkipDir
+				}
 
-				// Only accept .devcontainer/devcontainer.json pattern
-				if filepath.Base(dir) == constants.DevcontainerDir {
-					projectPath := filepath.Dir(dir)
-					onFound(path, projectPath)
+				if target != "" {
+					if !withinAny(target, walked) {
+						walked = append(walked, target)
+						walk(target, path)
+					}
+					if stopped {
+						return fs.SkipAll
+					}
+					return nil
 				}
+
+				// Look for devcontainer.json
+				if name == constants.DevcontainerConfigFile {
+					dir := filepath.Dir(path)
+
+					// Only accept .devcontainer/devcontainer.json pattern
+					if filepath.Base(dir) == constants.DevcontainerDir {
+						projectPath := filepath.Dir(dir)
+						if !onFound(path, projectPath) {
+							stopped = true
+							return fs.SkipAll
+						}
+					}
+				}
+
+				return nil
+			})
+		}
+
+		if !followSymlinks {
+			walk(searchPath, searchPath)
+			continue
+		}
+		root, err := filepath.EvalSymlinks(searchPath)
+		if err != nil || withinAny(root, walked) {
+			continue // Missing, or already walked through another search path
+		}
+		walked = append(walked, root)
+		walk(root, searchPath)
+	}
+}
+
+// symlinkedDir returns the real path of the directory the symlink at path
+// points to, or "" if it is broken or points to a file
+func symlinkedDir(path string) string {
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return ""
+	}
+	if info, err := os.Stat(target); err != nil || !info.IsDir() {
+		return ""
+	}
+	return target
+}
+
+// withinAny reports whether path is one of roots or inside one of them
+func withinAny(path string, roots []string) bool {
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// scanDevcontainerDirs is the fast, non-recursive discovery path: it checks
+// each search path and its immediate children for .devcontainer/devcontainer.json.
+// Hidden and excluded children are skipped, as in the recursive walk.
+func scanDevcontainerDirs(searchPaths []string, excludeSet map[string]bool, onWalk func(), onFound devcontainerFoundFunc) {
+	// Real paths already checked, so a followed symlink to a project
+	// doesn't list it twice
+	checked := make(map[string]bool)
+	// check reports whether the scan should go on
+	check := func(projectPath string) bool {
+		onWalk()
+		if followSymlinks {
+			if real, err := filepath.EvalSymlinks(projectPath); err == nil {
+				if checked[real] {
+					return true
+				}
+				checked[real] = true
 			}
+		}
+		configPath := filepath.Join(projectPath, constants.DevcontainerDir, constants.DevcontainerConfigFile)
+		if info, err := os.Stat(configPath); err == nil && !info.IsDir() {
+			return onFound(configPath, projectPath)
+		}
+		return true
+	}
 
-			return nil
-		})
+	for _, searchPath := range searchPaths {
+		if !check(searchPath) {
+			return
+		}
+
+		entries, err := os.ReadDir(searchPath)
+		if err != nil {
+			continue // Skip search paths we can't read
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			isDir := entry.IsDir()
+			if followSymlinks && entry.Type()&fs.ModeSymlink != 0 {
+				isDir = symlinkedDir(filepath.Join(searchPath, name)) != ""
+			}
+			if !isDir || strings.HasPrefix(name, ".") || excludeSet[name] {
+				continue
+			}
+			if !check(filepath.Join(searchPath, name)) {
+				return
+			}
+		}
 	}
 }
 
@@ -66,14 +248,49 @@ func walkDevcontainerDirs(searchPaths []string, maxDepth int, excludedDirs []str
 // For each project with a devcontainer.json, it finds all git worktrees
 // and adds each worktree as a separate instance
 func DiscoverInstances(searchPaths []string, maxDepth int, excludedDirs []string) []ContainerInstance {
+	instances, _ := DiscoverInstancesLimit(searchPaths, maxDepth, excludedDirs, 0, nil)
+	return instances
+}
+
+// DiscoverInstancesLimit is DiscoverInstances capped at limit instances (0 means
+// no limit). The walk stops as soon as more than limit are found, and truncated
+// reports whether any were left out. onWalk, if not nil, is called from the
+// calling goroutine for every directory entry visited.
+func DiscoverInstancesLimit(searchPaths []string, maxDepth int, excludedDirs []string, limit int, onWalk func()) (instances []ContainerInstance, truncated bool) {
+	instances = discoverInstances(searchPaths, maxDepth, excludedDirs, limit, onWalk)
+	if limit > 0 && len(instances) > limit {
+		instances = instances[:limit]
+		truncated = true
+	}
+
+	// Worktrees share their main repo's devcontainer.json, so read each once
+	postAttach := make(map[string]string)
+	for i := range instances {
+		configPath := instances[i].ConfigPath
+		if _, ok := postAttach[configPath]; !ok {
+			postAttach[configPath] = ReadPostAttachCommand(configPath)
+		}
+		instances[i].PostAttachCommand = postAttach[configPath]
+	}
+	return instances, truncated
+}
+
+// discoverInstances walks the search paths and builds an instance per worktree.
+// With a limit it stops walking once it has more than limit instances, so the
+// caller can tell the list was cut short.
+func discoverInstances(searchPaths []string, maxDepth int, excludedDirs []string, limit int, onWalk func()) []ContainerInstance {
 	var instances []ContainerInstance
 	seenProjects := make(map[string]bool)  // Track main repos we've processed
 	seenWorktrees := make(map[string]bool) // Track worktree paths to deduplicate
+	keepGoing := func() bool { return limit <= 0 || len(instances) <= limit }
 
-	walkDevcontainerDirs(searchPaths, maxDepth, excludedDirs, func(configPath, projectPath string) {
+	walkDevcontainerDirs(searchPaths, maxDepth, excludedDirs, onWalk, func(configPath, projectPath string) bool {
 		// Check if this is a git repo/worktree
 		wtInfo := IsGitWorktree(projectPath)
 		if wtInfo == nil {
+			if gitOnly {
+				return true
+			}
 			// Not a git repo - just add as a single instance without worktree info
 			if !seenWorktrees[projectPath] {
 				seenWorktrees[projectPath] = true
@@ -86,13 +303,13 @@ func DiscoverInstances(searchPaths []string, maxDepth int, excludedDirs []string
 					Worktree:   nil,
 				})
 			}
-			return
+			return keepGoing()
 		}
 
 		// Get the main repo path
 		mainRepo := wtInfo.MainRepo
 		if seenProjects[mainRepo] {
-			return // Already processed this project and its worktrees
+			return true // Already processed this project and its worktrees
 		}
 		seenProjects[mainRepo] = true
 
@@ -118,12 +335,12 @@ func DiscoverInstances(searchPaths []string, maxDepth int, excludedDirs []string
 					Worktree:   wtInfo,
 				})
 			}
-			return
+			return keepGoing()
 		}
 
 		// Add each worktree as a separate instance
 		for _, wt := range worktrees {
-			if seenWorktrees[wt.Path] {
+			if seenWorktrees[wt.Path] || (hideWorktrees && !wt.IsMain) {
 				continue
 			}
 			seenWorktrees[wt.Path] = true
@@ -139,7 +356,139 @@ func DiscoverInstances(searchPaths []string, maxDepth int, excludedDirs []string
 				Worktree:   &wtCopy,
 			})
 		}
+		return keepGoing()
 	})
 
 	return instances
 }
+
+// DiscoveryStats records a discovery run so the next one over the same search
+// paths can show rough progress
+type DiscoveryStats struct {
+	Walked   int           `json:"walked"`   // Directory entries visited
+	Duration time.Duration `json:"duration"` // How long the run took
+}
+
+// discoveryStatsPath returns the file holding the last run's stats for each
+// set of search paths
+func discoveryStatsPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "claude-quick", "discovery.json"), nil
+}
+
+// discoveryStatsKey identifies a set of search paths in the stats file. Fast
+// discovery visits far fewer entries, so its runs are recorded apart.
+func discoveryStatsKey(searchPaths []string) string {
+	key := strings.Join(searchPaths, string(os.PathListSeparator))
+	if fastDiscovery {
+		key = "fast:" + key
+	}
+	return key
+}
+
+// readDiscoveryStats loads the stats file, empty if it is missing or unreadable
+func readDiscoveryStats() map[string]DiscoveryStats {
+	all := make(map[string]DiscoveryStats)
+	path, err := discoveryStatsPath()
+	if err != nil {
+		return all
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &all)
+	}
+	return all
+}
+
+// LoadDiscoveryStats returns the last recorded run over searchPaths; ok is
+// false if there is none
+func LoadDiscoveryStats(searchPaths []string) (stats DiscoveryStats, ok bool) {
+	stats, ok = readDiscoveryStats()[discoveryStatsKey(searchPaths)]
+	return stats, ok && stats.Walked > 0
+}
+
+// SaveDiscoveryStats records a run over searchPaths, replacing the previous one
+func SaveDiscoveryStats(searchPaths []string, stats DiscoveryStats) error {
+	path, err := discoveryStatsPath()
+	if err != nil {
+		return err
+	}
+	all := readDiscoveryStats()
+	all[discoveryStatsKey(searchPaths)] = stats
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to save discovery stats: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to save discovery stats: %w", err)
+	}
+	return nil
+}
+
+// LoadInstance builds a single ContainerInstance for the project at path without
+// walking the filesystem. The path must contain .devcontainer/devcontainer.json.
+// Worktrees share the main repo's devcontainer.json when it has one, as in DiscoverInstances.
+func LoadInstance(path string) (*ContainerInstance, error) {
+	projectPath, err := filepath.Abs(util.ExpandPath(path))
+	if err != nil {
+		return nil, fmt.Errorf("invalid project path %q: %w", path, err)
+	}
+
+	configPath := filepath.Join(projectPath, constants.DevcontainerDir, constants.DevcontainerConfigFile)
+	if _, err := os.Stat(configPath); err != nil {
+		return nil, fmt.Errorf("no %s/%s found in %s", constants.DevcontainerDir, constants.DevcontainerConfigFile, projectPath)
+	}
+
+	instance := &ContainerInstance{
+		Project: Project{
+			Name: filepath.Base(projectPath),
+			Path: projectPath,
+		},
+		ConfigPath: configPath,
+	}
+
+	if wtInfo := IsGitWorktree(projectPath); wtInfo != nil {
+		instance.Name = filepath.Base(wtInfo.MainRepo)
+		instance.Worktree = wtInfo
+		mainConfigPath := filepath.Join(wtInfo.MainRepo, constants.DevcontainerDir, constants.DevcontainerConfigFile)
+		if _, err := os.Stat(mainConfigPath); err == nil {
+			instance.ConfigPath = mainConfigPath
+		}
+	}
+	instance.PostAttachCommand = ReadPostAttachCommand(instance.ConfigPath)
+
+	return instance, nil
+}
+
+// ErrProjectNotFound is returned by FindInstance when no instance matches
+var ErrProjectNotFound = errors.New("project not found")
+
+// FindInstance returns the instance named by query: its display name
+// ("project" or "project [branch]", using aliases) or its directory name.
+// It fails if nothing or more than one instance matches.
+func FindInstance(instances []ContainerInstance, query string) (*ContainerInstance, error) {
+	var matches []int
+	for i, inst := range instances {
+		if inst.DisplayName() == query || filepath.Base(inst.Path) == query {
+			matches = append(matches, i)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %q", ErrProjectNotFound, query)
+	case 1:
+		return &instances[matches[0]], nil
+	}
+
+	paths := make([]string, len(matches))
+	for i, idx := range matches {
+		paths[i] = instances[idx].Path
+	}
+	return nil, fmt.Errorf("%q matches several projects: %s", query, strings.Join(paths, ", "))
+}
@@ -1,9 +1,12 @@
 package devcontainer
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestWalkDevcontainerDirs_FindsDevcontainer(t *testing.T) {
@@ -33,8 +36,10 @@ func TestWalkDevcontainerDirs_FindsDevcontainer(t *testing.T) {
 		[]string{tmpDir},
 		3,
 		[]string{},
-		func(configPath, projectPath string) {
+		nil,
+		func(configPath, projectPath string) bool {
 			found = append(found, projectPath)
+			return true
 		},
 	)
 
@@ -80,8 +85,10 @@ func TestWalkDevcontainerDirs_SkipsExcludedDirs(t *testing.T) {
 		[]string{tmpDir},
 		3,
 		[]string{"node_modules"},
-		func(configPath, projectPath string) {
+		nil,
+		func(configPath, projectPath string) bool {
 			found = append(found, projectPath)
+			return true
 		},
 	)
 
@@ -133,8 +140,10 @@ func TestWalkDevcontainerDirs_RespectsMaxDepth(t *testing.T) {
 		[]string{tmpDir},
 		2,
 		[]string{},
-		func(configPath, projectPath string) {
+		nil,
+		func(configPath, projectPath string) bool {
 			found = append(found, filepath.Base(projectPath))
+			return true
 		},
 	)
 
@@ -189,8 +198,10 @@ func TestWalkDevcontainerDirs_SkipsHiddenDirs(t *testing.T) {
 		[]string{tmpDir},
 		3,
 		[]string{},
-		func(configPath, projectPath string) {
+		nil,
+		func(configPath, projectPath string) bool {
 			found = append(found, filepath.Base(projectPath))
+			return true
 		},
 	)
 
@@ -240,8 +251,10 @@ func TestWalkDevcontainerDirs_MultipleSearchPaths(t *testing.T) {
 		[]string{tmpDir1, tmpDir2},
 		3,
 		[]string{},
-		func(configPath, projectPath string) {
+		nil,
+		func(configPath, projectPath string) bool {
 			found = append(found, filepath.Base(projectPath))
+			return true
 		},
 	)
 
@@ -275,8 +288,10 @@ func TestWalkDevcontainerDirs_EmptySearchPaths(t *testing.T) {
 		[]string{},
 		3,
 		[]string{},
-		func(configPath, projectPath string) {
+		nil,
+		func(configPath, projectPath string) bool {
 			found = append(found, projectPath)
+			return true
 		},
 	)
 
@@ -291,8 +306,10 @@ func TestWalkDevcontainerDirs_NonexistentSearchPath(t *testing.T) {
 		[]string{"/nonexistent/path/that/does/not/exist"},
 		3,
 		[]string{},
-		func(configPath, projectPath string) {
+		nil,
+		func(configPath, projectPath string) bool {
 			found = append(found, projectPath)
+			return true
 		},
 	)
 
@@ -302,6 +319,99 @@ func TestWalkDevcontainerDirs_NonexistentSearchPath(t *testing.T) {
 	}
 }
 
+// discoveredProjects runs walkDevcontainerDirs in the given mode and
+// returns the found project paths relative to root, sorted
+func discoveredProjects(t *testing.T, root string, fast bool) []string {
+	t.Helper()
+	SetFastDiscovery(fast)
+	defer SetFastDiscovery(false)
+
+	var found []string
+	walkDevcontainerDirs([]string{root}, 3, []string{"node_modules"}, nil, func(configPath, projectPath string) bool {
+		rel, err := filepath.Rel(root, projectPath)
+		if err != nil {
+			t.Fatalf("filepath.Rel() error = %v", err)
+		}
+		found = append(found, rel)
+		return true
+	})
+	slices.Sort(found)
+	return found
+}
+
+func TestWalkDevcontainerDirs_FastMatchesRecursiveOnFlatLayout(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"api/.devcontainer/devcontainer.json":          "{}",
+		"web/.devcontainer/devcontainer.json":          "{}",
+		"web/src/main.go":                              "package main",
+		"docs/README.md":                               "no devcontainer",
+		".cache/.devcontainer/devcontainer.json":       "{}", // Hidden
+		"node_modules/.devcontainer/devcontainer.json": "{}", // Excluded
+		"notes.txt": "",
+	})
+
+	recursive := discoveredProjects(t, root, false)
+	fast := discoveredProjects(t, root, true)
+	if !slices.Equal(fast, recursive) {
+		t.Errorf("fast discovery = %v, recursive = %v; want the same projects", fast, recursive)
+	}
+	if want := []string{"api", "web"}; !slices.Equal(fast, want) {
+		t.Errorf("fast discovery = %v, want %v", fast, want)
+	}
+}
+
+func TestWalkDevcontainerDirs_FastSkipsNestedProjects(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		".devcontainer/devcontainer.json":             "{}", // The search path itself
+		"api/.devcontainer/devcontainer.json":         "{}",
+		"clients/ios/.devcontainer/devcontainer.json": "{}", // Two levels down
+	})
+
+	if got, want := discoveredProjects(t, root, false), []string{".", "api", "clients/ios"}; !slices.Equal(got, want) {
+		t.Errorf("recursive discovery = %v, want %v", got, want)
+	}
+	if got, want := discoveredProjects(t, root, true), []string{".", "api"}; !slices.Equal(got, want) {
+		t.Errorf("fast discovery = %v, want %v", got, want)
+	}
+}
+
+func TestWalkDevcontainerDirs_FollowSymlinks(t *testing.T) {
+	defer SetFollowSymlinks(false)
+	outside := t.TempDir()
+	writeFiles(t, outside, map[string]string{
+		"linked/.devcontainer/devcontainer.json": "{}",
+	})
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"api/.devcontainer/devcontainer.json": "{}",
+	})
+	if err := os.Symlink(filepath.Join(outside, "linked"), filepath.Join(root, "linked")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	// A link back to the search path and one to a directory already walked
+	// must not loop or duplicate projects
+	if err := os.Symlink(root, filepath.Join(root, "api", "loop")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "api"), filepath.Join(root, "api-again")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := discoveredProjects(t, root, false), []string{"api"}; !slices.Equal(got, want) {
+		t.Errorf("without follow_symlinks: discovery = %v, want %v", got, want)
+	}
+
+	SetFollowSymlinks(true)
+	if got, want := discoveredProjects(t, root, false), []string{"api", "linked"}; !slices.Equal(got, want) {
+		t.Errorf("recursive discovery = %v, want %v", got, want)
+	}
+	if got, want := discoveredProjects(t, root, true), []string{"api", "linked"}; !slices.Equal(got, want) {
+		t.Errorf("fast discovery = %v, want %v", got, want)
+	}
+}
+
 func TestDiscoverInstances_NonGitProject(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "test-discover-*")
 	if err != nil {
@@ -400,3 +510,228 @@ func TestDiscoverInstances_Deduplication(t *testing.T) {
 		seen[inst.Path] = true
 	}
 }
+
+func TestLoadInstance_NonGitProject(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "test-load-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	project := filepath.Join(tmpDir, "my-project")
+	devcontainer := filepath.Join(project, ".devcontainer")
+	if err := os.MkdirAll(devcontainer, 0755); err != nil {
+		t.Fatalf("failed to create devcontainer dir: %v", err)
+	}
+	configPath := filepath.Join(devcontainer, "devcontainer.json")
+	if err := os.WriteFile(configPath, []byte(`{}`), 0644); err != nil {
+		t.Fatalf("failed to create devcontainer.json: %v", err)
+	}
+
+	instance, err := LoadInstance(project)
+	if err != nil {
+		t.Fatalf("LoadInstance() error = %v", err)
+	}
+	if instance.Name != "my-project" {
+		t.Errorf("Name = %q, want 'my-project'", instance.Name)
+	}
+	if instance.Path != project {
+		t.Errorf("Path = %q, want %q", instance.Path, project)
+	}
+	if instance.ConfigPath != configPath {
+		t.Errorf("ConfigPath = %q, want %q", instance.ConfigPath, configPath)
+	}
+	if instance.Worktree != nil {
+		t.Error("Worktree should be nil for non-git project")
+	}
+}
+
+func TestLoadInstance_NoDevcontainer(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "test-load-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if _, err := LoadInstance(tmpDir); err == nil {
+		t.Error("LoadInstance() should fail for a path without devcontainer.json")
+	}
+}
+
+func TestFindInstance(t *testing.T) {
+	defer SetProjectAliases(nil)
+	SetProjectAliases(map[string]string{"/src/api": "backend"})
+
+	instances := []ContainerInstance{
+		{Project: Project{Name: "api", Path: "/src/api"}, Worktree: &WorktreeInfo{Branch: "main", MainRepo: "/src/api", IsMain: true}},
+		{Project: Project{Name: "api", Path: "/src/api-login"}, Worktree: &WorktreeInfo{Branch: "login", MainRepo: "/src/api"}},
+		{Project: Project{Name: "web", Path: "/src/web"}},
+		{Project: Project{Name: "web", Path: "/other/web"}},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		wantPath string
+		wantErr  bool
+	}{
+		{"alias", "backend", "/src/api", false},
+		{"directory name", "api", "/src/api", false},
+		{"worktree display name", "backend [login]", "/src/api-login", false},
+		{"worktree directory name", "api-login", "/src/api-login", false},
+		{"ambiguous", "web", "", true},
+		{"not found", "missing", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindInstance(instances, tt.query)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("FindInstance(%q) = %q, want error", tt.query, got.Path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindInstance(%q) error = %v", tt.query, err)
+			}
+			if got.Path != tt.wantPath {
+				t.Errorf("FindInstance(%q) = %q, want %q", tt.query, got.Path, tt.wantPath)
+			}
+		})
+	}
+
+	if _, err := FindInstance(instances, "missing"); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("FindInstance(missing) error = %v, want ErrProjectNotFound", err)
+	}
+}
+
+func TestDiscoverInstancesLimit(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"a/.devcontainer/devcontainer.json": "{}",
+		"b/.devcontainer/devcontainer.json": "{}",
+		"c/.devcontainer/devcontainer.json": "{}",
+		"d/.devcontainer/devcontainer.json": "{}",
+	})
+	defer SetFastDiscovery(false)
+
+	for _, fast := range []bool{false, true} {
+		SetFastDiscovery(fast)
+		got, truncated := DiscoverInstancesLimit([]string{root}, 3, nil, 2, nil)
+		if len(got) != 2 || !truncated {
+			t.Errorf("fast=%v: limit 2 found %d instances (truncated=%v), want 2 (truncated=true)", fast, len(got), truncated)
+		}
+
+		if got, truncated := DiscoverInstancesLimit([]string{root}, 3, nil, 4, nil); len(got) != 4 || truncated {
+			t.Errorf("fast=%v: limit 4 found %d instances (truncated=%v), want 4 (truncated=false)", fast, len(got), truncated)
+		}
+		if got, truncated := DiscoverInstancesLimit([]string{root}, 3, nil, 0, nil); len(got) != 4 || truncated {
+			t.Errorf("fast=%v: no limit found %d instances (truncated=%v), want 4 (truncated=false)", fast, len(got), truncated)
+		}
+	}
+}
+
+func TestWalkDevcontainerDirs_StopsWhenCallbackDeclines(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"a/.devcontainer/devcontainer.json":        "{}",
+		"b/.devcontainer/devcontainer.json":        "{}",
+		"nested/c/.devcontainer/devcontainer.json": "{}",
+	})
+	other := t.TempDir()
+	writeFiles(t, other, map[string]string{"d/.devcontainer/devcontainer.json": "{}"})
+
+	calls := 0
+	walkDevcontainerDirs([]string{root, other}, 3, nil, nil, func(configPath, projectPath string) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Errorf("callback called %d times after asking to stop, want 1", calls)
+	}
+}
+
+func TestDiscoverInstancesLimit_ReportsWalkedEntries(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"a/.devcontainer/devcontainer.json": "{}",
+		"b/notes.txt":                       "",
+	})
+
+	walked := 0
+	DiscoverInstancesLimit([]string{root}, 3, nil, 0, func() { walked++ })
+	// root, a, a/.devcontainer, devcontainer.json, b, b/notes.txt
+	if walked != 6 {
+		t.Errorf("walked = %d, want 6", walked)
+	}
+}
+
+func TestDiscoveryStats(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	paths := []string{"/src", "/work"}
+
+	if _, ok := LoadDiscoveryStats(paths); ok {
+		t.Fatal("LoadDiscoveryStats() found stats before any were saved")
+	}
+	want := DiscoveryStats{Walked: 1200, Duration: 3 * time.Second}
+	if err := SaveDiscoveryStats(paths, want); err != nil {
+		t.Fatalf("SaveDiscoveryStats() error = %v", err)
+	}
+	if err := SaveDiscoveryStats([]string{"/other"}, DiscoveryStats{Walked: 5}); err != nil {
+		t.Fatalf("SaveDiscoveryStats() error = %v", err)
+	}
+
+	if got, ok := LoadDiscoveryStats(paths); !ok || got != want {
+		t.Errorf("LoadDiscoveryStats() = %+v, %v; want %+v, true", got, ok, want)
+	}
+
+	// Fast discovery runs are kept apart
+	SetFastDiscovery(true)
+	defer SetFastDiscovery(false)
+	if _, ok := LoadDiscoveryStats(paths); ok {
+		t.Error("fast discovery should not reuse the recursive run's stats")
+	}
+}
+
+func TestDiscoverInstances_HideWorktrees(t *testing.T) {
+	baseDir, clone := setupRepoWithOrigin(t)
+	writeFiles(t, clone, map[string]string{".devcontainer/devcontainer.json": "{}"})
+	if _, _, err := CreateWorktree(clone, "feature", "", "", false); err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+
+	if got := DiscoverInstances([]string{baseDir}, 3, nil); len(got) != 2 {
+		t.Fatalf("with worktrees shown: found %d instances, want 2", len(got))
+	}
+
+	SetShowWorktrees(false)
+	defer SetShowWorktrees(true)
+	got := DiscoverInstances([]string{baseDir}, 3, nil)
+	if len(got) != 1 {
+		t.Fatalf("with worktrees hidden: found %d instances, want 1", len(got))
+	}
+	if got[0].Path != clone || got[0].Worktree == nil || !got[0].Worktree.IsMain {
+		t.Errorf("instance = %+v, want the main worktree at %s", got[0], clone)
+	}
+}
+
+func TestDiscoverInstances_GitOnly(t *testing.T) {
+	baseDir, clone := setupRepoWithOrigin(t)
+	writeFiles(t, clone, map[string]string{".devcontainer/devcontainer.json": "{}"})
+	writeFiles(t, baseDir, map[string]string{"template/.devcontainer/devcontainer.json": "{}"})
+
+	if got := DiscoverInstances([]string{baseDir}, 3, nil); len(got) != 2 {
+		t.Fatalf("by default: found %d instances, want 2", len(got))
+	}
+
+	SetGitOnly(true)
+	defer SetGitOnly(false)
+	got := DiscoverInstances([]string{baseDir}, 3, nil)
+	if len(got) != 1 {
+		t.Fatalf("with git_only: found %d instances, want 1", len(got))
+	}
+	if got[0].Path != clone || got[0].Worktree == nil {
+		t.Errorf("instance = %+v, want the git repository at %s", got[0], clone)
+	}
+}
@@ -0,0 +1,56 @@
+package devcontainer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrPartialDiskUsage is wrapped by WorktreeDiskUsage when some files or
+// directories could not be read. The size returned with it is a lower bound.
+var ErrPartialDiskUsage = errors.New("some files could not be read")
+
+// WorktreeDiskUsage returns the size in bytes of the files in a worktree.
+// Only tracked and untracked-but-not-ignored files are counted, so .git,
+// node_modules and build output don't drown out the working tree itself.
+// Unreadable entries are skipped and reported via ErrPartialDiskUsage.
+func WorktreeDiskUsage(path string) (int64, error) {
+	cmd := exec.Command("git", "-C", path, "ls-files", "-z", "--cached", "--others", "--exclude-standard")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list worktree files: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	// git skips directories it can't open with a warning instead of failing
+	unreadable := strings.Count(stderr.String(), "could not open directory")
+
+	var total int64
+	for _, name := range strings.Split(string(output), "\x00") {
+		if name == "" {
+			continue
+		}
+		info, err := os.Lstat(filepath.Join(path, name))
+		if err != nil {
+			// Deleted in the working tree but still in the index
+			if !os.IsNotExist(err) {
+				unreadable++
+			}
+			continue
+		}
+		// Submodules show up as directories; their contents aren't counted
+		if info.Mode().IsRegular() {
+			total += info.Size()
+		}
+	}
+
+	if unreadable > 0 {
+		return total, fmt.Errorf("%w (%d skipped)", ErrPartialDiskUsage, unreadable)
+	}
+	return total, nil
+}
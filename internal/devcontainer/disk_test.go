@@ -0,0 +1,55 @@
+package devcontainer
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorktreeDiskUsage(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+
+	writeFiles(t, dir, map[string]string{
+		".gitignore":             "node_modules/\n", // 14 bytes
+		"main.go":                "package main\n",  // 13 bytes
+		"docs/readme.md":         "hello",           // 5 bytes
+		"node_modules/pkg/a.js":  "ignored content",
+		"node_modules/pkg/b.js":  "also ignored",
+		"untracked-but-kept.txt": "1234567890", // 10 bytes
+	})
+	if out, err := exec.Command("git", "-C", dir, "add", "main.go").CombinedOutput(); err != nil {
+		t.Fatalf("git add: %v: %s", err, out)
+	}
+	// Tracked in the index but deleted from the working tree
+	writeFiles(t, dir, map[string]string{"gone.txt": "x"})
+	if out, err := exec.Command("git", "-C", dir, "add", "gone.txt").CombinedOutput(); err != nil {
+		t.Fatalf("git add: %v: %s", err, out)
+	}
+	if err := os.Remove(filepath.Join(dir, "gone.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	size, err := WorktreeDiskUsage(dir)
+	if err != nil {
+		t.Fatalf("WorktreeDiskUsage() error = %v", err)
+	}
+	if want := int64(14 + 13 + 5 + 10); size != want {
+		t.Errorf("WorktreeDiskUsage() = %d, want %d (ignored files and .git excluded)", size, want)
+	}
+}
+
+func TestWorktreeDiskUsage_NotARepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	if _, err := WorktreeDiskUsage(t.TempDir()); err == nil {
+		t.Error("expected an error outside a git repository")
+	}
+}
@@ -27,6 +27,8 @@
 //
 //	docker ps --filter label=devcontainer.local_folder=<path>
 //
+// The label key can be changed with SetContainerLabelKey for custom tooling.
+//
 // # Git Worktree Integration
 //
 // Each worktree is treated as a separate devcontainer instance.
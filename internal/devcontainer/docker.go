@@ -2,16 +2,128 @@ package devcontainer
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
+
+	"github.com/christophergyman/claude-quick/internal/constants"
+)
+
+// containerLabelKey is the Docker label used to find a project's container
+var containerLabelKey = constants.DefaultContainerLabelKey
+
+// SetContainerLabelKey sets the Docker label used to find a project's container
+// (for devcontainer tooling that doesn't use devcontainer.local_folder).
+// An empty key restores the default.
+func SetContainerLabelKey(key string) {
+	if key == "" {
+		key = constants.DefaultContainerLabelKey
+	}
+	containerLabelKey = key
+}
+
+// extraUpArgs are appended verbatim to every devcontainer up command
+var extraUpArgs []string
+
+// SetUpArgs sets extra arguments passed verbatim to devcontainer up
+// (e.g. --gpu-availability all). Callers should drop flags the tool sets
+// itself; see config.Load.
+func SetUpArgs(args []string) {
+	extraUpArgs = args
+}
+
+// overrideConfig is passed to devcontainer up as --override-config ("" for none)
+var overrideConfig string
+
+// SetOverrideConfig sets the devcontainer.json passed to devcontainer up with
+// --override-config. A relative path is resolved in each project and only
+// used where that file exists; an empty path turns the override off.
+func SetOverrideConfig(path string) {
+	overrideConfig = path
+}
+
+// overrideConfigPath returns the override config to use for a project, or ""
+func overrideConfigPath(projectPath string) string {
+	if overrideConfig == "" || filepath.IsAbs(overrideConfig) {
+		return overrideConfig
+	}
+	file := filepath.Join(projectPath, overrideConfig)
+	if info, err := os.Stat(file); err != nil || info.IsDir() {
+		return ""
+	}
+	return file
+}
+
+// Errors wrapped by container operations so callers can tell failures apart
+// with errors.Is rather than matching message text
+var (
+	ErrNoContainer       = errors.New("no container found")
+	ErrDaemonUnreachable = errors.New("docker daemon unreachable")
+	ErrImagePullFailed   = errors.New("failed to pull image")
+)
+
+// daemonUnreachableMarkers and imagePullMarkers are lowercase fragments of
+// docker/devcontainer CLI output that identify each failure
+var (
+	daemonUnreachableMarkers = []string{
+		"cannot connect to the docker daemon",
+		"is the docker daemon running",
+		"error during connect",
+		"permission denied while trying to connect to the docker daemon",
+	}
+	imagePullMarkers = []string{
+		"pull access denied",
+		"manifest unknown",
+		"failed to resolve reference",
+		"error pulling image",
+	}
 )
 
+// classifyDockerError returns the sentinel error matching a docker failure's
+// output, or nil if it isn't one callers can act on
+func classifyDockerError(output string) error {
+	lower := strings.ToLower(output)
+	for _, marker := range daemonUnreachableMarkers {
+		if strings.Contains(lower, marker) {
+			return ErrDaemonUnreachable
+		}
+	}
+	for _, marker := range imagePullMarkers {
+		if strings.Contains(lower, marker) {
+			return ErrImagePullFailed
+		}
+	}
+	return nil
+}
+
+// dockerFailure builds the error for a failed docker/devcontainer command,
+// wrapping a sentinel from classifyDockerError when the output matches one
+func dockerFailure(action, output string) error {
+	output = strings.TrimSpace(output)
+	if kind := classifyDockerError(output); kind != nil {
+		return fmt.Errorf("%s: %w: %s", action, kind, output)
+	}
+	return fmt.Errorf("%s: %s", action, output)
+}
+
+// labelFilter returns the docker --filter value matching the project's container.
+// The path needs no quoting: the filter is passed as a single argument (no
+// shell), and docker splits it only at the first "=" (filter name) and the
+// next (label key), so spaces, commas and "=" in the path are kept as-is.
+func labelFilter(projectPath string) string {
+	return fmt.Sprintf("label=%s=%s", containerLabelKey, projectPath)
+}
+
 // CheckCLI verifies the devcontainer CLI is installed
 func CheckCLI() error {
 	_, err := exec.LookPath("devcontainer")
@@ -21,47 +133,128 @@ func CheckCLI() error {
 	return nil
 }
 
+// CheckDockerDaemon verifies the Docker daemon is reachable by running docker info.
+// This surfaces an unreachable DOCKER_HOST instead of every status check returning unknown.
+func CheckDockerDaemon() error {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.DockerDaemonTimeout*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "docker", "info", "--format", "{{.ServerVersion}}")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		reason := strings.TrimSpace(stderr.String())
+		if ctx.Err() == context.DeadlineExceeded {
+			reason = fmt.Sprintf("timed out after %ds", constants.DockerDaemonTimeout)
+		} else if reason == "" {
+			reason = err.Error()
+		}
+		if host := os.Getenv("DOCKER_HOST"); host != "" {
+			return fmt.Errorf("%s (DOCKER_HOST=%s)", reason, host)
+		}
+		return errors.New(reason)
+	}
+	return nil
+}
+
 // Up starts the devcontainer for a project
 // Returns error if it fails
 func Up(projectPath string) error {
+	return UpContext(context.Background(), projectPath)
+}
+
+// UpContext starts the devcontainer for a project, killing devcontainer up
+// and everything it spawned if ctx is cancelled or times out. The returned
+// error wraps ctx.Err() in that case.
+func UpContext(ctx context.Context, projectPath string) error {
+	cmd := exec.CommandContext(ctx, "devcontainer", upArgs(projectPath, IsGitWorktree(projectPath))...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	killProcessGroupOnCancel(cmd)
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("failed to start container: %w", ctxErr)
+		}
+		return dockerFailure("failed to start container", stderr.String())
+	}
+	return nil
+}
+
+// upArgs builds the devcontainer up arguments, followed by any extra args
+// configured with SetUpArgs
+func upArgs(projectPath string, wtInfo *WorktreeInfo) []string {
 	args := []string{"up", "--workspace-folder", projectPath}
+	if file := overrideConfigPath(projectPath); file != "" {
+		args = append(args, "--override-config", file)
+	}
 
 	// For worktrees, mount the main repo's .git directory at the expected host path
 	// This allows git to find the gitdir referenced in the worktree's .git file
-	wtInfo := IsGitWorktree(projectPath)
 	if wtInfo != nil && !wtInfo.IsMain {
 		mainGitDir := filepath.Join(wtInfo.MainRepo, ".git")
 		args = append(args, "--mount",
 			fmt.Sprintf("type=bind,source=%s,target=%s", mainGitDir, mainGitDir))
 	}
 
-	cmd := exec.Command("devcontainer", args...)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to start container: %s", stderr.String())
-	}
-	return nil
+	return append(args, extraUpArgs...)
 }
 
-// findContainerByPath finds a Docker container by its devcontainer.local_folder label
+// findContainerByPath finds a Docker container by its workspace folder label
+// (devcontainer.local_folder unless overridden with SetContainerLabelKey)
 // If runningOnly is true, only searches running containers
 // If runningOnly is false, searches all containers (including stopped)
 func findContainerByPath(projectPath string, runningOnly bool) (string, error) {
-	args := []string{"ps", "-q", "--filter", fmt.Sprintf("label=devcontainer.local_folder=%s", projectPath)}
-	if !runningOnly {
-		// Insert "-a" after "ps" to include stopped containers
-		args = []string{"ps", "-a", "-q", "--filter", fmt.Sprintf("label=devcontainer.local_folder=%s", projectPath)}
-	}
-	cmd := exec.Command("docker", args...)
+	cmd := exec.Command("docker", psArgs(projectPath, runningOnly)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
 	output, err := cmd.Output()
 	if err != nil {
+		if kind := classifyDockerError(stderr.String()); kind != nil {
+			return "", fmt.Errorf("failed to find container: %w: %s", kind, strings.TrimSpace(stderr.String()))
+		}
 		return "", fmt.Errorf("failed to find container: %w", err)
 	}
 	return strings.TrimSpace(string(output)), nil
 }
 
+// psArgs returns the docker ps arguments listing the project's container IDs
+func psArgs(projectPath string, runningOnly bool) []string {
+	if runningOnly {
+		return []string{"ps", "-q", "--filter", labelFilter(projectPath)}
+	}
+	// Include stopped containers
+	return []string{"ps", "-a", "-q", "--filter", labelFilter(projectPath)}
+}
+
+// ContainerLogs returns the output of docker logs for the project's container.
+// tail limits the output to the last tail lines; tail <= 0 returns everything.
+func ContainerLogs(projectPath string, tail int) (string, error) {
+	containerID, err := findContainerByPath(projectPath, false)
+	if err != nil {
+		return "", err
+	}
+	if containerID == "" {
+		return "", fmt.Errorf("%w for %s", ErrNoContainer, projectPath)
+	}
+	// Multiple matches (e.g. stale containers) - use the most recent one
+	containerID = strings.Fields(containerID)[0]
+
+	args := []string{"logs"}
+	if tail > 0 {
+		args = append(args, "--tail", strconv.Itoa(tail))
+	}
+	args = append(args, containerID)
+
+	// Containers log to both streams, so interleave them as docker does
+	output, err := exec.Command("docker", args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to read container logs: %s", strings.TrimSpace(string(output)))
+	}
+	return string(output), nil
+}
+
 // Stop stops the devcontainer by finding and stopping its Docker container
 // It waits for the container to fully exit before returning
 func Stop(projectPath string) error {
@@ -70,13 +263,13 @@ func Stop(projectPath string) error {
 		return err
 	}
 	if containerID == "" {
-		return fmt.Errorf("no running container found for project")
+		return fmt.Errorf("%w running for project", ErrNoContainer)
 	}
 	stopCmd := exec.Command("docker", "stop", containerID)
 	var stderr bytes.Buffer
 	stopCmd.Stderr = &stderr
 	if err := stopCmd.Run(); err != nil {
-		return fmt.Errorf("failed to stop container: %s", stderr.String())
+		return dockerFailure("failed to stop container", stderr.String())
 	}
 
 	// Wait for container to fully exit (not just receive stop signal)
@@ -104,6 +297,27 @@ func waitForContainerExit(containerID string, timeout time.Duration) error {
 	return fmt.Errorf("timeout waiting for container to exit")
 }
 
+// RemoveContainer force-removes the project's container, running or not.
+// Used when the workspace folder moves and the container's label no longer
+// matches a path. Returns nil if there is no container.
+func RemoveContainer(projectPath string) error {
+	containerID, err := findContainerByPath(projectPath, false)
+	if err != nil {
+		return err
+	}
+	if containerID == "" {
+		return nil
+	}
+	args := append([]string{"rm", "-f"}, strings.Fields(containerID)...)
+	rmCmd := exec.Command("docker", args...)
+	var stderr bytes.Buffer
+	rmCmd.Stderr = &stderr
+	if err := rmCmd.Run(); err != nil {
+		return dockerFailure("failed to remove container", stderr.String())
+	}
+	return nil
+}
+
 // Restart restarts the devcontainer
 func Restart(projectPath string) error {
 	containerID, err := findContainerByPath(projectPath, false)
@@ -117,7 +331,7 @@ func Restart(projectPath string) error {
 	var stderr bytes.Buffer
 	restartCmd.Stderr = &stderr
 	if err := restartCmd.Run(); err != nil {
-		return fmt.Errorf("failed to restart container: %s", stderr.String())
+		return dockerFailure("failed to restart container", stderr.String())
 	}
 	return nil
 }
@@ -135,7 +349,7 @@ func GetContainerStatus(projectPath string) (ContainerStatus, string) {
 
 	// Check stopped containers
 	cmd := exec.Command("docker", "ps", "-a", "-q",
-		"--filter", fmt.Sprintf("label=devcontainer.local_folder=%s", projectPath),
+		"--filter", labelFilter(projectPath),
 		"--filter", "status=exited")
 	output, err := cmd.Output()
 	if err != nil {
@@ -150,8 +364,12 @@ func GetContainerStatus(projectPath string) (ContainerStatus, string) {
 	return StatusUnknown, ""
 }
 
-// GetAllInstancesStatus returns all instances with their current Docker status
-func GetAllInstancesStatus(instances []ContainerInstance) []ContainerInstanceWithStatus {
+// GetAllInstancesStatus returns all instances with their current Docker status.
+// When countSessions is false, SessionCount is left at zero and no tmux
+// listing is run inside the containers, saving one devcontainer exec per
+// running instance. When showLastCommit is false, LastCommit is left zero and
+// the git log per worktree instance is skipped.
+func GetAllInstancesStatus(instances []ContainerInstance, countSessions, showLastCommit bool) []ContainerInstanceWithStatus {
 	result := make([]ContainerInstanceWithStatus, len(instances))
 	var wg sync.WaitGroup
 
@@ -165,18 +383,25 @@ func GetAllInstancesStatus(instances []ContainerInstance) []ContainerInstanceWit
 			sessionCount := 0
 
 			// Only count sessions if container is running
-			if status == StatusRunning {
+			if countSessions && status == StatusRunning {
 				sessions, err := ListTmuxSessions(instance.Path)
 				if err == nil {
 					sessionCount = len(sessions)
 				}
 			}
 
+			// A failed git call just leaves the time out of the dashboard
+			var lastCommit time.Time
+			if showLastCommit && instance.Worktree != nil {
+				lastCommit, _ = LastCommitTime(instance.Path)
+			}
+
 			result[idx] = ContainerInstanceWithStatus{
 				ContainerInstance: instance,
 				Status:            status,
 				ContainerID:       containerID,
 				SessionCount:      sessionCount,
+				LastCommit:        lastCommit,
 			}
 		}(i, inst)
 	}
@@ -185,6 +410,12 @@ func GetAllInstancesStatus(instances []ContainerInstance) []ContainerInstanceWit
 	return result
 }
 
+// ExecCommandPrefix returns the shell command line that runs a command in the
+// project's devcontainer, ready to paste into a terminal and complete
+func ExecCommandPrefix(projectPath string) string {
+	return "devcontainer exec --workspace-folder " + shellQuote(projectPath)
+}
+
 // ExecInteractive executes a command inside the devcontainer interactively
 // This replaces the current process with the devcontainer exec
 func ExecInteractive(projectPath string, args []string) error {
@@ -200,6 +431,19 @@ func ExecInteractive(projectPath string, args []string) error {
 	return syscall.Exec(devcontainerPath, cmdArgs, os.Environ())
 }
 
+// ShellArgs returns the command that opens an interactive shell in the
+// container: the given shell (DefaultContainerShell when blank), or
+// FallbackContainerShell with a notice when the image doesn't have it
+func ShellArgs(shell string) []string {
+	if shell == "" {
+		shell = constants.DefaultContainerShell
+	}
+	fallback := constants.FallbackContainerShell
+	script := `if command -v "$1" >/dev/null 2>&1; then exec "$1"; fi; ` +
+		`echo "claude-quick: $1 not found in the container, using $2" >&2; exec "$2"`
+	return []string{fallback, "-c", script, "sh", shell, fallback}
+}
+
 // execInContainer runs a command inside the devcontainer and returns its output
 func execInContainer(projectPath string, args ...string) ([]byte, error) {
 	cmdArgs := append([]string{"exec", "--workspace-folder", projectPath}, args...)
@@ -218,3 +462,188 @@ func execInContainerWithStderr(projectPath string, errPrefix string, args ...str
 	}
 	return nil
 }
+
+// CheckImageStatus compares the image the project's running container was
+// created from against the image its tag currently points to locally
+// (e.g., after a docker pull). This runs several docker commands, so callers
+// should invoke it on demand rather than during status refresh.
+func CheckImageStatus(projectPath string) (ImageStatus, error) {
+	containerID, err := findContainerByPath(projectPath, true)
+	if err != nil {
+		return "", err
+	}
+	if containerID == "" {
+		return "", fmt.Errorf("%w running for project", ErrNoContainer)
+	}
+
+	// Image ID the container runs and the tag it was created from
+	cmd := exec.Command("docker", "inspect", "--format", "{{.Image}} {{.Config.Image}}", containerID)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container: %s", stderr.String())
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) != 2 {
+		return "", fmt.Errorf("unexpected docker inspect output: %q", strings.TrimSpace(string(output)))
+	}
+	runningID, imageRef := fields[0], fields[1]
+
+	// Image ID the tag points to now; RepoDigests is empty for locally built images
+	cmd = exec.Command("docker", "image", "inspect", "--format", "{{.Id}} {{len .RepoDigests}}", imageRef)
+	stderr.Reset()
+	cmd.Stderr = &stderr
+	output, err = cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect image %s: %s", imageRef, stderr.String())
+	}
+	fields = strings.Fields(string(output))
+	if len(fields) != 2 {
+		return "", fmt.Errorf("unexpected docker image inspect output: %q", strings.TrimSpace(string(output)))
+	}
+
+	return classifyImage(runningID, fields[0], fields[1] != "0"), nil
+}
+
+// classifyImage decides the image status from the container's image ID,
+// the ID its tag currently resolves to, and whether the image came from a registry
+func classifyImage(runningID, latestID string, fromRegistry bool) ImageStatus {
+	if !fromRegistry {
+		return ImageLocalBuild
+	}
+	if runningID != latestID {
+		return ImageOutdated
+	}
+	return ImageUpToDate
+}
+
+// containerMount is the part of a docker inspect mount entry needed to find
+// which host directory backs the workspace folder
+type containerMount struct {
+	Type        string
+	Source      string
+	Destination string
+}
+
+// workspaceInspect is the part of docker inspect output needed to find the
+// workspace folder and the mount backing it
+type workspaceInspect struct {
+	Mounts []containerMount
+	Config struct {
+		WorkingDir string
+		Labels     map[string]string
+	}
+}
+
+// CheckWorkspaceMount reports whether the running container's workspace
+// folder is bind-mounted from projectPath. A devcontainer.json that hardcodes
+// workspaceFolder or workspaceMount to the main repo silently shares one
+// checkout across worktrees. Only the container's inspect data is read;
+// nothing runs inside it. Returns a description of the mismatch, or "" if
+// the workspace matches or can't be determined.
+func CheckWorkspaceMount(projectPath string) (string, error) {
+	containerID, err := findContainerByPath(projectPath, true)
+	if err != nil {
+		return "", err
+	}
+	if containerID == "" {
+		return "", fmt.Errorf("%w running for project", ErrNoContainer)
+	}
+	containerID = strings.Fields(containerID)[0]
+
+	cmd := exec.Command("docker", "inspect", "--format", "{{json .}}", containerID)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container: %s", stderr.String())
+	}
+	var info workspaceInspect
+	if err := json.Unmarshal(output, &info); err != nil {
+		return "", fmt.Errorf("unexpected docker inspect output: %w", err)
+	}
+
+	labels := info.Config.Labels
+	workspaceDir := containerWorkspaceFolder(projectPath, labels["devcontainer.config_file"],
+		labels["devcontainer.metadata"], info.Config.WorkingDir)
+	return workspaceMismatch(projectPath, workspaceDir, info.Mounts), nil
+}
+
+// containerWorkspaceFolder works out the container's workspace folder: the
+// workspaceFolder of the devcontainer.json the container was created from
+// (the spec's /workspaces/<name> if it has none), else one recorded in the
+// devcontainer metadata label, else the container's working directory.
+// Returns "" if none of these settle it.
+func containerWorkspaceFolder(projectPath, configFile, metadata, workingDir string) string {
+	if configFile != "" {
+		if folder, ok := readWorkspaceFolder(configFile); ok {
+			if folder == "" {
+				return "/workspaces/" + filepath.Base(projectPath)
+			}
+			folder = strings.ReplaceAll(folder, "${localWorkspaceFolderBasename}", filepath.Base(projectPath))
+			folder = strings.ReplaceAll(folder, "${localWorkspaceFolder}", projectPath)
+			if strings.Contains(folder, "${") {
+				return ""
+			}
+			return folder
+		}
+	}
+	var entries []struct {
+		WorkspaceFolder string `json:"workspaceFolder"`
+	}
+	if json.Unmarshal([]byte(metadata), &entries) == nil {
+		// Later entries override earlier ones, as when the CLI merges them
+		for i := len(entries) - 1; i >= 0; i-- {
+			if entries[i].WorkspaceFolder != "" {
+				return entries[i].WorkspaceFolder
+			}
+		}
+	}
+	if workingDir != "/" {
+		return workingDir
+	}
+	return ""
+}
+
+// workspaceMismatch describes how the mount at workspaceDir fails to come
+// from projectPath, or returns "" if it does. Only the mount at exactly
+// workspaceDir counts: a parent such as $HOME mounted for dotfiles says
+// nothing about which checkout the workspace is. A workspace on a volume, or
+// an unknown workspaceDir, can't be compared.
+func workspaceMismatch(projectPath, workspaceDir string, mounts []containerMount) string {
+	if workspaceDir == "" {
+		return ""
+	}
+	workspaceDir = path.Clean(workspaceDir)
+	for _, mnt := range mounts {
+		if path.Clean(mnt.Destination) != workspaceDir {
+			continue
+		}
+		if mnt.Type != "bind" || resolveDir(hostMountSource(mnt.Source)) == resolveDir(projectPath) {
+			return ""
+		}
+		return fmt.Sprintf("workspace folder %s is mounted from %s, not %s (check workspaceFolder/workspaceMount in devcontainer.json)",
+			workspaceDir, mnt.Source, projectPath)
+	}
+	return fmt.Sprintf("workspace folder %s is not mounted from %s (check workspaceFolder/workspaceMount in devcontainer.json)",
+		workspaceDir, projectPath)
+}
+
+// hostMountSource undoes Docker Desktop's translation of a bind mount source
+// into its VM (/host_mnt/Users/... for /Users/...)
+func hostMountSource(source string) string {
+	if rest, ok := strings.CutPrefix(source, "/host_mnt/"); ok {
+		return "/" + rest
+	}
+	return source
+}
+
+// resolveDir cleans a host path, resolving symlinks where possible
+// (e.g. /tmp to /private/tmp on macOS)
+func resolveDir(dir string) string {
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	return filepath.Clean(dir)
+}
@@ -0,0 +1,313 @@
+package devcontainer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestClassifyImage(t *testing.T) {
+	tests := []struct {
+		name         string
+		runningID    string
+		latestID     string
+		fromRegistry bool
+		want         ImageStatus
+	}{
+		{"same image", "sha256:aaa", "sha256:aaa", true, ImageUpToDate},
+		{"newer image pulled", "sha256:aaa", "sha256:bbb", true, ImageOutdated},
+		{"local build", "sha256:aaa", "sha256:bbb", false, ImageLocalBuild},
+		{"local build unchanged", "sha256:aaa", "sha256:aaa", false, ImageLocalBuild},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyImage(tt.runningID, tt.latestID, tt.fromRegistry); got != tt.want {
+				t.Errorf("classifyImage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabelFilter(t *testing.T) {
+	defer SetContainerLabelKey("")
+
+	if got, want := labelFilter("/src/app"), "label=devcontainer.local_folder=/src/app"; got != want {
+		t.Errorf("labelFilter() = %q, want %q", got, want)
+	}
+
+	SetContainerLabelKey("com.example.workspace")
+	if got, want := labelFilter("/src/app"), "label=com.example.workspace=/src/app"; got != want {
+		t.Errorf("labelFilter() = %q, want %q", got, want)
+	}
+
+	// Empty key restores the default
+	SetContainerLabelKey("")
+	if got, want := labelFilter("/src/app"), "label=devcontainer.local_folder=/src/app"; got != want {
+		t.Errorf("labelFilter() = %q, want %q", got, want)
+	}
+}
+
+func TestLabelFilter_SpecialCharacters(t *testing.T) {
+	for _, path := range []string{"/src/my app", "/src/a,b", "/src/key=value", "/src/it's here"} {
+		args := psArgs(path, true)
+		want := []string{"ps", "-q", "--filter", "label=devcontainer.local_folder=" + path}
+		if !slices.Equal(args, want) {
+			t.Errorf("psArgs(%q) = %q, want %q", path, args, want)
+		}
+
+		// Split the way docker does: filter name, then label key and value
+		name, label, _ := strings.Cut(args[len(args)-1], "=")
+		key, value, _ := strings.Cut(label, "=")
+		if name != "label" || key != "devcontainer.local_folder" || value != path {
+			t.Errorf("filter for %q parses as %s/%s/%q", path, name, key, value)
+		}
+	}
+
+	if got := psArgs("/src/my app", false); !slices.Equal(got[:3], []string{"ps", "-a", "-q"}) {
+		t.Errorf("psArgs() = %q, want stopped containers included", got)
+	}
+}
+
+func TestExecCommandPrefix(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/src/app", "devcontainer exec --workspace-folder /src/app"},
+		{"/src/my app", "devcontainer exec --workspace-folder '/src/my app'"},
+	}
+
+	for _, tt := range tests {
+		if got := ExecCommandPrefix(tt.path); got != tt.want {
+			t.Errorf("ExecCommandPrefix(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestShellArgs(t *testing.T) {
+	if got := ShellArgs(""); got[len(got)-2] != "/bin/bash" {
+		t.Errorf("ShellArgs(\"\") shell = %q, want /bin/bash", got[len(got)-2])
+	}
+
+	// Run the wrapper locally: a missing shell falls back to /bin/sh, which
+	// exits straight away on an empty stdin
+	args := ShellArgs("/no/such/shell")
+	cmd := exec.Command(args[0], args[1:]...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("fallback shell failed: %v (%s)", err, stderr.String())
+	}
+	if want := "/no/such/shell not found in the container, using /bin/sh"; !strings.Contains(stderr.String(), want) {
+		t.Errorf("stderr = %q, want it to contain %q", stderr.String(), want)
+	}
+
+	// An available shell runs without the notice
+	args = ShellArgs("/bin/sh")
+	cmd = exec.Command(args[0], args[1:]...)
+	stderr.Reset()
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil || stderr.Len() > 0 {
+		t.Errorf("ShellArgs(/bin/sh) = err %v, stderr %q; want a clean exit", err, stderr.String())
+	}
+}
+
+func TestClassifyDockerError(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   error
+	}{
+		{"daemon down", "Cannot connect to the Docker daemon at unix:///var/run/docker.sock. Is the docker daemon running?", ErrDaemonUnreachable},
+		{"remote host down", "error during connect: Get \"http://host:2375/v1.24/containers/json\"", ErrDaemonUnreachable},
+		{"private image", "Error response from daemon: pull access denied for acme/app, repository does not exist", ErrImagePullFailed},
+		{"missing tag", "manifest unknown: manifest tagged by \"nope\" is not found", ErrImagePullFailed},
+		{"other failure", "postCreateCommand failed with exit code 1", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyDockerError(tt.output); got != tt.want {
+				t.Errorf("classifyDockerError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDockerFailure(t *testing.T) {
+	err := dockerFailure("failed to start container", "pull access denied for acme/app\n")
+	if !errors.Is(err, ErrImagePullFailed) {
+		t.Errorf("error should wrap ErrImagePullFailed: %v", err)
+	}
+	if !strings.Contains(err.Error(), "pull access denied for acme/app") {
+		t.Errorf("error should keep the command output: %v", err)
+	}
+
+	err = dockerFailure("failed to stop container", "exit status 1")
+	if errors.Is(err, ErrImagePullFailed) || errors.Is(err, ErrDaemonUnreachable) {
+		t.Errorf("unclassified output should not wrap a sentinel: %v", err)
+	}
+}
+
+func TestUpArgs(t *testing.T) {
+	SetUpArgs([]string{"--gpu-availability", "all"})
+	t.Cleanup(func() { SetUpArgs(nil) })
+
+	got := strings.Join(upArgs("/src/app", nil), " ")
+	if want := "up --workspace-folder /src/app --gpu-availability all"; got != want {
+		t.Errorf("upArgs() = %q, want %q", got, want)
+	}
+
+	// Extra args follow the worktree .git mount
+	wt := &WorktreeInfo{MainRepo: "/src/app", IsMain: false}
+	got = strings.Join(upArgs("/src/app-feature", wt), " ")
+	if !strings.HasSuffix(got, "target=/src/app/.git --gpu-availability all") {
+		t.Errorf("upArgs() = %q, want extra args after the mount", got)
+	}
+}
+
+func TestUpArgs_OverrideConfig(t *testing.T) {
+	t.Cleanup(func() { SetOverrideConfig("") })
+	with := t.TempDir()
+	writeFiles(t, with, map[string]string{".devcontainer/local.json": "{}"})
+	without := t.TempDir()
+
+	SetOverrideConfig(".devcontainer/local.json")
+	got := strings.Join(upArgs(with, nil), " ")
+	if want := "up --workspace-folder " + with + " --override-config " + filepath.Join(with, ".devcontainer/local.json"); got != want {
+		t.Errorf("upArgs() = %q, want %q", got, want)
+	}
+	// Projects without the relative file start from their own config alone
+	if got := upArgs(without, nil); slices.Contains(got, "--override-config") {
+		t.Errorf("upArgs() = %q, want no override for a project without the file", got)
+	}
+
+	SetOverrideConfig("/etc/shared.json")
+	if got := strings.Join(upArgs(without, nil), " "); !strings.HasSuffix(got, "--override-config /etc/shared.json") {
+		t.Errorf("upArgs() = %q, want the absolute override for every project", got)
+	}
+}
+
+func TestWorkspaceMismatch(t *testing.T) {
+	worktree := t.TempDir()
+	mainRepo := t.TempDir()
+	link := filepath.Join(t.TempDir(), "link")
+	if err := os.Symlink(worktree, link); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		workspace string
+		mounts    []containerMount
+		wantWarn  bool
+	}{
+		{"bind mount of the instance", "/workspaces/app",
+			[]containerMount{{"bind", worktree, "/workspaces/app"}}, false},
+		{"bind mount through a symlink", "/workspaces/app",
+			[]containerMount{{"bind", link, "/workspaces/app"}}, false},
+		{"Docker Desktop host path", "/workspaces/app/",
+			[]containerMount{{"bind", "/host_mnt" + worktree, "/workspaces/app"}}, false},
+		{"workspace mounted from the main repo", "/workspaces/app",
+			[]containerMount{{"bind", mainRepo, "/workspaces/app"}, {"bind", filepath.Join(mainRepo, ".git"), filepath.Join(mainRepo, ".git")}}, true},
+		{"a parent mount such as $HOME doesn't count", "/workspaces/main",
+			[]containerMount{{"bind", filepath.Dir(worktree), "/home/dev"}, {"bind", mainRepo, "/workspaces/main"}}, true},
+		{"workspace folder not mounted", "/workspaces/main",
+			[]containerMount{{"bind", worktree, "/workspaces/app"}}, true},
+		{"volume mounts can't be compared", "/workspaces/app",
+			[]containerMount{{"volume", "/var/lib/docker/volumes/app/_data", "/workspaces/app"}}, false},
+		{"unknown workspace folder", "",
+			[]containerMount{{"bind", mainRepo, "/workspaces/app"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := workspaceMismatch(worktree, tt.workspace, tt.mounts)
+			if (got != "") != tt.wantWarn {
+				t.Errorf("workspaceMismatch() = %q, want warning %v", got, tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestContainerWorkspaceFolder(t *testing.T) {
+	project := filepath.Join(t.TempDir(), "feature-x")
+	configDir := t.TempDir()
+	writeConfig := func(name, content string) string {
+		file := filepath.Join(configDir, name)
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+	hardcoded := writeConfig("hardcoded.json", `{
+		// Points every worktree at the main checkout
+		"workspaceFolder": "/workspaces/main",
+	}`)
+	basename := writeConfig("basename.json", `{"workspaceFolder": "/src/${localWorkspaceFolderBasename}"}`)
+	unset := writeConfig("unset.json", `{"image": "ubuntu"}`)
+	unknownVar := writeConfig("var.json", `{"workspaceFolder": "${containerEnv:HOME}/src"}`)
+	metadata := `[{"remoteUser":"dev"},{"workspaceFolder":"/meta"}]`
+
+	tests := []struct {
+		name                          string
+		configFile, metadata, workDir string
+		want                          string
+	}{
+		{"hardcoded in devcontainer.json", hardcoded, metadata, "/app", "/workspaces/main"},
+		{"basename variable", basename, "", "", "/src/feature-x"},
+		{"spec default", unset, metadata, "/app", "/workspaces/feature-x"},
+		{"unknown variable", unknownVar, "", "", ""},
+		{"metadata label without a config file", filepath.Join(configDir, "missing.json"), metadata, "/app", "/meta"},
+		{"working directory", "", "", "/app", "/app"},
+		{"nothing to go on", "", "", "/", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := containerWorkspaceFolder(project, tt.configFile, tt.metadata, tt.workDir); got != tt.want {
+				t.Errorf("containerWorkspaceFolder() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// BenchmarkGetAllInstancesStatus refreshes 20 running instances with and
+// without session counting. docker and devcontainer are stubbed: docker ps
+// answers at once and each devcontainer exec burns about 0.1s of CPU, a low
+// estimate of starting the node-based CLI, so the gap between the two runs is
+// the cost of the per-container tmux listing.
+func BenchmarkGetAllInstancesStatus(b *testing.B) {
+	if runtime.GOOS == "windows" {
+		b.Skip("stubs are shell scripts")
+	}
+	bin := b.TempDir()
+	stubs := map[string]string{
+		"docker":       "#!/bin/sh\necho c0ffee\n",
+		"devcontainer": "#!/bin/sh\nawk 'BEGIN { for (i = 0; i < 4000000; i++); }'\necho main:0\n",
+	}
+	for name, script := range stubs {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte(script), 0755); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	instances := make([]ContainerInstance, 20)
+	for i := range instances {
+		instances[i].Path = filepath.Join(bin, fmt.Sprintf("project-%d", i))
+	}
+	for _, countSessions := range []bool{true, false} {
+		b.Run(fmt.Sprintf("count_sessions=%v", countSessions), func(b *testing.B) {
+			for b.Loop() {
+				GetAllInstancesStatus(instances, countSessions, false)
+			}
+		})
+	}
+}
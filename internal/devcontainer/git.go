@@ -1,12 +1,17 @@
 package devcontainer
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/christophergyman/claude-quick/internal/constants"
 )
@@ -39,24 +44,96 @@ func IsGitWorktree(path string) *WorktreeInfo {
 	if !strings.HasPrefix(line, "gitdir: ") {
 		return nil
 	}
-	gitDir := strings.TrimPrefix(line, "gitdir: ")
-
-	// Find the main repo (go up from .git/worktrees/<name> to .git, then parent)
-	// gitDir is like /path/to/main/.git/worktrees/feature-x
-	mainGitDir := filepath.Dir(filepath.Dir(gitDir)) // Go up to .git
-	mainRepo := filepath.Dir(mainGitDir)             // Go up to main repo
+	// Relative gitdirs (submodules, worktree.useRelativePaths) are relative
+	// to the directory containing the .git file
+	gitDir := resolveGitPath(path, strings.TrimPrefix(line, "gitdir: "))
 
 	branch := getGitBranch(path)
 
+	commonDir := gitCommonDir(gitDir)
+	if filepath.Clean(commonDir) == filepath.Clean(gitDir) {
+		// Not a linked worktree: .git points at the repository's own git dir,
+		// as with submodules and --separate-git-dir checkouts
+		return &WorktreeInfo{
+			Path:     path,
+			Branch:   branch,
+			MainRepo: path,
+			GitDir:   gitDir,
+			IsMain:   true,
+		}
+	}
+
 	return &WorktreeInfo{
 		Path:     path,
 		Branch:   branch,
-		MainRepo: mainRepo,
+		MainRepo: mainRepoFromCommonDir(commonDir),
 		GitDir:   gitDir,
 		IsMain:   false,
 	}
 }
 
+// resolveGitPath returns p as an absolute path, resolving relative paths against base
+func resolveGitPath(base, p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.Clean(p)
+	}
+	return filepath.Join(base, p)
+}
+
+// gitCommonDir returns the repository directory shared by all worktrees,
+// read from the commondir file inside a linked worktree's gitdir. Without
+// that file, gitdirs under a "worktrees" directory fall back to the standard
+// layout; any other gitdir is its own common dir.
+func gitCommonDir(gitDir string) string {
+	if content, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		if commonDir := strings.TrimSpace(string(content)); commonDir != "" {
+			return resolveGitPath(gitDir, commonDir)
+		}
+	}
+	if filepath.Base(filepath.Dir(gitDir)) == "worktrees" {
+		return filepath.Dir(filepath.Dir(gitDir))
+	}
+	return gitDir
+}
+
+// mainRepoFromCommonDir returns the main worktree for a common git dir.
+// A ".git" directory sits inside its worktree; other layouts (such as a
+// submodule's .git/modules/<name>) record the worktree in core.worktree.
+func mainRepoFromCommonDir(commonDir string) string {
+	if filepath.Base(commonDir) == ".git" {
+		return filepath.Dir(commonDir)
+	}
+	if worktree := readCoreWorktree(commonDir); worktree != "" {
+		return resolveGitPath(commonDir, worktree)
+	}
+	return filepath.Dir(commonDir)
+}
+
+// readCoreWorktree returns the core.worktree value from a git dir's config,
+// or "" if it isn't set
+func readCoreWorktree(gitDir string) string {
+	file, err := os.Open(filepath.Join(gitDir, "config"))
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	inCore := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inCore = strings.EqualFold(strings.Trim(line, "[] \t"), "core")
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if inCore && found && strings.EqualFold(strings.TrimSpace(key), "worktree") {
+			return strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	return ""
+}
+
 // getGitBranch returns the current branch name for a git repository
 func getGitBranch(repoPath string) string {
 	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--abbrev-ref", "HEAD")
@@ -134,9 +211,47 @@ func GetMainRepo(worktreePath string) (string, error) {
 	return info.MainRepo, nil
 }
 
+// worktreeDir returns the preferred directory for a branch's worktree.
+// Flat layout (default): sibling "repo-feature-auth", with "/" replaced by "-".
+// Nested layout: "repo-worktrees/feature/auth", keeping the branch hierarchy.
+func worktreeDir(mainRepo, branchName string, nested bool) string {
+	repoName := filepath.Base(mainRepo)
+	parent := filepath.Dir(mainRepo)
+	if nested {
+		return filepath.Join(parent, repoName+constants.NestedWorktreeDirSuffix, filepath.FromSlash(branchName))
+	}
+	safeBranchName := strings.ReplaceAll(branchName, "/", "-")
+	return filepath.Join(parent, repoName+"-"+safeBranchName)
+}
+
+// resolveWorktreePath picks a free directory for the branch's worktree.
+// If the preferred directory is taken by a different branch (e.g., "feature/auth"
+// and "feature-auth" both flatten to the same name), a numeric suffix is appended.
+// Returns an error if the branch already has a worktree at that location.
+func resolveWorktreePath(mainRepo, branchName string, nested bool) (string, error) {
+	base := worktreeDir(mainRepo, branchName, nested)
+	path := base
+	for i := 2; i <= constants.MaxWorktreePathSuffix; i++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return path, nil
+		}
+		if existing := IsGitWorktree(path); existing != nil && existing.Branch == branchName {
+			return "", fmt.Errorf("worktree for branch %q already exists: %s", branchName, path)
+		}
+		path = fmt.Sprintf("%s-%d", base, i)
+	}
+	return "", fmt.Errorf("worktree directory already exists: %s (and %d alternatives)", base, constants.MaxWorktreePathSuffix-1)
+}
+
 // CreateWorktree creates a new git worktree with a new branch
-// Returns the path to the new worktree directory and any push warning
-func CreateWorktree(repoPath, branchName string, autoPush bool) (worktreePath string, pushWarning string, err error) {
+// The new branch starts from base, a local branch, or the main repo's HEAD if base is empty
+// If the branch only exists on origin, the new branch tracks origin/<branch> instead
+// (without a base; branching off base always needs a new branch name)
+// If pushRemote is set, a newly created branch is pushed there with upstream tracking
+// If nested is true, the worktree directory mirrors the branch hierarchy
+// Returns the path to the new worktree directory and a notice for the user
+// (a push failure, or that an existing remote branch is being tracked)
+func CreateWorktree(repoPath, branchName, base, pushRemote string, nested bool) (worktreePath string, notice string, err error) {
 	// Validate branch name
 	if err := ValidateBranchName(branchName); err != nil {
 		return "", "", err
@@ -151,31 +266,46 @@ func CreateWorktree(repoPath, branchName string, autoPush bool) (worktreePath st
 	// Get the main repo path
 	mainRepo := wtInfo.MainRepo
 
+	// The base is resolved from the main repo, which shares every worktree's branches
+	if base != "" {
+		verifyBase := exec.Command("git", "-C", mainRepo, "rev-parse", "--verify", "--quiet", "refs/heads/"+base)
+		if err := verifyBase.Run(); err != nil {
+			return "", "", fmt.Errorf("base branch %q not found", base)
+		}
+	}
+
 	// Prune stale worktree entries before attempting to create
 	// This handles cases where directories were manually deleted
 	pruneCmd := exec.Command("git", "-C", mainRepo, "worktree", "prune")
 	_ = pruneCmd.Run() // Ignore errors - prune is best-effort cleanup
 
-	// Create worktree path as sibling directory: repo-branchname
-	// Replace "/" with "-" to avoid creating nested directories for hierarchical branches
-	repoName := filepath.Base(mainRepo)
-	safeBranchName := strings.ReplaceAll(branchName, "/", "-")
-	wtPath := filepath.Join(filepath.Dir(mainRepo), repoName+"-"+safeBranchName)
-
-	// Check if worktree already exists
-	if _, err := os.Stat(wtPath); err == nil {
-		return "", "", fmt.Errorf("worktree directory already exists: %s", wtPath)
+	// Pick the worktree directory, disambiguating collisions with other branches
+	wtPath, err := resolveWorktreePath(mainRepo, branchName, nested)
+	if err != nil {
+		return "", "", err
 	}
 
-	// Check if branch already exists
+	// Check if branch already exists locally, or failing that on origin
 	checkBranch := exec.Command("git", "-C", mainRepo, "rev-parse", "--verify", branchName)
 	branchExists := checkBranch.Run() == nil
+	if branchExists && base != "" {
+		return "", "", fmt.Errorf("branch %q already exists", branchName)
+	}
+	trackRemote := !branchExists && base == "" && remoteBranchExists(mainRepo, branchName)
 
-	// Create the worktree - use existing branch or create new one
+	// Create the worktree - use existing branch, track the remote one, or create new one
 	var cmd *exec.Cmd
-	if branchExists {
+	switch {
+	case branchExists:
 		cmd = exec.Command("git", "-C", mainRepo, "worktree", "add", wtPath, branchName)
-	} else {
+	case trackRemote:
+		if err := fetchRemoteBranch(mainRepo, branchName); err != nil {
+			return "", "", err
+		}
+		cmd = exec.Command("git", "-C", mainRepo, "worktree", "add", "--track", "-b", branchName, wtPath, "origin/"+branchName)
+	case base != "":
+		cmd = exec.Command("git", "-C", mainRepo, "worktree", "add", "-b", branchName, wtPath, base)
+	default:
 		cmd = exec.Command("git", "-C", mainRepo, "worktree", "add", "-b", branchName, wtPath)
 	}
 	var stderr bytes.Buffer
@@ -185,18 +315,106 @@ func CreateWorktree(repoPath, branchName string, autoPush bool) (worktreePath st
 		return "", "", fmt.Errorf("failed to create worktree: %s", stderr.String())
 	}
 
+	// A tracked remote branch already has an upstream, so there is nothing to push
+	if trackRemote {
+		return wtPath, fmt.Sprintf("Tracking existing remote branch origin/%s", branchName), nil
+	}
+
 	// Push new branch upstream with tracking if enabled and branch is new
-	if autoPush && !branchExists {
-		pushCmd := exec.Command("git", "-C", mainRepo, "push", "-u", "origin", branchName)
+	if pushRemote != "" && !branchExists {
+		if !remoteExists(mainRepo, pushRemote) {
+			return wtPath, fmt.Sprintf("Branch created but not pushed: remote %q does not exist", pushRemote), nil
+		}
+		pushCmd := exec.Command("git", "-C", mainRepo, "push", "-u", pushRemote, branchName)
 		var pushStderr bytes.Buffer
 		pushCmd.Stderr = &pushStderr
 		if err := pushCmd.Run(); err != nil {
-			pushWarning = fmt.Sprintf("Branch created but push failed: %s",
+			notice = fmt.Sprintf("Branch created but push failed: %s",
 				strings.TrimSpace(pushStderr.String()))
 		}
 	}
 
-	return wtPath, pushWarning, nil
+	return wtPath, notice, nil
+}
+
+// LastCommitTime returns when the commit checked out at path was made.
+// git gets LastCommitTimeout seconds, so a slow repository can't hold up a
+// status refresh.
+func LastCommitTime(path string) (time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.LastCommitTimeout*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "git", "-C", path, "log", "-1", "--format=%ct").Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read last commit: %w", err)
+	}
+	secs, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected git log output %q", out)
+	}
+	return time.Unix(secs, 0), nil
+}
+
+// RunPostCreateCommand runs a shell command on the host in a newly created
+// worktree (e.g. to copy untracked .env files or install dependencies).
+// CLAUDE_QUICK_MAIN_REPO is set to the main repository's path so the command
+// can copy files from it. The error includes the command's output.
+func RunPostCreateCommand(worktreePath, command string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.PostCreateTimeout*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = worktreePath
+	cmd.Env = os.Environ()
+	if mainRepo, err := GetMainRepo(worktreePath); err == nil {
+		cmd.Env = append(cmd.Env, "CLAUDE_QUICK_MAIN_REPO="+mainRepo)
+	}
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("post-create command timed out after %ds", constants.PostCreateTimeout)
+	}
+	if err != nil {
+		if out := strings.TrimSpace(string(output)); out != "" {
+			return fmt.Errorf("post-create command failed: %v: %s", err, out)
+		}
+		return fmt.Errorf("post-create command failed: %v", err)
+	}
+	return nil
+}
+
+// remoteExists reports whether the repository has a remote with the given name
+func remoteExists(repoPath, remote string) bool {
+	output, err := exec.Command("git", "-C", repoPath, "remote").Output()
+	if err != nil {
+		return false
+	}
+	for _, name := range strings.Fields(string(output)) {
+		if name == remote {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteBranchExists reports whether origin has a branch with the given name.
+// Any failure (no origin, offline, auth required) is treated as not existing.
+func remoteBranchExists(repoPath, branchName string) bool {
+	cmd := exec.Command("git", "-C", repoPath, "ls-remote", "--exit-code", "--heads", "origin", branchName)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	return cmd.Run() == nil
+}
+
+// fetchRemoteBranch updates the origin/<branch> remote-tracking ref
+func fetchRemoteBranch(repoPath, branchName string) error {
+	refspec := fmt.Sprintf("+refs/heads/%s:refs/remotes/origin/%s", branchName, branchName)
+	cmd := exec.Command("git", "-C", repoPath, "fetch", "-q", "origin", refspec)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to fetch remote branch %s: %s", branchName, strings.TrimSpace(stderr.String()))
+	}
+	return nil
 }
 
 // RemoveWorktree removes a git worktree
@@ -204,8 +422,8 @@ func CreateWorktree(repoPath, branchName string, autoPush bool) (worktreePath st
 func RemoveWorktree(worktreePath string, mainRepoPath ...string) error {
 	// Stop any running Docker container for this worktree first and wait for full cleanup
 	if err := Stop(worktreePath); err != nil {
-		// Ignore "no running container" - that's expected if container isn't running
-		if !strings.Contains(err.Error(), "no running container") {
+		// Ignore a missing container - that's expected if it isn't running
+		if !errors.Is(err, ErrNoContainer) {
 			return fmt.Errorf("failed to stop container: %w", err)
 		}
 	}
@@ -242,12 +460,97 @@ func RemoveWorktree(worktreePath string, mainRepoPath ...string) error {
 	return nil
 }
 
+// UnpushedCommits counts the commits on a local branch that neither a
+// remote-tracking branch nor another local branch contains, i.e. the work lost
+// if the branch is deleted. Without a remote that is every commit no other
+// local branch has.
+func UnpushedCommits(repoPath, branch string) (int, error) {
+	cmd := exec.Command("git", "-C", repoPath, "rev-list", "--count", "refs/heads/"+branch,
+		"--not", "--remotes", "--exclude="+branch, "--branches")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unpushed commits on %s: %s", branch, strings.TrimSpace(stderr.String()))
+	}
+	return strconv.Atoi(strings.TrimSpace(string(output)))
+}
+
+// DeleteBranch force-deletes a local branch, as git worktree remove leaves it behind.
+// Reserved branches are never deleted.
+func DeleteBranch(repoPath, branch string) error {
+	if isReservedBranch(branch) {
+		return fmt.Errorf("refusing to delete reserved branch %s", branch)
+	}
+	cmd := exec.Command("git", "-C", repoPath, "branch", "-D", branch)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %s", branch, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// ResolveBaseRef returns the ref a worktree's commits should be compared against.
+// Non-main worktrees compare against the main repo's current branch; the main
+// worktree compares against its upstream tracking branch.
+func ResolveBaseRef(wt *WorktreeInfo) (string, error) {
+	if wt == nil {
+		return "", fmt.Errorf("not a git repository")
+	}
+
+	if !wt.IsMain {
+		base := getGitBranch(wt.MainRepo)
+		if base == constants.DefaultBranchUnknown || base == "HEAD" {
+			return "", fmt.Errorf("cannot determine base branch: main repository is not on a branch")
+		}
+		return base, nil
+	}
+
+	cmd := exec.Command("git", "-C", wt.Path, "rev-parse", "--abbrev-ref", "@{upstream}")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("no upstream branch configured for %s", wt.Branch)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// CommitsSinceBase returns the one-line summaries of commits on HEAD that are not on base
+// Returns an empty slice (not nil) if there are no such commits
+func CommitsSinceBase(worktreePath, base string) ([]string, error) {
+	if base == "" {
+		return nil, fmt.Errorf("no base branch specified")
+	}
+
+	// Verify the base exists so a missing branch gives a clear error
+	verifyCmd := exec.Command("git", "-C", worktreePath, "rev-parse", "--verify", "--quiet", base)
+	if err := verifyCmd.Run(); err != nil {
+		return nil, fmt.Errorf("base branch %q not found", base)
+	}
+
+	cmd := exec.Command("git", "-C", worktreePath, "log", "--oneline", base+"..HEAD")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	commits := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			commits = append(commits, line)
+		}
+	}
+	return commits, nil
+}
+
 // ValidateBranchName checks if a branch name is valid for git
 func ValidateBranchName(name string) error {
 	if name == "" {
 		return fmt.Errorf("branch name cannot be empty")
 	}
-	if constants.IsReservedBranchName(name) {
+	if isReservedBranch(name) {
 		return fmt.Errorf("'%s' is a reserved branch name", name)
 	}
 	// Git branch name rules (simplified)
@@ -268,3 +571,144 @@ func ValidateBranchName(name string) error {
 	}
 	return nil
 }
+
+// MoveWorktree relocates a linked worktree with git worktree move, creating
+// the parent of newPath if needed. The main worktree can't be moved.
+func MoveWorktree(oldPath, newPath string) error {
+	wtInfo := IsGitWorktree(oldPath)
+	if wtInfo == nil {
+		return fmt.Errorf("not a git worktree")
+	}
+	if wtInfo.IsMain {
+		return fmt.Errorf("cannot move the main worktree")
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("destination already exists: %s", newPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		return fmt.Errorf("failed to create parent directory: %w", err)
+	}
+
+	cmd := exec.Command("git", "-C", wtInfo.MainRepo, "worktree", "move", oldPath, newPath)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to move worktree: %s", strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// reservedBranches holds configured branch names blocked in addition to
+// constants.ReservedBranchNames
+var reservedBranches = map[string]bool{}
+
+// SetReservedBranches sets extra branch names that ValidateBranchName rejects,
+// on top of the built-in main and master. A nil list restores the defaults.
+func SetReservedBranches(names []string) {
+	reservedBranches = make(map[string]bool, len(names))
+	for _, name := range names {
+		reservedBranches[name] = true
+	}
+}
+
+// isReservedBranch reports whether name is a built-in or configured reserved branch
+func isReservedBranch(name string) bool {
+	return constants.IsReservedBranchName(name) || reservedBranches[name]
+}
+
+// ValidateCloneURL checks that a clone source looks like a git remote URL:
+// https://, http://, ssh://, git://, file://, or scp-like user@host:path
+func ValidateCloneURL(url string) error {
+	if url == "" {
+		return fmt.Errorf("repository URL cannot be empty")
+	}
+	if strings.HasPrefix(url, "-") {
+		return fmt.Errorf("repository URL cannot start with '-'")
+	}
+	if strings.ContainsAny(url, " \t\n") {
+		return fmt.Errorf("repository URL cannot contain whitespace")
+	}
+	for _, scheme := range []string{"https://", "http://", "ssh://", "git://", "file://"} {
+		if strings.HasPrefix(url, scheme) && len(url) > len(scheme) {
+			return nil
+		}
+	}
+	// scp-like syntax: git@github.com:owner/repo.git
+	if at, colon := strings.Index(url, "@"), strings.Index(url, ":"); at > 0 && colon > at+1 && colon < len(url)-1 {
+		return nil
+	}
+	return fmt.Errorf("unsupported repository URL: %s", url)
+}
+
+// RepoNameFromURL returns the directory name git clone would use for url
+// (e.g., "https://github.com/owner/repo.git" -> "repo")
+func RepoNameFromURL(url string) string {
+	name := strings.TrimRight(url, "/")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.TrimSuffix(name, ".git")
+}
+
+// CloneRepo clones url into destDir, which must not already exist.
+// If onProgress is non-nil it is called with each progress line git reports
+// (e.g., "Receiving objects:  42% (420/1000)").
+func CloneRepo(url, destDir string, onProgress func(line string)) error {
+	if err := ValidateCloneURL(url); err != nil {
+		return err
+	}
+	if destDir == "" {
+		return fmt.Errorf("clone destination cannot be empty")
+	}
+	if _, err := os.Stat(destDir); err == nil {
+		return fmt.Errorf("destination already exists: %s", destDir)
+	}
+	if info, err := os.Stat(filepath.Dir(destDir)); err != nil || !info.IsDir() {
+		return fmt.Errorf("destination parent directory does not exist: %s", filepath.Dir(destDir))
+	}
+
+	cmd := exec.Command("git", "clone", "--progress", url, destDir)
+	// Fail instead of prompting for credentials, since there is no terminal to answer
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("failed to clone repository: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to clone repository: %w", err)
+	}
+
+	// git rewrites progress lines with \r, so split on either line ending
+	var lastLine string
+	scanner := bufio.NewScanner(stderr)
+	scanner.Split(scanProgressLines)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		lastLine = line
+		if onProgress != nil {
+			onProgress(line)
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("failed to clone repository: %s", lastLine)
+	}
+	return nil
+}
+
+// scanProgressLines is a bufio.SplitFunc that splits on \n or \r
+func scanProgressLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -1,10 +1,13 @@
 package devcontainer
 
 import (
+	"bufio"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidateBranchName(t *testing.T) {
@@ -55,6 +58,27 @@ func TestValidateBranchName(t *testing.T) {
 	}
 }
 
+func TestValidateBranchName_ConfiguredReserved(t *testing.T) {
+	SetReservedBranches([]string{"develop", "release"})
+	t.Cleanup(func() { SetReservedBranches(nil) })
+
+	for _, name := range []string{"develop", "release", "main", "master"} {
+		if err := ValidateBranchName(name); err == nil || !strings.Contains(err.Error(), "reserved branch name") {
+			t.Errorf("ValidateBranchName(%q) = %v, want reserved error", name, err)
+		}
+	}
+	for _, name := range []string{"develop-x", "release/1", "trunk"} {
+		if err := ValidateBranchName(name); err != nil {
+			t.Errorf("ValidateBranchName(%q) = %v, want nil", name, err)
+		}
+	}
+
+	SetReservedBranches(nil)
+	if err := ValidateBranchName("develop"); err != nil {
+		t.Errorf("ValidateBranchName(develop) after reset = %v, want nil", err)
+	}
+}
+
 func TestIsGitWorktree_RegularGitRepo(t *testing.T) {
 	// Create a temporary directory with a .git directory (simulating regular repo)
 	tmpDir, err := os.MkdirTemp("", "test-git-repo")
@@ -156,6 +180,98 @@ func TestIsGitWorktree_WorktreeFile(t *testing.T) {
 	}
 }
 
+// writeFiles creates each file (and its parent directories) under baseDir
+func writeFiles(t *testing.T, baseDir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(baseDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestIsGitWorktree_GitdirLayouts(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        map[string]string
+		path         string
+		wantMainRepo string
+		wantGitDir   string
+		wantIsMain   bool
+	}{
+		{
+			name: "relative gitdir with commondir",
+			files: map[string]string{
+				"repo/.git/HEAD":                     "ref: refs/heads/main\n",
+				"repo/.git/worktrees/feat/commondir": "../..\n",
+				"feat/.git":                          "gitdir: ../repo/.git/worktrees/feat\n",
+			},
+			path:         "feat",
+			wantMainRepo: "repo",
+			wantGitDir:   "repo/.git/worktrees/feat",
+		},
+		{
+			name: "commondir outside the standard layout",
+			files: map[string]string{
+				"store/repo.git/config":  "[core]\n\tbare = false\n",
+				"gitdirs/feat/commondir": "../../store/repo.git\n",
+				"feat/.git":              "gitdir: ../gitdirs/feat\n",
+			},
+			path:         "feat",
+			wantMainRepo: "store",
+			wantGitDir:   "gitdirs/feat",
+		},
+		{
+			name: "submodule",
+			files: map[string]string{
+				"super/.git/modules/lib/config": "[core]\n\tworktree = ../../../lib\n",
+				"super/lib/.git":                "gitdir: ../.git/modules/lib\n",
+			},
+			path:         "super/lib",
+			wantMainRepo: "super/lib",
+			wantGitDir:   "super/.git/modules/lib",
+			wantIsMain:   true,
+		},
+		{
+			name: "worktree of a submodule",
+			files: map[string]string{
+				"super/.git/modules/lib/config":                   "[core]\n\tworktree = ../../../lib\n",
+				"super/.git/modules/lib/worktrees/feat/commondir": "../..\n",
+				"lib-feat/.git": "gitdir: ../super/.git/modules/lib/worktrees/feat\n",
+			},
+			path:         "lib-feat",
+			wantMainRepo: "super/lib",
+			wantGitDir:   "super/.git/modules/lib/worktrees/feat",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseDir := t.TempDir()
+			writeFiles(t, baseDir, tt.files)
+
+			path := filepath.Join(baseDir, tt.path)
+			result := IsGitWorktree(path)
+			if result == nil {
+				t.Fatal("IsGitWorktree returned nil")
+			}
+			if want := filepath.Join(baseDir, tt.wantMainRepo); result.MainRepo != want {
+				t.Errorf("MainRepo = %q, want %q", result.MainRepo, want)
+			}
+			if want := filepath.Join(baseDir, tt.wantGitDir); result.GitDir != want {
+				t.Errorf("GitDir = %q, want %q", result.GitDir, want)
+			}
+			if result.IsMain != tt.wantIsMain {
+				t.Errorf("IsMain = %v, want %v", result.IsMain, tt.wantIsMain)
+			}
+		})
+	}
+}
+
 func TestIsGitWorktree_InvalidGitFile(t *testing.T) {
 	// Create a temporary directory with an invalid .git file
 	tmpDir, err := os.MkdirTemp("", "test-invalid-git")
@@ -202,3 +318,507 @@ func TestParseWorktreeListOutput(t *testing.T) {
 	t.Log("ListWorktrees parses 'git worktree list --porcelain' output")
 	t.Log("Expected format: worktree <path>, HEAD <sha>, branch refs/heads/<name>")
 }
+
+func TestResolveBaseRef_Nil(t *testing.T) {
+	if _, err := ResolveBaseRef(nil); err == nil {
+		t.Error("ResolveBaseRef(nil) should return an error")
+	}
+}
+
+func TestCommitsSinceBase_Errors(t *testing.T) {
+	if _, err := CommitsSinceBase(t.TempDir(), ""); err == nil {
+		t.Error("CommitsSinceBase with empty base should return an error")
+	}
+
+	// A directory that is not a git repository has no base to compare against
+	_, err := CommitsSinceBase(t.TempDir(), "main")
+	if err == nil {
+		t.Fatal("CommitsSinceBase outside a git repository should return an error")
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		t.Errorf("error = %q, should mention the base was not found", err)
+	}
+}
+
+func TestWorktreeDir(t *testing.T) {
+	mainRepo := filepath.Join("/src", "repo")
+	tests := []struct {
+		name   string
+		branch string
+		nested bool
+		want   string
+	}{
+		{"flat simple", "feature", false, filepath.Join("/src", "repo-feature")},
+		{"flat hierarchical", "feature/auth", false, filepath.Join("/src", "repo-feature-auth")},
+		{"nested simple", "feature", true, filepath.Join("/src", "repo-worktrees", "feature")},
+		{"nested hierarchical", "feature/auth", true, filepath.Join("/src", "repo-worktrees", "feature", "auth")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := worktreeDir(mainRepo, tt.branch, tt.nested); got != tt.want {
+				t.Errorf("worktreeDir(%q, %v) = %q, want %q", tt.branch, tt.nested, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveWorktreePath_Collision(t *testing.T) {
+	baseDir := t.TempDir()
+	mainRepo := filepath.Join(baseDir, "repo")
+
+	// Free path is used as-is
+	got, err := resolveWorktreePath(mainRepo, "feature/auth", false)
+	if err != nil {
+		t.Fatalf("resolveWorktreePath() error = %v", err)
+	}
+	if want := filepath.Join(baseDir, "repo-feature-auth"); got != want {
+		t.Errorf("resolveWorktreePath() = %q, want %q", got, want)
+	}
+
+	// A directory for another branch (e.g., "feature-auth") gets a suffix
+	if err := os.Mkdir(filepath.Join(baseDir, "repo-feature-auth"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	got, err = resolveWorktreePath(mainRepo, "feature/auth", false)
+	if err != nil {
+		t.Fatalf("resolveWorktreePath() error = %v", err)
+	}
+	if want := filepath.Join(baseDir, "repo-feature-auth-2"); got != want {
+		t.Errorf("resolveWorktreePath() = %q, want %q", got, want)
+	}
+
+	if err := os.Mkdir(filepath.Join(baseDir, "repo-feature-auth-2"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = resolveWorktreePath(mainRepo, "feature/auth", false)
+	if want := filepath.Join(baseDir, "repo-feature-auth-3"); got != want {
+		t.Errorf("resolveWorktreePath() = %q, want %q", got, want)
+	}
+}
+
+func TestResolveWorktreePath_Nested(t *testing.T) {
+	baseDir := t.TempDir()
+	mainRepo := filepath.Join(baseDir, "repo")
+
+	got, err := resolveWorktreePath(mainRepo, "feature/auth", true)
+	if err != nil {
+		t.Fatalf("resolveWorktreePath() error = %v", err)
+	}
+	if want := filepath.Join(baseDir, "repo-worktrees", "feature", "auth"); got != want {
+		t.Errorf("resolveWorktreePath() = %q, want %q", got, want)
+	}
+
+	// Nested paths keep "feature/auth" and "feature-auth" apart
+	got, err = resolveWorktreePath(mainRepo, "feature-auth", true)
+	if err != nil {
+		t.Fatalf("resolveWorktreePath() error = %v", err)
+	}
+	if want := filepath.Join(baseDir, "repo-worktrees", "feature-auth"); got != want {
+		t.Errorf("resolveWorktreePath() = %q, want %q", got, want)
+	}
+}
+
+func TestResolveWorktreePath_SameBranchExists(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	baseDir := t.TempDir()
+	mainRepo := filepath.Join(baseDir, "repo")
+	existing := filepath.Join(baseDir, "repo-feature-auth")
+
+	// Simulate an existing checkout of the same branch at the target path
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "feature/auth", existing},
+		{"-C", existing, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git setup failed: %v: %s", err, out)
+		}
+	}
+
+	_, err := resolveWorktreePath(mainRepo, "feature/auth", false)
+	if err == nil {
+		t.Fatal("resolveWorktreePath() should fail when the branch already has a worktree there")
+	}
+	if !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("error = %q, should say the worktree already exists", err)
+	}
+}
+
+func TestValidateCloneURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://github.com/owner/repo.git", false},
+		{"ssh://git@github.com/owner/repo.git", false},
+		{"git@github.com:owner/repo.git", false},
+		{"file:///srv/git/repo.git", false},
+		{"", true},
+		{"https://", true},
+		{"--upload-pack=evil", true},
+		{"https://github.com/owner/my repo", true},
+		{"github.com/owner/repo", true},
+		{"git@github.com:", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := ValidateCloneURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCloneURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRepoNameFromURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/owner/repo.git", "repo"},
+		{"https://github.com/owner/repo", "repo"},
+		{"https://github.com/owner/repo/", "repo"},
+		{"git@github.com:owner/repo.git", "repo"},
+		{"git@host:repo.git", "repo"},
+	}
+
+	for _, tt := range tests {
+		if got := RepoNameFromURL(tt.url); got != tt.want {
+			t.Errorf("RepoNameFromURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestScanProgressLines(t *testing.T) {
+	input := "Cloning into 'repo'...\nReceiving objects:  50% (1/2)\rReceiving objects: 100% (2/2), done.\n"
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	scanner.Split(scanProgressLines)
+
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	want := []string{"Cloning into 'repo'...", "Receiving objects:  50% (1/2)", "Receiving objects: 100% (2/2), done."}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+}
+
+func TestCloneRepo_DestinationExists(t *testing.T) {
+	dest := t.TempDir()
+	err := CloneRepo("https://github.com/owner/repo.git", dest, nil)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("CloneRepo() error = %v, want destination exists error", err)
+	}
+}
+
+func TestCloneRepo_Local(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	baseDir := t.TempDir()
+	source := filepath.Join(baseDir, "source")
+	for _, args := range [][]string{
+		{"init", "-q", source},
+		{"-C", source, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git setup failed: %v: %s", err, out)
+		}
+	}
+
+	dest := filepath.Join(baseDir, "clone")
+	var progress []string
+	if err := CloneRepo("file://"+source, dest, func(line string) { progress = append(progress, line) }); err != nil {
+		t.Fatalf("CloneRepo() error = %v", err)
+	}
+	if IsGitWorktree(dest) == nil {
+		t.Error("destination should be a git repository")
+	}
+	if len(progress) == 0 {
+		t.Error("should report progress lines")
+	}
+}
+
+// setupRepoWithOrigin creates an origin repository with a "remote-only" branch and
+// a clone of it in which that branch does not exist locally
+func setupRepoWithOrigin(t *testing.T) (baseDir, clone string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	baseDir = t.TempDir()
+	origin := filepath.Join(baseDir, "origin")
+	clone = filepath.Join(baseDir, "repo")
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main", origin},
+		{"-C", origin, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"clone", "-q", origin, clone},
+		{"-C", origin, "branch", "remote-only"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git setup failed: %v: %s", err, out)
+		}
+	}
+	return baseDir, clone
+}
+
+func TestRemoteBranchExists(t *testing.T) {
+	baseDir, clone := setupRepoWithOrigin(t)
+
+	tests := []struct {
+		name   string
+		repo   string
+		branch string
+		want   bool
+	}{
+		{"branch on origin", clone, "remote-only", true},
+		{"default branch on origin", clone, "main", true},
+		{"missing branch", clone, "no-such-branch", false},
+		{"no origin remote", filepath.Join(baseDir, "origin"), "main", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := remoteBranchExists(tt.repo, tt.branch); got != tt.want {
+				t.Errorf("remoteBranchExists(%q) = %v, want %v", tt.branch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateWorktree_TracksRemoteBranch(t *testing.T) {
+	baseDir, clone := setupRepoWithOrigin(t)
+
+	wtPath, notice, err := CreateWorktree(clone, "remote-only", "", "", false)
+	if err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+	if want := filepath.Join(baseDir, "repo-remote-only"); wtPath != want {
+		t.Errorf("worktree path = %q, want %q", wtPath, want)
+	}
+	if !strings.Contains(notice, "origin/remote-only") {
+		t.Errorf("notice = %q, should mention the tracked remote branch", notice)
+	}
+
+	out, err := exec.Command("git", "-C", wtPath, "rev-parse", "--abbrev-ref", "@{upstream}").Output()
+	if err != nil {
+		t.Fatalf("worktree branch has no upstream: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "origin/remote-only" {
+		t.Errorf("upstream = %q, want origin/remote-only", got)
+	}
+}
+
+func TestRemoteExists(t *testing.T) {
+	_, clone := setupRepoWithOrigin(t)
+
+	if !remoteExists(clone, "origin") {
+		t.Error("remoteExists(origin) = false, want true")
+	}
+	if remoteExists(clone, "upstream") {
+		t.Error("remoteExists(upstream) = true, want false")
+	}
+}
+
+func TestCreateWorktree_PushRemote(t *testing.T) {
+	baseDir, clone := setupRepoWithOrigin(t)
+	fork := filepath.Join(baseDir, "fork.git")
+	for _, args := range [][]string{
+		{"init", "-q", "--bare", fork},
+		{"-C", clone, "remote", "add", "fork", fork},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git setup failed: %v: %s", err, out)
+		}
+	}
+
+	// Pushes to the configured remote rather than origin
+	_, notice, err := CreateWorktree(clone, "pushed", "", "fork", false)
+	if err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+	if notice != "" {
+		t.Errorf("notice = %q, want none", notice)
+	}
+	if err := exec.Command("git", "-C", fork, "rev-parse", "--verify", "refs/heads/pushed").Run(); err != nil {
+		t.Error("branch should have been pushed to the fork remote")
+	}
+
+	// A missing remote leaves the branch local and says why
+	_, notice, err = CreateWorktree(clone, "unpushed", "", "upstream", false)
+	if err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+	if !strings.Contains(notice, `remote "upstream" does not exist`) {
+		t.Errorf("notice = %q, should explain the missing remote", notice)
+	}
+}
+
+func TestMoveWorktree(t *testing.T) {
+	baseDir, clone := setupRepoWithOrigin(t)
+
+	wtPath, _, err := CreateWorktree(clone, "moving", "", "", false)
+	if err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+
+	newPath := filepath.Join(baseDir, "worktrees", "moving")
+	if err := MoveWorktree(wtPath, newPath); err != nil {
+		t.Fatalf("MoveWorktree() error = %v", err)
+	}
+	if _, err := os.Stat(wtPath); !os.IsNotExist(err) {
+		t.Error("old worktree directory should be gone")
+	}
+	info := IsGitWorktree(newPath)
+	if info == nil || info.IsMain || info.MainRepo != clone {
+		t.Errorf("IsGitWorktree(new path) = %+v, want a linked worktree of %s", info, clone)
+	}
+
+	if err := MoveWorktree(clone, filepath.Join(baseDir, "elsewhere")); err == nil || !strings.Contains(err.Error(), "main worktree") {
+		t.Errorf("MoveWorktree(main) = %v, want main worktree error", err)
+	}
+	if err := MoveWorktree(newPath, clone); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("MoveWorktree(onto existing dir) = %v, want already exists error", err)
+	}
+}
+
+func TestUnpushedCommitsAndDeleteBranch(t *testing.T) {
+	_, clone := setupRepoWithOrigin(t)
+	for _, args := range [][]string{
+		{"-C", clone, "branch", "pushed"},
+		{"-C", clone, "checkout", "-q", "-b", "local"},
+		{"-C", clone, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "one"},
+		{"-C", clone, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "two"},
+		{"-C", clone, "checkout", "-q", "-b", "shared", "main"},
+		{"-C", clone, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "three"},
+		{"-C", clone, "branch", "shared-copy"},
+		{"-C", clone, "checkout", "-q", "main"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	// Commits another local branch still holds aren't lost with the branch
+	for branch, want := range map[string]int{"pushed": 0, "local": 2, "shared": 0} {
+		got, err := UnpushedCommits(clone, branch)
+		if err != nil {
+			t.Fatalf("UnpushedCommits(%s) error = %v", branch, err)
+		}
+		if got != want {
+			t.Errorf("UnpushedCommits(%s) = %d, want %d", branch, got, want)
+		}
+	}
+	if _, err := UnpushedCommits(clone, "no-such-branch"); err == nil {
+		t.Error("UnpushedCommits() should fail for a missing branch")
+	}
+
+	noRemote := filepath.Join(t.TempDir(), "local-only")
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main", noRemote},
+		{"-C", noRemote, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+		{"-C", noRemote, "checkout", "-q", "-b", "feature"},
+		{"-C", noRemote, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "only here"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	// Without a remote the branch is the only copy of its own commits
+	if got, err := UnpushedCommits(noRemote, "feature"); err != nil || got != 1 {
+		t.Errorf("UnpushedCommits(no remote) = %d, %v; want 1", got, err)
+	}
+
+	if err := DeleteBranch(clone, "local"); err != nil {
+		t.Fatalf("DeleteBranch() error = %v", err)
+	}
+	if err := exec.Command("git", "-C", clone, "rev-parse", "--verify", "--quiet", "refs/heads/local").Run(); err == nil {
+		t.Error("branch local should be deleted")
+	}
+	if err := DeleteBranch(clone, "main"); err == nil {
+		t.Error("DeleteBranch() should refuse a reserved branch")
+	}
+}
+
+func TestCreateWorktree_FromBase(t *testing.T) {
+	baseDir, clone := setupRepoWithOrigin(t)
+
+	featurePath, _, err := CreateWorktree(clone, "feature", "", "", false)
+	if err != nil {
+		t.Fatalf("CreateWorktree(feature) error = %v", err)
+	}
+	commit := exec.Command("git", "-C", featurePath, "-c", "user.name=test", "-c", "user.email=test@example.com",
+		"commit", "-q", "--allow-empty", "-m", "feature work")
+	if out, err := commit.CombinedOutput(); err != nil {
+		t.Fatalf("git commit: %v: %s", err, out)
+	}
+
+	// Branching off the feature worktree's branch keeps its commits
+	wtPath, _, err := CreateWorktree(featurePath, "feature-followup", "feature", "", false)
+	if err != nil {
+		t.Fatalf("CreateWorktree(feature-followup) error = %v", err)
+	}
+	if want := filepath.Join(baseDir, "repo-feature-followup"); wtPath != want {
+		t.Errorf("worktree path = %q, want %q", wtPath, want)
+	}
+	head, _ := exec.Command("git", "-C", wtPath, "rev-parse", "HEAD").Output()
+	featureHead, _ := exec.Command("git", "-C", clone, "rev-parse", "feature").Output()
+	if len(head) == 0 || string(head) != string(featureHead) {
+		t.Errorf("new branch HEAD = %q, want feature's %q", head, featureHead)
+	}
+
+	if _, _, err := CreateWorktree(clone, "other", "no-such-branch", "", false); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("missing base: error = %v, want base branch not found", err)
+	}
+	if _, _, err := CreateWorktree(clone, "feature-followup", "feature", "", false); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("existing branch: error = %v, want already exists", err)
+	}
+}
+
+func TestRunPostCreateCommand(t *testing.T) {
+	_, clone := setupRepoWithOrigin(t)
+	if err := os.WriteFile(filepath.Join(clone, ".env"), []byte("KEY=1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wtPath, _, err := CreateWorktree(clone, "with-env", "", "", false)
+	if err != nil {
+		t.Fatalf("CreateWorktree() error = %v", err)
+	}
+
+	// Runs in the worktree, with the main repo available to copy from
+	if err := RunPostCreateCommand(wtPath, `cp "$CLAUDE_QUICK_MAIN_REPO/.env" .`); err != nil {
+		t.Fatalf("RunPostCreateCommand() error = %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(wtPath, ".env")); err != nil || string(data) != "KEY=1" {
+		t.Errorf(".env in worktree = %q, %v; want it copied from the main repo", data, err)
+	}
+
+	err = RunPostCreateCommand(wtPath, "echo boom >&2; exit 3")
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("RunPostCreateCommand() error = %v, want the failing command's output", err)
+	}
+}
+
+func TestLastCommitTime(t *testing.T) {
+	_, clone := setupRepoWithOrigin(t)
+
+	got, err := LastCommitTime(clone)
+	if err != nil {
+		t.Fatalf("LastCommitTime() error = %v", err)
+	}
+	if age := time.Since(got); age < 0 || age > time.Hour {
+		t.Errorf("LastCommitTime() = %v, want the commit just made", got)
+	}
+
+	if _, err := LastCommitTime(t.TempDir()); err == nil {
+		t.Error("LastCommitTime() should fail outside a git repository")
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package devcontainer
+
+import "os/exec"
+
+// killProcessGroupOnCancel leaves cmd.Cancel at its default, which kills only
+// the direct child: there are no Unix process groups to kill here.
+func killProcessGroupOnCancel(cmd *exec.Cmd) {}
@@ -0,0 +1,19 @@
+//go:build unix
+
+package devcontainer
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killProcessGroupOnCancel runs cmd in its own process group and makes
+// context cancellation kill the whole group. devcontainer up is a node script
+// that shells out to docker, so killing only the direct child would orphan
+// the docker processes it started.
+func killProcessGroupOnCancel(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build unix
+
+package devcontainer
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestKillProcessGroupOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	// The backgrounded sleep inherits stdout, so Run only returns once the
+	// grandchild is gone too
+	cmd := exec.CommandContext(ctx, "sh", "-c", "sleep 30 & wait")
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	killProcessGroupOnCancel(cmd)
+
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancelling should kill the whole process group")
+	}
+}
@@ -1,12 +1,14 @@
 package devcontainer
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
-	"os"
+	"maps"
 	"os/exec"
-	"path/filepath"
+	"path"
+	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/christophergyman/claude-quick/internal/auth"
 )
@@ -32,18 +34,33 @@ func ListTmuxSessions(projectPath string) ([]string, error) {
 	return sessions, nil
 }
 
+// SanitizeSessionName makes name usable as a tmux session name.
+// tmux rejects "." and ":" (they separate window and pane targets), so those
+// and whitespace become "-".
+func SanitizeSessionName(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if r == '.' || r == ':' || unicode.IsSpace(r) {
+			return '-'
+		}
+		return r
+	}, name)
+	return strings.Trim(sanitized, "-")
+}
+
+// TmuxSessionOptions customizes a new tmux session. Empty fields keep tmux defaults.
+type TmuxSessionOptions struct {
+	LaunchCommand string            // Sent to the session after creation
+	WindowName    string            // Name of the initial window (-n)
+	StartDir      string            // Working directory inside the container (-c)
+	Env           map[string]string // Non-secret variables set alongside the credentials
+}
+
 // CreateTmuxSession creates a new tmux session in the container.
-// If launchCommand is non-empty, it will be sent to the session after creation.
-func CreateTmuxSession(projectPath, sessionName, launchCommand string) error {
+// If opts.LaunchCommand is non-empty, it will be sent to the session after creation.
+func CreateTmuxSession(projectPath, sessionName string, opts TmuxSessionOptions) error {
 	// Read credentials BEFORE creating session so they're available to the initial shell
-	creds := readCredentialFile(projectPath)
-
-	// Build tmux command with -e flags to inject env vars at session creation time
-	// This ensures the initial shell gets the credentials (setenv only affects new windows)
-	args := []string{"new-session", "-d", "-s", sessionName}
-	for name, value := range creds {
-		args = append(args, "-e", fmt.Sprintf("%s=%s", name, value))
-	}
+	creds := auth.ReadCredentialFile(projectPath)
+	args := newSessionArgs(sessionName, opts, creds)
 
 	if err := execInContainerWithStderr(projectPath, "failed to create tmux session",
 		append([]string{"tmux"}, args...)...); err != nil {
@@ -54,69 +71,48 @@ func CreateTmuxSession(projectPath, sessionName, launchCommand string) error {
 	applyTmuxStyling(projectPath, sessionName)
 
 	// Also set via setenv for any new windows/panes created later
-	injectTmuxSessionEnv(projectPath, sessionName)
+	injectTmuxSessionEnv(projectPath, sessionName, opts.Env)
 
 	// Run launch command if specified
-	if launchCommand != "" {
-		execInContainer(projectPath, "tmux", "send-keys", "-t", sessionName, launchCommand, "Enter")
+	if opts.LaunchCommand != "" {
+		execInContainer(projectPath, "tmux", "send-keys", "-t", sessionName, opts.LaunchCommand, "Enter")
 	}
 
 	return nil
 }
 
-// injectTmuxSessionEnv reads credentials from the auth file and sets them as tmux session env vars.
-// Uses "tmux setenv" which propagates to all new windows/panes in the session.
-func injectTmuxSessionEnv(projectPath, sessionName string) {
-	creds := readCredentialFile(projectPath)
+// newSessionArgs builds the tmux new-session arguments.
+// Env and credentials are passed with -e flags so the initial shell has them
+// (setenv only affects new windows). tmux sets them directly, with no shell
+// in between, so values need no quoting. Credentials come last and win.
+func newSessionArgs(sessionName string, opts TmuxSessionOptions, creds map[string]string) []string {
+	args := []string{"new-session", "-d", "-s", sessionName}
+	if opts.WindowName != "" {
+		args = append(args, "-n", opts.WindowName)
+	}
+	if opts.StartDir != "" {
+		args = append(args, "-c", opts.StartDir)
+	}
+	for _, name := range slices.Sorted(maps.Keys(opts.Env)) {
+		args = append(args, "-e", fmt.Sprintf("%s=%s", name, opts.Env[name]))
+	}
 	for name, value := range creds {
-		// tmux setenv -t session NAME value
-		execInContainer(projectPath, "tmux", "setenv", "-t", sessionName, name, value)
+		args = append(args, "-e", fmt.Sprintf("%s=%s", name, value))
 	}
+	return args
 }
 
-// readCredentialFile parses the .claude-quick-auth file and returns env var name/value pairs.
-func readCredentialFile(projectPath string) map[string]string {
-	result := make(map[string]string)
-
-	filePath := filepath.Join(projectPath, auth.CredFileName)
-	file, err := os.Open(filePath)
-	if err != nil {
-		return result // File doesn't exist or can't be read
+// injectTmuxSessionEnv sets env and the credentials from the auth file as tmux session env vars.
+// Uses "tmux setenv" which propagates to all new windows/panes in the session.
+func injectTmuxSessionEnv(projectPath, sessionName string, env map[string]string) {
+	for name, value := range env {
+		execInContainer(projectPath, "tmux", "setenv", "-t", sessionName, name, value)
 	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-
-		// Skip comments and empty lines
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-
-		// Parse "export NAME='value'" format
-		if strings.HasPrefix(line, "export ") {
-			line = strings.TrimPrefix(line, "export ")
-			if idx := strings.Index(line, "="); idx > 0 {
-				name := line[:idx]
-				value := line[idx+1:]
-
-				// Remove only the outermost quotes (not all leading/trailing quote chars)
-				// strings.Trim would remove ALL matching chars, corrupting escaped values
-				if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
-					value = value[1 : len(value)-1]
-				}
-
-				// Handle escaped single quotes: 'val'"'"'ue' -> val'ue
-				// The pattern '\"'\"' is shell escaping for a literal single quote
-				value = strings.ReplaceAll(value, "'\"'\"'", "'")
-
-				result[name] = value
-			}
-		}
+	creds := auth.ReadCredentialFile(projectPath)
+	for name, value := range creds {
+		// tmux setenv -t session NAME value
+		execInContainer(projectPath, "tmux", "setenv", "-t", sessionName, name, value)
 	}
-
-	return result
 }
 
 // HasTmux checks if tmux is available in the container
@@ -125,12 +121,77 @@ func HasTmux(projectPath string) bool {
 	return err == nil
 }
 
+// AttachArgs returns the tmux command that attaches to sessionName. With mouse
+// set, mouse mode is first turned on for that session only (never -g, so the
+// user's tmux config is untouched), in the same tmux call as the attach.
+func AttachArgs(sessionName string, mouse bool) []string {
+	args := []string{"tmux"}
+	if mouse {
+		args = append(args, "set-option", "-t", sessionName, "mouse", "on", ";")
+	}
+	return append(args, "attach", "-t", sessionName)
+}
+
 // KillTmuxSession kills a tmux session in the container
 func KillTmuxSession(projectPath, sessionName string) error {
 	return execInContainerWithStderr(projectPath, "failed to kill tmux session",
 		"tmux", "kill-session", "-t", sessionName)
 }
 
+// KillAllTmuxSessions kills every tmux session in the container by stopping the tmux server
+func KillAllTmuxSessions(projectPath string) error {
+	return execInContainerWithStderr(projectPath, "failed to kill tmux server",
+		"tmux", "kill-server")
+}
+
+// RestartTmuxServer stops the tmux server in the container, killing every
+// session, so the next session starts a fresh one. Unlike KillAllTmuxSessions
+// it succeeds when no server is running, as a recovery step for a wedged server.
+func RestartTmuxServer(projectPath string) error {
+	err := KillAllTmuxSessions(projectPath)
+	if err != nil && isNoTmuxServerError(err.Error()) {
+		return nil
+	}
+	return err
+}
+
+// isNoTmuxServerError reports whether tmux output says there is no server to talk to
+func isNoTmuxServerError(output string) bool {
+	return strings.Contains(output, "no server running") || strings.Contains(output, "error connecting to")
+}
+
+// PipeSession appends everything shown in the session's active pane to
+// outPath inside the container (tmux pipe-pane -o, so an existing pipe is
+// left alone). A relative outPath is resolved against the workspace folder,
+// so the file lands in the mounted project directory on the host.
+func PipeSession(projectPath, session, outPath string) error {
+	if err := validatePipePath(outPath); err != nil {
+		return err
+	}
+	if !path.IsAbs(outPath) {
+		output, err := execInContainer(projectPath, "pwd")
+		if err != nil {
+			return fmt.Errorf("failed to find the workspace folder: %w", err)
+		}
+		outPath = path.Join(strings.TrimSpace(string(output)), outPath)
+	}
+	return execInContainerWithStderr(projectPath, "failed to pipe tmux session",
+		"tmux", "pipe-pane", "-o", "-t", session, "cat >> "+shellQuote(outPath))
+}
+
+// validatePipePath checks that outPath can name the file a session is piped to
+func validatePipePath(outPath string) error {
+	switch {
+	case strings.TrimSpace(outPath) == "":
+		return errors.New("output path is empty")
+	case strings.ContainsAny(outPath, "\x00\n\r"):
+		return fmt.Errorf("output path %q contains a control character", outPath)
+	case strings.HasSuffix(outPath, "/") || path.Base(outPath) == "." || path.Base(outPath) == "..":
+		return fmt.Errorf("output path %q names a directory, not a file", outPath)
+	}
+	return nil
+}
+
 // applyTmuxStyling applies Anthropic-themed styling to a tmux session.
 // Uses orange (#D97706) as the primary color with git branch display.
 func applyTmuxStyling(projectPath, sessionName string) {
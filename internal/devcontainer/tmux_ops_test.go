@@ -0,0 +1,121 @@
+package devcontainer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewSessionArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  TmuxSessionOptions
+		creds map[string]string
+		want  string
+	}{
+		{"defaults", TmuxSessionOptions{}, nil, "new-session -d -s main"},
+		{"launch command only", TmuxSessionOptions{LaunchCommand: "claude"}, nil, "new-session -d -s main"},
+		{"window name", TmuxSessionOptions{WindowName: "editor"}, nil, "new-session -d -s main -n editor"},
+		{"start dir", TmuxSessionOptions{StartDir: "/workspaces/app"}, nil, "new-session -d -s main -c /workspaces/app"},
+		{
+			"all options with credentials",
+			TmuxSessionOptions{WindowName: "editor", StartDir: "/src"},
+			map[string]string{"TOKEN": "x"},
+			"new-session -d -s main -n editor -c /src -e TOKEN=x",
+		},
+		{
+			// Values go to tmux verbatim; credentials follow the sorted env
+			"session env",
+			TmuxSessionOptions{Env: map[string]string{"RUST_LOG": "debug", "GREETING": `it's "$HOME"`}},
+			map[string]string{"TOKEN": "x"},
+			`new-session -d -s main -e GREETING=it's "$HOME" -e RUST_LOG=debug -e TOKEN=x`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(newSessionArgs("main", tt.opts, tt.creds), " ")
+			if got != tt.want {
+				t.Errorf("newSessionArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeSessionName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"feature/auth", "feature/auth"},
+		{"release-1.2", "release-1-2"},
+		{"fix:bug", "fix-bug"},
+		{"my branch", "my-branch"},
+		{".hidden.", "hidden"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := SanitizeSessionName(tt.name); got != tt.want {
+			t.Errorf("SanitizeSessionName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAttachArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		mouse bool
+		want  string
+	}{
+		{"plain attach", false, "tmux attach -t main"},
+		{"mouse scoped to the session", true, "tmux set-option -t main mouse on ; attach -t main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(AttachArgs("main", tt.mouse), " ")
+			if got != tt.want {
+				t.Errorf("AttachArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNoTmuxServerError(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{"failed to kill tmux server: no server running on /tmp/tmux-1000/default\n", true},
+		{"failed to kill tmux server: error connecting to /tmp/tmux-1000/default (No such file or directory)\n", true},
+		{"failed to kill tmux server: Error: Dev container not found.", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isNoTmuxServerError(tt.output); got != tt.want {
+			t.Errorf("isNoTmuxServerError(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestValidatePipePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{"session.log", false},
+		{"/workspaces/app/logs/main.log", false},
+		{"", true},
+		{"   ", true},
+		{"logs/", true},
+		{"..", true},
+		{"bad\nname.log", true},
+	}
+
+	for _, tt := range tests {
+		if err := validatePipePath(tt.path); (err != nil) != tt.wantErr {
+			t.Errorf("validatePipePath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+		}
+	}
+}
@@ -2,7 +2,13 @@
 // git worktrees, and tmux sessions within devcontainers.
 package devcontainer
 
-import "path/filepath"
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/christophergyman/claude-quick/internal/util"
+)
 
 // Project represents a devcontainer project
 type Project struct {
@@ -51,12 +57,23 @@ const (
 	StatusUnknown ContainerStatus = "unknown"
 )
 
+// ImageStatus describes whether a running container's image is still the
+// latest local copy of its tag
+type ImageStatus string
+
+const (
+	ImageUpToDate   ImageStatus = "up-to-date"  // Container runs the image its tag points to
+	ImageOutdated   ImageStatus = "outdated"    // A newer image has been pulled for the tag
+	ImageLocalBuild ImageStatus = "local-build" // Image was built locally, so there is no upstream to compare
+)
+
 // ContainerInstance represents a specific devcontainer instance
 // Each instance corresponds to a main repo or a git worktree
 type ContainerInstance struct {
-	Project              // Embedded: Name and Path (workspace folder)
-	ConfigPath string    // Full path to devcontainer.json (from main repo)
-	Worktree   *WorktreeInfo // Worktree info (nil for main repo if not a worktree)
+	Project                         // Embedded: Name and Path (workspace folder)
+	ConfigPath        string        // Full path to devcontainer.json (from main repo)
+	PostAttachCommand string        // postAttachCommand from devcontainer.json, as a command line
+	Worktree          *WorktreeInfo // Worktree info (nil for main repo if not a worktree)
 }
 
 // ContainerInstanceWithStatus extends ContainerInstance with runtime info
@@ -65,12 +82,48 @@ type ContainerInstanceWithStatus struct {
 	Status       ContainerStatus
 	ContainerID  string
 	SessionCount int
+	LastCommit   time.Time // When the checked-out commit was made (zero if unknown or not git)
+	Unmanaged    bool      // Running but apparently started outside claude-quick (set by the TUI)
+}
+
+// projectAliases maps absolute project paths to configured display names
+var projectAliases = map[string]string{}
+
+// SetProjectAliases sets display names for projects, keyed by path.
+// Keys may use ~ and are matched as cleaned absolute paths; blank aliases
+// are ignored. A nil map removes all aliases.
+func SetProjectAliases(aliases map[string]string) {
+	projectAliases = make(map[string]string, len(aliases))
+	for path, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			continue
+		}
+		if abs, err := filepath.Abs(util.ExpandPath(path)); err == nil {
+			path = abs
+		}
+		projectAliases[filepath.Clean(path)] = alias
+	}
+}
+
+// ProjectName returns the project's configured alias, or its directory name.
+// A worktree uses its own alias if it has one, else its main repo's.
+func (c ContainerInstance) ProjectName() string {
+	if alias, ok := projectAliases[filepath.Clean(c.Path)]; ok {
+		return alias
+	}
+	if c.Worktree != nil && c.Worktree.MainRepo != "" {
+		if alias, ok := projectAliases[filepath.Clean(c.Worktree.MainRepo)]; ok {
+			return alias
+		}
+	}
+	return c.Name
 }
 
 // DisplayName returns the formatted name for UI display
 func (c ContainerInstance) DisplayName() string {
 	if c.Worktree != nil && !c.Worktree.IsMain {
-		return c.Name + " [" + c.Worktree.Branch + "]"
+		return c.ProjectName() + " [" + c.Worktree.Branch + "]"
 	}
-	return c.Name
+	return c.ProjectName()
 }
@@ -181,6 +181,54 @@ func TestContainerInstance_DisplayName(t *testing.T) {
 	}
 }
 
+func TestContainerInstance_DisplayNameWithAlias(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	SetProjectAliases(map[string]string{
+		"~/src/acme-web-frontend-v2/": "Frontend",
+		"/src/blank":                  "  ",
+	})
+	t.Cleanup(func() { SetProjectAliases(nil) })
+
+	mainRepo := filepath.Join(home, "src", "acme-web-frontend-v2")
+	tests := []struct {
+		name     string
+		instance ContainerInstance
+		want     string
+	}{
+		{
+			name:     "aliased main repo",
+			instance: ContainerInstance{Project: Project{Name: "acme-web-frontend-v2", Path: mainRepo}},
+			want:     "Frontend",
+		},
+		{
+			name: "worktree of aliased repo keeps branch suffix",
+			instance: ContainerInstance{
+				Project:  Project{Name: "acme-web-frontend-v2", Path: mainRepo + "-feature"},
+				Worktree: &WorktreeInfo{Branch: "feature", MainRepo: mainRepo},
+			},
+			want: "Frontend [feature]",
+		},
+		{
+			name:     "blank alias is ignored",
+			instance: ContainerInstance{Project: Project{Name: "blank", Path: "/src/blank"}},
+			want:     "blank",
+		},
+		{
+			name:     "no alias",
+			instance: ContainerInstance{Project: Project{Name: "other", Path: "/src/other"}},
+			want:     "other",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.instance.DisplayName(); got != tt.want {
+				t.Errorf("DisplayName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestContainerStatusConstants(t *testing.T) {
 	tests := []struct {
 		name     string
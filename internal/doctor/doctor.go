@@ -0,0 +1,118 @@
+// Package doctor runs environment health checks for the --doctor flag.
+//
+// Each check reuses the same validation the TUI performs at runtime, so a
+// passing report means the dashboard should start cleanly. Critical checks
+// (devcontainer CLI, Docker daemon, config validity) make the report fail;
+// the rest are reported as warnings since claude-quick works without them.
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/christophergyman/claude-quick/internal/config"
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/github"
+	"github.com/christophergyman/claude-quick/internal/util"
+)
+
+// Check is a single named diagnostic
+type Check struct {
+	Name     string
+	Critical bool // A failing critical check makes the report fail
+	Run      func() error
+}
+
+// Result is the outcome of running a Check
+type Result struct {
+	Name     string
+	Critical bool
+	Err      error // nil if the check passed
+}
+
+// Checks returns the standard battery of checks.
+// cfg and loadErr are the results of config.Load; search paths are only
+// checked when the config loaded successfully.
+func Checks(cfg *config.Config, loadErr error) []Check {
+	checks := []Check{
+		{Name: "devcontainer CLI installed", Critical: true, Run: devcontainer.CheckCLI},
+		{Name: "Docker daemon reachable", Critical: true, Run: devcontainer.CheckDockerDaemon},
+		{Name: "GitHub CLI installed and authenticated", Run: github.CheckCLI},
+		{Name: "Config file exists", Run: checkConfigExists},
+		{Name: "Config is valid", Critical: true, Run: func() error { return loadErr }},
+	}
+	if cfg != nil {
+		checks = append(checks, searchPathChecks(cfg.SearchPaths)...)
+	}
+	return checks
+}
+
+// checkConfigExists reports when claude-quick is running on built-in defaults
+func checkConfigExists() error {
+	if !config.ConfigExists() {
+		return errors.New("no config file found, using defaults (run claude-quick to start the setup wizard)")
+	}
+	return nil
+}
+
+// searchPathChecks returns one check per configured search path
+func searchPathChecks(paths []string) []Check {
+	if len(paths) == 0 {
+		return []Check{{Name: "Search paths configured", Run: func() error {
+			return errors.New("no search_paths configured")
+		}}}
+	}
+
+	checks := make([]Check, 0, len(paths))
+	for _, path := range paths {
+		p := path // capture for closure
+		checks = append(checks, Check{
+			Name: "Search path " + p,
+			Run: func() error {
+				info, err := os.Stat(util.ExpandPath(p))
+				if err != nil {
+					return errors.New("does not exist")
+				}
+				if !info.IsDir() {
+					return errors.New("is not a directory")
+				}
+				return nil
+			},
+		})
+	}
+	return checks
+}
+
+// Run executes the checks in order
+func Run(checks []Check) []Result {
+	results := make([]Result, len(checks))
+	for i, c := range checks {
+		results[i] = Result{Name: c.Name, Critical: c.Critical, Err: c.Run()}
+	}
+	return results
+}
+
+// Report writes a PASS/WARN/FAIL line per result followed by a summary.
+// Returns false if any critical check failed.
+func Report(w io.Writer, results []Result) bool {
+	ok := true
+	failed, warned := 0, 0
+	for _, r := range results {
+		switch {
+		case r.Err == nil:
+			fmt.Fprintf(w, "PASS  %s\n", r.Name)
+		case r.Critical:
+			ok = false
+			failed++
+			fmt.Fprintf(w, "FAIL  %s: %v\n", r.Name, r.Err)
+		default:
+			warned++
+			fmt.Fprintf(w, "WARN  %s: %v\n", r.Name, r.Err)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d checks, %d failed, %d warnings\n", len(results), failed, warned)
+	return ok
+}
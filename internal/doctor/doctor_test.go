@@ -0,0 +1,93 @@
+package doctor
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  []Result
+		wantOK   bool
+		contains []string
+	}{
+		{
+			name:     "all pass",
+			results:  []Result{{Name: "one", Critical: true}, {Name: "two"}},
+			wantOK:   true,
+			contains: []string{"PASS  one", "PASS  two", "2 checks, 0 failed, 0 warnings"},
+		},
+		{
+			name:     "non-critical failure warns",
+			results:  []Result{{Name: "gh", Err: errors.New("not found")}},
+			wantOK:   true,
+			contains: []string{"WARN  gh: not found", "1 checks, 0 failed, 1 warnings"},
+		},
+		{
+			name:     "critical failure fails",
+			results:  []Result{{Name: "docker", Critical: true, Err: errors.New("down")}},
+			wantOK:   false,
+			contains: []string{"FAIL  docker: down", "1 checks, 1 failed, 0 warnings"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if got := Report(&buf, tt.results); got != tt.wantOK {
+				t.Errorf("Report() = %v, want %v", got, tt.wantOK)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("report should contain %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	boom := errors.New("boom")
+	results := Run([]Check{
+		{Name: "ok", Critical: true, Run: func() error { return nil }},
+		{Name: "bad", Run: func() error { return boom }},
+	})
+
+	if len(results) != 2 {
+		t.Fatalf("len(results) = %d, want 2", len(results))
+	}
+	if results[0].Name != "ok" || !results[0].Critical || results[0].Err != nil {
+		t.Errorf("results[0] = %+v", results[0])
+	}
+	if results[1].Name != "bad" || results[1].Err != boom {
+		t.Errorf("results[1] = %+v", results[1])
+	}
+}
+
+func TestSearchPathChecks(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	results := Run(searchPathChecks([]string{dir, file, filepath.Join(dir, "missing")}))
+	if results[0].Err != nil {
+		t.Errorf("existing directory should pass, got %v", results[0].Err)
+	}
+	if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "not a directory") {
+		t.Errorf("file should fail as not a directory, got %v", results[1].Err)
+	}
+	if results[2].Err == nil || !strings.Contains(results[2].Err.Error(), "does not exist") {
+		t.Errorf("missing path should fail, got %v", results[2].Err)
+	}
+
+	if results := Run(searchPathChecks(nil)); len(results) != 1 || results[0].Err == nil {
+		t.Error("no search paths should produce a single failing check")
+	}
+}
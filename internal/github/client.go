@@ -1,38 +1,176 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/christophergyman/claude-quick/internal/constants"
+)
+
+// Errors returned by CheckCLI so callers can offer a specific hint
+var (
+	ErrCLINotFound      = errors.New("GitHub CLI (gh) not found. Install from https://cli.github.com")
+	ErrNotAuthenticated = errors.New("not authenticated with GitHub CLI. Run: gh auth login")
 )
 
+// CheckInstalled verifies that gh CLI is on the PATH without running it.
+// Returns ErrCLINotFound if it is missing.
+func CheckInstalled() error {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return ErrCLINotFound
+	}
+	return nil
+}
+
 // CheckCLI verifies that gh CLI is installed and authenticated.
+// Returns ErrCLINotFound or ErrNotAuthenticated on failure.
 func CheckCLI() error {
 	// First check if gh is installed
-	if _, err := exec.LookPath("gh"); err != nil {
-		return fmt.Errorf("GitHub CLI not found. Install with: brew install gh")
+	if err := CheckInstalled(); err != nil {
+		return err
 	}
 
-	// Check if authenticated
-	cmd := exec.Command("gh", "auth", "status")
+	// Check if authenticated (bounded, since gh may contact the network)
+	ctx, cancel := context.WithTimeout(context.Background(), constants.GitHubAuthCheckTimeout*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "gh", "auth", "status")
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("not authenticated with GitHub CLI. Run: gh auth login")
+		if ctxErr := timeoutError(ctx, "checking gh auth status"); ctxErr != nil {
+			return ctxErr
+		}
+		return ErrNotAuthenticated
+	}
+	return nil
+}
+
+// ScopeError reports token scopes required by an operation that the gh
+// token was not granted.
+type ScopeError struct {
+	Missing []string
+}
+
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("GitHub token is missing the %s scope(s). Run: gh auth refresh -s %s",
+		strings.Join(e.Missing, ", "), strings.Join(e.Missing, ","))
+}
+
+// impliedScopes lists the narrower scopes each broad OAuth scope grants
+var impliedScopes = map[string][]string{
+	"repo":            {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events"},
+	"admin:org":       {"write:org", "read:org"},
+	"write:org":       {"read:org"},
+	"admin:repo_hook": {"write:repo_hook", "read:repo_hook"},
+	"write:repo_hook": {"read:repo_hook"},
+}
+
+// CheckTokenScopes verifies that the gh token has each of the required OAuth
+// scopes. Returns a *ScopeError naming the missing scopes. Tokens whose scopes
+// gh cannot report (e.g. fine-grained tokens) are assumed sufficient.
+func CheckTokenScopes(required ...string) error {
+	if err := CheckInstalled(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.GitHubAuthCheckTimeout*time.Second)
+	defer cancel()
+	// Older gh versions print the status on stderr
+	output, err := exec.CommandContext(ctx, "gh", "auth", "status").CombinedOutput()
+	if err != nil {
+		if ctxErr := timeoutError(ctx, "checking gh auth status"); ctxErr != nil {
+			return ctxErr
+		}
+		return ErrNotAuthenticated
+	}
+
+	granted, ok := parseTokenScopes(string(output))
+	if !ok {
+		return nil
+	}
+	if missing := missingScopes(granted, required); len(missing) > 0 {
+		return &ScopeError{Missing: missing}
+	}
+	return nil
+}
+
+// parseTokenScopes extracts the scopes from `gh auth status` output, which
+// prints a line like "- Token scopes: 'gist', 'read:org', 'repo'".
+// Returns false if no scopes line is present.
+func parseTokenScopes(output string) ([]string, bool) {
+	for _, line := range strings.Split(output, "\n") {
+		_, list, found := strings.Cut(line, "Token scopes:")
+		if !found {
+			continue
+		}
+		var scopes []string
+		for _, scope := range strings.Split(list, ",") {
+			scope = strings.Trim(strings.TrimSpace(scope), "'\"")
+			if scope != "" && scope != "none" {
+				scopes = append(scopes, scope)
+			}
+		}
+		return scopes, true
+	}
+	return nil, false
+}
+
+// missingScopes returns the required scopes not covered by the granted ones,
+// in the order they were required
+func missingScopes(granted, required []string) []string {
+	have := make(map[string]bool)
+	for _, scope := range granted {
+		have[scope] = true
+		for _, implied := range impliedScopes[scope] {
+			have[implied] = true
+		}
+	}
+	var missing []string
+	for _, scope := range required {
+		if !have[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// timeoutError wraps a context error with the operation that was interrupted.
+// Returns nil if the context was neither cancelled nor timed out.
+func timeoutError(ctx context.Context, operation string) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("timed out %s: %w", operation, ctx.Err())
+	case errors.Is(ctx.Err(), context.Canceled):
+		return ctx.Err()
 	}
 	return nil
 }
 
-// DetectRepository determines the GitHub owner/repo using gh CLI.
-func DetectRepository(repoPath string) (owner, repo string, err error) {
+// DetectRepository determines the GitHub owner/repo. The first remote in
+// cfg.PreferredRemotes that points at a GitHub host wins, so issues come from
+// upstream in a fork; otherwise gh picks the repository.
+func DetectRepository(ctx context.Context, repoPath string, cfg Config) (owner, repo string, err error) {
 	if err := CheckCLI(); err != nil {
 		return "", "", err
 	}
 
+	if owner, repo, ok := selectRemote(listRemotes(ctx, repoPath), cfg.PreferredRemotes(), githubHosts()); ok {
+		return owner, repo, nil
+	}
+
 	// Use gh repo view to get owner and repo name
-	cmd := exec.Command("gh", "repo", "view", "--json", "owner,name")
+	cmd := exec.CommandContext(ctx, "gh", "repo", "view", "--json", "owner,name")
 	cmd.Dir = repoPath
 	output, err := cmd.Output()
 	if err != nil {
+		if ctxErr := timeoutError(ctx, "detecting repository"); ctxErr != nil {
+			return "", "", ctxErr
+		}
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			return "", "", fmt.Errorf("not a GitHub repository: %s", strings.TrimSpace(string(exitErr.Stderr)))
 		}
@@ -52,24 +190,85 @@ func DetectRepository(repoPath string) (owner, repo string, err error) {
 	return result.Owner.Login, result.Name, nil
 }
 
-// FetchIssues retrieves issues from the repository.
-func FetchIssues(owner, repo string, cfg Config) ([]Issue, error) {
+// listRemotes returns the fetch URL of each git remote in the repository,
+// keyed by remote name (empty if git fails)
+func listRemotes(ctx context.Context, repoPath string) map[string]string {
+	output, err := exec.CommandContext(ctx, "git", "-C", repoPath, "remote", "-v").Output()
+	if err != nil {
+		return nil
+	}
+	remotes := make(map[string]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[2] == "(fetch)" {
+			remotes[fields[0]] = fields[1]
+		}
+	}
+	return remotes
+}
+
+// githubHosts returns the hosts whose remotes count as GitHub repositories:
+// github.com and, for GitHub Enterprise, the host gh is pointed at with GH_HOST
+func githubHosts() []string {
+	hosts := []string{"github.com"}
+	if host := os.Getenv("GH_HOST"); host != "" {
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// selectRemote returns the owner/repo of the first remote in preference
+// order whose URL is on one of hosts
+func selectRemote(remotes map[string]string, preference, hosts []string) (owner, repo string, ok bool) {
+	for _, name := range preference {
+		url, found := remotes[name]
+		if !found {
+			continue
+		}
+		if owner, repo, ok := parseRemoteURL(url, hosts); ok {
+			return owner, repo, true
+		}
+	}
+	return "", "", false
+}
+
+// parseRemoteURL extracts owner/repo from a git remote URL on one of hosts.
+// Handles https://host/owner/repo(.git), git@host:owner/repo(.git) and
+// ssh://git@host/owner/repo(.git).
+func parseRemoteURL(url string, hosts []string) (owner, repo string, ok bool) {
+	rest := ""
+	for _, host := range hosts {
+		for _, prefix := range []string{"https://" + host + "/", "http://" + host + "/", "ssh://git@" + host + "/", "git@" + host + ":"} {
+			if after, found := strings.CutPrefix(url, prefix); found {
+				rest = after
+			}
+		}
+	}
+	owner, repo, found := strings.Cut(strings.TrimSuffix(strings.TrimSuffix(rest, "/"), ".git"), "/")
+	if !found || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", false
+	}
+	return owner, repo, true
+}
+
+// FetchIssues retrieves issues in the given state from the repository.
+// An empty state uses cfg.DefaultState. A non-empty search is a gh search
+// string (e.g., from cfg.SavedQueries) that narrows the list.
+// The context bounds how long gh may run (e.g., if it stalls on network or auth).
+func FetchIssues(ctx context.Context, owner, repo string, state IssueState, search string, cfg Config) ([]Issue, error) {
 	if err := CheckCLI(); err != nil {
 		return nil, err
 	}
 
 	// Build gh command with JSON output
-	args := []string{
-		"issue", "list",
-		"--repo", fmt.Sprintf("%s/%s", owner, repo),
-		"--state", string(cfg.DefaultState),
-		"--limit", fmt.Sprintf("%d", cfg.MaxIssues),
-		"--json", "number,title,state,url,labels",
-	}
+	args := issueListArgs(owner, repo, state, search, cfg)
 
-	cmd := exec.Command("gh", args...)
+	cmd := exec.CommandContext(ctx, "gh", args...)
 	output, err := cmd.Output()
 	if err != nil {
+		if ctxErr := timeoutError(ctx, "fetching issues"); ctxErr != nil {
+			return nil, ctxErr
+		}
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			return nil, fmt.Errorf("failed to fetch issues: %s", string(exitErr.Stderr))
 		}
@@ -84,8 +283,28 @@ func FetchIssues(owner, repo string, cfg Config) ([]Issue, error) {
 	return issues, nil
 }
 
+// issueListArgs builds the gh issue list arguments. An empty state falls
+// back to cfg.DefaultState; a non-empty search is passed through to --search.
+func issueListArgs(owner, repo string, state IssueState, search string, cfg Config) []string {
+	if state == "" {
+		state = cfg.DefaultState
+	}
+	args := []string{
+		"issue", "list",
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--state", string(state),
+		"--limit", fmt.Sprintf("%d", cfg.MaxIssues),
+		"--json", "number,title,state,url,labels",
+	}
+	if search != "" {
+		args = append(args, "--search", search)
+	}
+	return args
+}
+
 // FetchIssueBody retrieves the full body of a single issue.
-func FetchIssueBody(owner, repo string, number int) (string, error) {
+// The context bounds how long gh may run.
+func FetchIssueBody(ctx context.Context, owner, repo string, number int) (string, error) {
 	if err := CheckCLI(); err != nil {
 		return "", err
 	}
@@ -97,9 +316,12 @@ func FetchIssueBody(owner, repo string, number int) (string, error) {
 		"--json", "body",
 	}
 
-	cmd := exec.Command("gh", args...)
+	cmd := exec.CommandContext(ctx, "gh", args...)
 	output, err := cmd.Output()
 	if err != nil {
+		if ctxErr := timeoutError(ctx, "fetching issue body"); ctxErr != nil {
+			return "", ctxErr
+		}
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			return "", fmt.Errorf("failed to fetch issue body: %s", string(exitErr.Stderr))
 		}
@@ -116,6 +338,59 @@ func FetchIssueBody(owner, repo string, number int) (string, error) {
 	return result.Body, nil
 }
 
+// CreateIssue opens a new issue with the given title and body (which may be
+// empty) and returns its number. Fails with a *ScopeError if the gh token
+// can't write to the repository. gh is killed when ctx is done, though the
+// issue may already exist by then.
+func CreateIssue(ctx context.Context, owner, repo, title, body string) (int, error) {
+	if err := CheckCLI(); err != nil {
+		return 0, err
+	}
+	if err := CheckTokenScopes("repo"); err != nil {
+		return 0, err
+	}
+
+	cmd := exec.CommandContext(ctx, "gh", issueCreateArgs(owner, repo, title, body)...)
+	output, err := cmd.Output()
+	if err != nil {
+		if ctxErr := timeoutError(ctx, "creating issue"); ctxErr != nil {
+			return 0, ctxErr
+		}
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return 0, fmt.Errorf("failed to create issue: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return 0, fmt.Errorf("failed to create issue: %w", err)
+	}
+
+	return parseIssueNumber(string(output))
+}
+
+// issueCreateArgs builds the gh issue create arguments. The body is always
+// passed so gh doesn't prompt for it.
+func issueCreateArgs(owner, repo, title, body string) []string {
+	return []string{
+		"issue", "create",
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--title", title,
+		"--body", body,
+	}
+}
+
+// parseIssueNumber extracts the issue number from the URL gh issue create
+// prints (e.g., https://github.com/owner/repo/issues/42)
+func parseIssueNumber(output string) (int, error) {
+	url := strings.TrimSpace(output)
+	if i := strings.LastIndex(url, "\n"); i >= 0 {
+		url = strings.TrimSpace(url[i+1:])
+	}
+	_, numStr, found := strings.Cut(url, "/issues/")
+	number, err := strconv.Atoi(numStr)
+	if !found || err != nil || number <= 0 {
+		return 0, fmt.Errorf("unexpected gh issue create output: %q", strings.TrimSpace(output))
+	}
+	return number, nil
+}
+
 // AddLabelToIssue adds a label to the specified issue.
 // If createIfMissing is true and the label doesn't exist, it will be created
 // with the specified color and description.
@@ -123,6 +398,9 @@ func AddLabelToIssue(owner, repo string, issueNumber int, label, color, descript
 	if err := CheckCLI(); err != nil {
 		return err
 	}
+	if err := CheckTokenScopes("repo"); err != nil {
+		return err
+	}
 
 	// Try to add the label
 	args := []string{
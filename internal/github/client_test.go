@@ -0,0 +1,244 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeoutError(t *testing.T) {
+	// Live context: no error
+	if err := timeoutError(context.Background(), "fetching issues"); err != nil {
+		t.Errorf("timeoutError() = %v, want nil", err)
+	}
+
+	// Deadline exceeded: wrapped with the operation
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	err := timeoutError(ctx, "fetching issues")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("timeoutError() = %v, want DeadlineExceeded", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "timed out fetching issues") {
+		t.Errorf("timeoutError() = %q, should name the operation", err)
+	}
+
+	// Cancelled: returned as-is
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := timeoutError(ctx, "fetching issues"); !errors.Is(err, context.Canceled) {
+		t.Errorf("timeoutError() = %v, want Canceled", err)
+	}
+}
+
+func TestCheckCLI_NotFound(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	if err := CheckCLI(); !errors.Is(err, ErrCLINotFound) {
+		t.Errorf("CheckCLI() = %v, want ErrCLINotFound", err)
+	}
+}
+
+func TestParseTokenScopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+		wantOK bool
+	}{
+		{
+			name: "quoted scopes",
+			output: `github.com
+  ✓ Logged in to github.com account octocat (keyring)
+  - Active account: true
+  - Git operations protocol: https
+  - Token: gho_************************************
+  - Token scopes: 'gist', 'read:org', 'repo', 'workflow'`,
+			want:   []string{"gist", "read:org", "repo", "workflow"},
+			wantOK: true,
+		},
+		{
+			name:   "unquoted scopes from older gh",
+			output: "  ✓ Token scopes: gist, read:org",
+			want:   []string{"gist", "read:org"},
+			wantOK: true,
+		},
+		{
+			name:   "no scopes granted",
+			output: "  - Token scopes: none",
+			wantOK: true,
+		},
+		{
+			name:   "scopes not reported",
+			output: "  ✓ Logged in to github.com account octocat (GH_TOKEN)",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseTokenScopes(tt.output)
+			if ok != tt.wantOK {
+				t.Fatalf("parseTokenScopes() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parseTokenScopes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	tests := []struct {
+		granted  []string
+		required []string
+		want     string
+	}{
+		{[]string{"repo", "gist"}, []string{"repo"}, ""},
+		{[]string{"repo"}, []string{"public_repo"}, ""},
+		{[]string{"admin:org"}, []string{"read:org"}, ""},
+		{[]string{"public_repo"}, []string{"repo"}, "repo"},
+		{nil, []string{"repo", "read:org"}, "repo,read:org"},
+	}
+
+	for _, tt := range tests {
+		got := strings.Join(missingScopes(tt.granted, tt.required), ",")
+		if got != tt.want {
+			t.Errorf("missingScopes(%v, %v) = %q, want %q", tt.granted, tt.required, got, tt.want)
+		}
+	}
+}
+
+func TestScopeError(t *testing.T) {
+	err := &ScopeError{Missing: []string{"repo", "read:org"}}
+	if !strings.Contains(err.Error(), "gh auth refresh -s repo,read:org") {
+		t.Errorf("ScopeError should explain how to re-authenticate: %q", err.Error())
+	}
+}
+
+func TestIssueListArgs(t *testing.T) {
+	cfg := Config{DefaultState: IssueStateClosed, MaxIssues: 10}
+
+	args := strings.Join(issueListArgs("o", "r", "", "", cfg), " ")
+	if !strings.Contains(args, "--state closed") {
+		t.Errorf("empty state should use the default: %q", args)
+	}
+	if strings.Contains(args, "--search") {
+		t.Errorf("no search should omit --search: %q", args)
+	}
+
+	got := issueListArgs("o", "r", IssueStateOpen, "label:bug author:@me", cfg)
+	if n := len(got); n < 2 || got[n-2] != "--search" || got[n-1] != "label:bug author:@me" {
+		t.Errorf("search should be passed as one --search argument: %q", got)
+	}
+}
+
+func TestIssueCreateArgs(t *testing.T) {
+	got := strings.Join(issueCreateArgs("o", "r", "Fix it", ""), " ")
+	if want := "issue create --repo o/r --title Fix it --body "; got != want {
+		t.Errorf("issueCreateArgs() = %q, want %q", got, want)
+	}
+}
+
+func TestParseIssueNumber(t *testing.T) {
+	tests := []struct {
+		output  string
+		want    int
+		wantErr bool
+	}{
+		{"https://github.com/o/r/issues/42\n", 42, false},
+		{"Creating issue in o/r\n\nhttps://github.com/o/r/issues/7\n", 7, false},
+		{"https://github.com/o/r/pull/3\n", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseIssueNumber(tt.output)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseIssueNumber(%q) = %d, %v; want %d, error %v", tt.output, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseRemoteURL(t *testing.T) {
+	hosts := []string{"github.com", "github.example.com"}
+	tests := []struct {
+		url       string
+		wantOwner string
+		wantRepo  string
+		wantOK    bool
+	}{
+		{"https://github.com/octo/app.git", "octo", "app", true},
+		{"https://github.com/octo/app", "octo", "app", true},
+		{"git@github.com:octo/app.git", "octo", "app", true},
+		{"ssh://git@github.com/octo/app.git", "octo", "app", true},
+		{"git@github.example.com:team/tool.git", "team", "tool", true},
+		{"https://gitlab.com/octo/app.git", "", "", false},
+		{"https://github.com/octo", "", "", false},
+		{"/srv/git/app.git", "", "", false},
+	}
+
+	for _, tt := range tests {
+		owner, repo, ok := parseRemoteURL(tt.url, hosts)
+		if owner != tt.wantOwner || repo != tt.wantRepo || ok != tt.wantOK {
+			t.Errorf("parseRemoteURL(%q) = %q, %q, %v; want %q, %q, %v", tt.url, owner, repo, ok, tt.wantOwner, tt.wantRepo, tt.wantOK)
+		}
+	}
+}
+
+func TestSelectRemote(t *testing.T) {
+	hosts := []string{"github.com"}
+	fork := map[string]string{
+		"origin":   "git@github.com:me/app.git",
+		"upstream": "https://github.com/octo/app.git",
+		"mirror":   "https://gitlab.com/octo/app.git",
+	}
+
+	tests := []struct {
+		name       string
+		remotes    map[string]string
+		preference []string
+		want       string
+	}{
+		{"upstream before origin by default", fork, Config{}.PreferredRemotes(), "octo/app"},
+		{"configured order", fork, []string{"origin", "upstream"}, "me/app"},
+		{"skips remotes on other hosts", fork, []string{"mirror", "origin"}, "me/app"},
+		{"only origin", map[string]string{"origin": "git@github.com:me/app.git"}, Config{}.PreferredRemotes(), "me/app"},
+		{"no GitHub remote", map[string]string{"mirror": fork["mirror"]}, Config{}.PreferredRemotes(), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, repo, ok := selectRemote(tt.remotes, tt.preference, hosts)
+			got := ""
+			if ok {
+				got = owner + "/" + repo
+			}
+			if got != tt.want {
+				t.Errorf("selectRemote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListRemotes(t *testing.T) {
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", dir},
+		{"-C", dir, "remote", "add", "origin", "git@github.com:me/app.git"},
+		{"-C", dir, "remote", "add", "upstream", "https://github.com/octo/app.git"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git setup failed: %v: %s", err, out)
+		}
+	}
+
+	remotes := listRemotes(context.Background(), dir)
+	if len(remotes) != 2 || remotes["upstream"] != "https://github.com/octo/app.git" {
+		t.Errorf("listRemotes() = %v, want origin and upstream", remotes)
+	}
+}
@@ -3,6 +3,7 @@ package github
 
 import (
 	"encoding/json"
+	"slices"
 	"strings"
 )
 
@@ -29,6 +30,19 @@ func (s *IssueState) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Next returns the state filter that follows s when cycling
+// open → closed → all → open. Unknown states restart at open.
+func (s IssueState) Next() IssueState {
+	switch s {
+	case IssueStateOpen:
+		return IssueStateClosed
+	case IssueStateClosed:
+		return IssueStateAll
+	default:
+		return IssueStateOpen
+	}
+}
+
 // Label represents a GitHub issue label.
 type Label struct {
 	Name string `json:"name"`
@@ -64,6 +78,31 @@ type Config struct {
 	LabelDescription     string     `yaml:"label_description,omitempty"`
 	AutoLabelIssues      *bool      `yaml:"auto_label_issues,omitempty"`
 	CreateLabelIfMissing *bool      `yaml:"create_label_if_missing,omitempty"`
+	FetchTimeoutSeconds  int        `yaml:"fetch_timeout_seconds,omitempty"`
+	IssueTitleMax        int        `yaml:"issue_title_max,omitempty"` // Cap on shown title width (0 = fit the terminal)
+	RemotePreference     []string   `yaml:"remote_preference,omitempty"`
+
+	// SavedQueries maps a name to a gh search string (e.g. "is:open label:bug")
+	SavedQueries map[string]string `yaml:"saved_queries,omitempty"`
+}
+
+// QueryNames returns the names of the saved queries in sorted order.
+func (c Config) QueryNames() []string {
+	names := make([]string, 0, len(c.SavedQueries))
+	for name := range c.SavedQueries {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// PreferredRemotes returns the git remotes to take the repository from, in
+// order. Forks keep issues on upstream, so it is tried before origin by default.
+func (c Config) PreferredRemotes() []string {
+	if len(c.RemotePreference) == 0 {
+		return []string{"upstream", "origin"}
+	}
+	return c.RemotePreference
 }
 
 // IsAutoLabelEnabled returns whether to auto-label issues on worktree creation.
@@ -85,11 +124,12 @@ func (c Config) ShouldCreateLabelIfMissing() bool {
 // DefaultConfig returns the default GitHub configuration.
 func DefaultConfig() Config {
 	return Config{
-		DefaultState:     IssueStateOpen,
-		BranchPrefix:     "issue-",
-		MaxIssues:        50,
-		InProgressLabel:  "in-progress",
-		LabelColor:       "fbca04",
-		LabelDescription: "Issue is being actively worked on",
+		DefaultState:        IssueStateOpen,
+		BranchPrefix:        "issue-",
+		MaxIssues:           50,
+		InProgressLabel:     "in-progress",
+		LabelColor:          "fbca04",
+		LabelDescription:    "Issue is being actively worked on",
+		FetchTimeoutSeconds: 30,
 	}
 }
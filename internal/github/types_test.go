@@ -67,6 +67,23 @@ func TestIssueState_UnmarshalJSON_NullValue(t *testing.T) {
 	}
 }
 
+func TestIssueState_Next(t *testing.T) {
+	tests := []struct {
+		state IssueState
+		want  IssueState
+	}{
+		{IssueStateOpen, IssueStateClosed},
+		{IssueStateClosed, IssueStateAll},
+		{IssueStateAll, IssueStateOpen},
+		{"", IssueStateOpen},
+	}
+	for _, tt := range tests {
+		if got := tt.state.Next(); got != tt.want {
+			t.Errorf("%q.Next() = %q, want %q", tt.state, got, tt.want)
+		}
+	}
+}
+
 func TestIssue_UnmarshalJSON(t *testing.T) {
 	// Test full Issue struct unmarshaling with uppercase state
 	input := `{"number": 42, "title": "Test Issue", "state": "OPEN", "url": "https://example.com"}`
@@ -160,3 +177,17 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("InProgressLabel = %q, want %q", cfg.InProgressLabel, "in-progress")
 	}
 }
+
+func TestConfig_QueryNames(t *testing.T) {
+	cfg := Config{SavedQueries: map[string]string{
+		"stale":   "updated:<2024-01-01",
+		"my bugs": "label:bug assignee:@me",
+	}}
+	got := cfg.QueryNames()
+	if len(got) != 2 || got[0] != "my bugs" || got[1] != "stale" {
+		t.Errorf("QueryNames() = %v, want [my bugs stale]", got)
+	}
+	if got := (Config{}).QueryNames(); len(got) != 0 {
+		t.Errorf("QueryNames() with no queries = %v, want empty", got)
+	}
+}
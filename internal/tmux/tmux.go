@@ -49,3 +49,15 @@ func (s Session) FormatSession() string {
 	}
 	return s.Name
 }
+
+// DefaultFirst moves the session named name to the front of sessions,
+// keeping the others in order. Sessions are returned unchanged if none matches.
+func DefaultFirst(sessions []Session, name string) []Session {
+	for i, s := range sessions {
+		if s.Name == name {
+			ordered := append([]Session{s}, sessions[:i]...)
+			return append(ordered, sessions[i+1:]...)
+		}
+	}
+	return sessions
+}
@@ -1,11 +1,18 @@
 package tui
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -24,26 +31,129 @@ var (
 	errNoWorktreeSelected = errors.New("no worktree selected")
 )
 
-// discoverInstances returns a command that discovers devcontainer instances
+// discoveryProgress is shared by a running discovery and the view: the walk
+// counts entries from the discovery goroutine while spinner ticks redraw
+type discoveryProgress struct {
+	walked atomic.Int64
+
+	mu      sync.Mutex
+	started time.Time
+	last    devcontainer.DiscoveryStats // The previous run over the same search paths (zero if none)
+}
+
+// begin resets the progress for a new run
+func (p *discoveryProgress) begin(last devcontainer.DiscoveryStats) {
+	if p == nil {
+		return
+	}
+	p.walked.Store(0)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.started = time.Now()
+	p.last = last
+}
+
+// snapshot returns the entries walked so far, the time since the run began
+// and the previous run to compare them with
+func (p *discoveryProgress) snapshot() (walked int, elapsed time.Duration, last devcontainer.DiscoveryStats) {
+	if p == nil {
+		return 0, 0, devcontainer.DiscoveryStats{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.started.IsZero() {
+		elapsed = time.Since(p.started)
+	}
+	return int(p.walked.Load()), elapsed, p.last
+}
+
+// discoverInstances returns a command that discovers devcontainer instances.
+// It records the run's size and duration so the next one can show progress.
+// The first complete run of the process is also compared with the last
+// process's instances and saved for the next.
 func (m Model) discoverInstances() tea.Cmd {
+	progress := m.discovery
+	compare := !m.snapshotTaken
 	return func() tea.Msg {
-		instances := devcontainer.DiscoverInstances(
-			m.config.SearchPaths,
+		searchPaths := m.config.SearchPaths
+		last, _ := devcontainer.LoadDiscoveryStats(searchPaths)
+		progress.begin(last)
+
+		start := time.Now()
+		walked := 0
+		instances, truncated := devcontainer.DiscoverInstancesLimit(
+			searchPaths,
 			m.config.MaxDepth,
 			m.config.ExcludedDirs,
+			m.config.MaxInstances,
+			func() {
+				walked++
+				if progress != nil {
+					progress.walked.Add(1)
+				}
+			},
 		)
-		return instancesDiscoveredMsg{instances: instances}
+		// A run cut short by max_instances would understate the next estimate,
+		// and can't tell which projects are gone
+		msg := instancesDiscoveredMsg{instances: instances, truncated: truncated}
+		if truncated {
+			return msg
+		}
+		devcontainer.SaveDiscoveryStats(searchPaths, devcontainer.DiscoveryStats{Walked: walked, Duration: time.Since(start)})
+		if compare {
+			msg.previous, _ = config.LoadDiscoverySnapshot(searchPaths)
+			paths := make([]string, len(instances))
+			for i, instance := range instances {
+				paths[i] = instance.Path
+			}
+			config.SaveDiscoverySnapshot(searchPaths, paths)
+			msg.compared = true
+		}
+		return msg
 	}
 }
 
 // refreshInstanceStatus returns a command that refreshes container status for all instances
 func (m Model) refreshInstanceStatus() tea.Cmd {
 	return func() tea.Msg {
-		statuses := devcontainer.GetAllInstancesStatus(m.instances)
+		statuses := devcontainer.GetAllInstancesStatus(m.instances, m.showSessionCounts(), m.showLastCommit())
+		if m.showSessionCounts() && m.config != nil && len(m.config.Auth.Credentials) > 0 {
+			markUnmanaged(statuses)
+		}
 		return instanceStatusRefreshedMsg{statuses: statuses}
 	}
 }
 
+// markUnmanaged flags running instances with no tmux sessions and no
+// credential file: containers started outside claude-quick (e.g. by a manual
+// devcontainer up), which can be adopted with A. It relies on session counts
+// and on credentials being configured, so callers check both first.
+func markUnmanaged(statuses []devcontainer.ContainerInstanceWithStatus) {
+	for i := range statuses {
+		s := &statuses[i]
+		s.Unmanaged = s.Status == devcontainer.StatusRunning && s.SessionCount == 0 && !auth.HasCredentialFile(s.Path)
+	}
+}
+
+// refreshInstance returns a command that rechecks the container status of the
+// instance at path and, with countSessions, recounts its tmux sessions.
+// Errors are ignored and leave the previous status or count shown.
+func refreshInstance(path string, countSessions bool) tea.Cmd {
+	return func() tea.Msg {
+		status, _ := devcontainer.GetContainerStatus(path)
+		if status == devcontainer.StatusUnknown {
+			return nil
+		}
+		msg := instanceRefreshedMsg{path: path, status: status, count: -1}
+		if countSessions && status == devcontainer.StatusRunning {
+			if sessions, err := devcontainer.ListTmuxSessions(path); err == nil {
+				msg.count = len(sessions)
+			}
+		}
+		return msg
+	}
+}
+
 // startContainer returns a command that starts the devcontainer
 func (m Model) startContainer() tea.Cmd {
 	return func() tea.Msg {
@@ -58,6 +168,7 @@ func (m Model) startContainer() tea.Cmd {
 
 		// Resolve and write authentication credentials
 		var authWarning string
+		var authFailures []auth.CredentialError
 		if m.config != nil {
 			result := m.config.Auth.Resolve(m.selectedInstance.Name)
 			if len(result.Credentials) > 0 {
@@ -66,16 +177,19 @@ func (m Model) startContainer() tea.Cmd {
 					authWarning = fmt.Sprintf("failed to write credentials: %v", err)
 				}
 			}
-			if result.HasErrors() {
-				if authWarning != "" {
-					authWarning += "; "
-				}
-				authWarning += result.ErrorSummary()
-			}
+			authFailures = result.Failed
 		}
 
 		// Start the container (path-based, each worktree has unique path)
-		if err := devcontainer.Up(m.selectedInstance.Path); err != nil {
+		ctx := m.startCtx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if err := devcontainer.UpContext(ctx, m.selectedInstance.Path); err != nil {
+			if errors.Is(err, context.Canceled) {
+				auth.CleanupCredentialFile(m.selectedInstance.Path)
+				return containerStartCancelledMsg{}
+			}
 			return containerErrorMsg{err: err}
 		}
 
@@ -84,7 +198,36 @@ func (m Model) startContainer() tea.Cmd {
 			return containerErrorMsg{err: &tmuxNotFoundError{}}
 		}
 
-		return containerStartedMsg{authWarning: authWarning}
+		// A workspace backed by another checkout breaks worktree isolation;
+		// warn but carry on, and skip the check if docker can't answer
+		workspaceWarning, _ := devcontainer.CheckWorkspaceMount(m.selectedInstance.Path)
+
+		return containerStartedMsg{path: m.selectedInstance.Path, authWarning: authWarning, workspaceWarning: workspaceWarning, authFailures: authFailures}
+	}
+}
+
+// adoptContainer returns a command that brings a container started outside
+// claude-quick under its management by writing the credential file. The
+// default session is created once its sessions are loaded.
+func (m Model) adoptContainer() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+
+		var authWarning string
+		result := m.config.Auth.Resolve(m.selectedInstance.Name)
+		if len(result.Credentials) > 0 {
+			if err := auth.WriteCredentialFile(m.selectedInstance.Path, result.Credentials); err != nil {
+				authWarning = fmt.Sprintf("failed to write credentials: %v", err)
+			}
+		}
+
+		if !devcontainer.HasTmux(m.selectedInstance.Path) {
+			return containerErrorMsg{err: &tmuxNotFoundError{}}
+		}
+
+		return containerAdoptedMsg{path: m.selectedInstance.Path, authWarning: authWarning, authFailures: result.Failed}
 	}
 }
 
@@ -116,6 +259,23 @@ func (m Model) restartContainer() tea.Cmd {
 	}
 }
 
+// loadRestartSessions returns a command that lists the tmux sessions running
+// in the selected container so the restart dialog can show them. Errors are
+// swallowed: the dialog is still shown, just without the session list.
+func (m Model) loadRestartSessions() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		path := m.selectedInstance.Path
+		sessions, err := devcontainer.ListTmuxSessions(path)
+		if err != nil {
+			sessions = nil
+		}
+		return restartSessionsLoadedMsg{path: path, sessions: sessions}
+	}
+}
+
 // stopTmuxSession returns a command that stops/kills a tmux session
 func (m Model) stopTmuxSession() tea.Cmd {
 	return func() tea.Msg {
@@ -132,6 +292,45 @@ func (m Model) stopTmuxSession() tea.Cmd {
 	}
 }
 
+// killAllTmuxSessions returns a command that kills every tmux session in the container
+func (m Model) killAllTmuxSessions() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		if err := devcontainer.KillAllTmuxSessions(m.selectedInstance.Path); err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return tmuxSessionStoppedMsg{}
+	}
+}
+
+// restartTmuxServer returns a command that restarts the tmux server in the
+// container, killing every session
+func (m Model) restartTmuxServer() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		if err := devcontainer.RestartTmuxServer(m.selectedInstance.Path); err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return tmuxSessionStoppedMsg{}
+	}
+}
+
+// pipeSession returns a command that appends the selected session's output
+// to outPath inside the container
+func (m Model) pipeSession(outPath string) tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil || m.selectedSession == nil {
+			return sessionPipedMsg{err: errNoInstanceSelected}
+		}
+		err := devcontainer.PipeSession(m.selectedInstance.Path, m.selectedSession.Name, outPath)
+		return sessionPipedMsg{session: m.selectedSession.Name, outPath: outPath, err: err}
+	}
+}
+
 // restartTmuxSession returns a command that restarts a tmux session (kill + create)
 func (m Model) restartTmuxSession() tea.Cmd {
 	return func() tea.Msg {
@@ -147,9 +346,9 @@ func (m Model) restartTmuxSession() tea.Cmd {
 			return containerErrorMsg{err: err}
 		}
 		// Resolve launch command (project-specific or global default)
-		launchCmd := m.config.Auth.ResolveLaunchCommand(m.selectedInstance.Name, m.config.LaunchCommand)
+		launchCmd := m.resolveLaunchCommand()
 		// Create new session with same name
-		if err := devcontainer.CreateTmuxSession(m.selectedInstance.Path, sessionName, launchCmd); err != nil {
+		if err := devcontainer.CreateTmuxSession(m.selectedInstance.Path, sessionName, m.tmuxSessionOptions(launchCmd)); err != nil {
 			return containerErrorMsg{err: err}
 		}
 		return tmuxSessionRestartedMsg{}
@@ -184,12 +383,64 @@ func (m Model) createTmuxSession(name string) tea.Cmd {
 			return containerErrorMsg{err: errNoInstanceSelected}
 		}
 		// Resolve launch command (project-specific or global default)
-		launchCmd := m.config.Auth.ResolveLaunchCommand(m.selectedInstance.Name, m.config.LaunchCommand)
-		if err := devcontainer.CreateTmuxSession(m.selectedInstance.Path, name, launchCmd); err != nil {
+		launchCmd := m.resolveLaunchCommand()
+		if launchCmd != "" && m.config.IsLaunchCommandFirstOnly() {
+			sessions, err := devcontainer.ListTmuxSessions(m.selectedInstance.Path)
+			if err != nil {
+				return containerErrorMsg{err: err}
+			}
+			launchCmd = sessionLaunchCommand(launchCmd, true, len(sessions))
+		}
+		if err := devcontainer.CreateTmuxSession(m.selectedInstance.Path, name, m.tmuxSessionOptions(launchCmd)); err != nil {
 			return containerErrorMsg{err: err}
 		}
-		return tmuxSessionCreatedMsg{}
+		return tmuxSessionCreatedMsg{name: name}
+	}
+}
+
+// resolveLaunchCommand returns the launch command for the selected instance:
+// the project override, else launch_command, else (with use_post_attach_command)
+// the project's devcontainer.json postAttachCommand
+func (m Model) resolveLaunchCommand() string {
+	globalDefault := m.config.LaunchCommand
+	if globalDefault == "" && m.config.IsUsePostAttachCommand() {
+		globalDefault = m.selectedInstance.PostAttachCommand
+	}
+	return m.config.Auth.ResolveLaunchCommand(m.selectedInstance.Name, globalDefault)
+}
+
+// tmuxSessionOptions returns the new-session options from config with the given launch command
+func (m Model) tmuxSessionOptions(launchCmd string) devcontainer.TmuxSessionOptions {
+	return devcontainer.TmuxSessionOptions{
+		LaunchCommand: launchCmd,
+		WindowName:    m.config.TmuxWindowName,
+		StartDir:      m.config.TmuxStartDir,
+		Env:           m.config.SessionEnv,
+	}
+}
+
+// sessionLaunchCommand returns the command to run in a new session, or "" for a
+// bare shell when the launch command is limited to the first session and
+// existingSessions are already running
+func sessionLaunchCommand(launchCmd string, firstOnly bool, existingSessions int) string {
+	if firstOnly && existingSessions > 0 {
+		return ""
+	}
+	return launchCmd
+}
+
+// autoAttachDefaultSession finishes the issue -> worktree -> container chain by
+// attaching to the default session, creating it first if it doesn't exist yet
+func (m Model) autoAttachDefaultSession() (tea.Model, tea.Cmd) {
+	m.pendingAutoAttach = false
+	name := m.defaultSessionName()
+	for _, s := range m.tmuxSessions {
+		if s.Name == name {
+			return m.attachToSession(name)
+		}
 	}
+	m.state = StateAttaching
+	return m, tea.Batch(m.spinner.Tick, m.createTmuxSession(name))
 }
 
 // attachToSession attaches to a tmux session using tea.ExecProcess
@@ -203,9 +454,10 @@ func (m Model) attachToSession(sessionName string) (tea.Model, tea.Cmd) {
 	m.state = StateAttaching
 
 	// Build the command to attach to tmux (path-based)
-	c := exec.Command("devcontainer", "exec",
-		"--workspace-folder", m.selectedInstance.Path,
-		"tmux", "attach", "-t", sessionName)
+	mouse := m.config != nil && m.config.IsTmuxAttachMouse()
+	args := append([]string{"exec", "--workspace-folder", m.selectedInstance.Path},
+		devcontainer.AttachArgs(sessionName, mouse)...)
+	c := exec.Command("devcontainer", args...)
 
 	// Use tea.ExecProcess to run tmux and return to TUI when done
 	return m, tea.ExecProcess(c, func(err error) tea.Msg {
@@ -214,6 +466,81 @@ func (m Model) attachToSession(sessionName string) (tea.Model, tea.Cmd) {
 	})
 }
 
+// openShell runs an interactive shell in the selected instance's container
+// using tea.ExecProcess, bypassing tmux. The TUI returns to returnTo when the
+// shell exits.
+func (m Model) openShell(returnTo State) (tea.Model, tea.Cmd) {
+	if m.selectedInstance == nil {
+		m.state = StateError
+		m.err = errNoInstanceSelected
+		return m, nil
+	}
+	m.state = StateAttaching
+
+	shell := ""
+	if m.config != nil {
+		shell = m.config.ContainerShell
+	}
+	args := append([]string{"exec", "--workspace-folder", m.selectedInstance.Path},
+		devcontainer.ShellArgs(shell)...)
+	c := exec.Command("devcontainer", args...)
+
+	return m, tea.ExecProcess(c, func(err error) tea.Msg {
+		return shellExitedMsg{err: err, returnTo: returnTo}
+	})
+}
+
+// discardWorktree stops the selected worktree's container, removes its
+// credential file, then removes the worktree
+func (m Model) discardWorktree() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil || m.selectedInstance.Worktree == nil {
+			return containerErrorMsg{err: errNoWorktreeSelected}
+		}
+		path := m.selectedInstance.Path
+		if err := devcontainer.Stop(path); err != nil && !errors.Is(err, devcontainer.ErrNoContainer) {
+			return containerErrorMsg{err: err}
+		}
+		auth.CleanupCredentialFile(path)
+		if err := devcontainer.RemoveWorktree(path, m.selectedInstance.Worktree.MainRepo); err != nil {
+			return containerErrorMsg{err: err}
+		}
+		branch, err := m.deleteWorktreeBranch()
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return worktreeDeletedMsg{branch: branch}
+	}
+}
+
+// moveWorktree moves the selected worktree to newPath. Its container is
+// labeled with the old path, so once the move has succeeded it is removed;
+// the caller restarts it at the new path if it was running. A failed move
+// leaves the container and credentials untouched.
+func (m Model) moveWorktree(newPath string) tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoWorktreeSelected}
+		}
+		oldPath := m.selectedInstance.Path
+		status, _ := devcontainer.GetContainerStatus(oldPath)
+		if err := devcontainer.MoveWorktree(oldPath, newPath); err != nil {
+			return containerErrorMsg{err: err}
+		}
+		if status == devcontainer.StatusRunning {
+			if err := devcontainer.Stop(oldPath); err != nil {
+				return containerErrorMsg{err: fmt.Errorf("worktree moved to %s, but stopping its old container failed: %w", newPath, err)}
+			}
+		}
+		if err := devcontainer.RemoveContainer(oldPath); err != nil {
+			return containerErrorMsg{err: fmt.Errorf("worktree moved to %s, but removing its old container failed: %w", newPath, err)}
+		}
+		// The credential file moved with the worktree
+		auth.CleanupCredentialFile(newPath)
+		return worktreeMovedMsg{path: newPath, restart: status == devcontainer.StatusRunning}
+	}
+}
+
 // deleteWorktree removes the selected git worktree
 func (m Model) deleteWorktree() tea.Cmd {
 	return func() tea.Msg {
@@ -228,7 +555,70 @@ func (m Model) deleteWorktree() tea.Cmd {
 		if err := devcontainer.RemoveWorktree(m.selectedInstance.Path, mainRepoPath); err != nil {
 			return containerErrorMsg{err: err}
 		}
-		return worktreeDeletedMsg{}
+		branch, err := m.deleteWorktreeBranch()
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return worktreeDeletedMsg{branch: branch}
+	}
+}
+
+// deleteWorktreeBranch deletes the local branch of the just-removed worktree
+// when delete_branch_with_worktree is on, returning the branch it deleted
+func (m Model) deleteWorktreeBranch() (string, error) {
+	if !m.deletesBranch() {
+		return "", nil
+	}
+	wt := m.selectedInstance.Worktree
+	if err := devcontainer.DeleteBranch(wt.MainRepo, wt.Branch); err != nil {
+		return "", fmt.Errorf("worktree removed, but %w", err)
+	}
+	return wt.Branch, nil
+}
+
+// loadWorktreeList lists the linked worktrees of the selected repository
+func (m Model) loadWorktreeList() tea.Cmd {
+	path := m.selectedInstance.Path
+	mainRepo := m.selectedInstance.Worktree.MainRepo
+	return func() tea.Msg {
+		worktrees, err := devcontainer.ListWorktrees(mainRepo)
+		if err != nil {
+			return worktreeListLoadedMsg{path: path, err: err}
+		}
+		var linked []devcontainer.WorktreeInfo
+		for _, wt := range worktrees {
+			if !wt.IsMain {
+				linked = append(linked, wt)
+			}
+		}
+		return worktreeListLoadedMsg{path: path, worktrees: linked}
+	}
+}
+
+// loadDeleteChecks gathers what the delete dialog warns about: the commits
+// the selected worktree's branch has that no remote does (when the branch goes
+// too) and, for a plain delete, whether its container is running and will be
+// stopped. A failed commit check is reported as -1 rather than an error so
+// the confirm dialog can still be shown.
+func (m Model) loadDeleteChecks(discard bool) tea.Cmd {
+	path := m.selectedInstance.Path
+	wt := *m.selectedInstance.Worktree
+	countCommits := m.deletesBranch()
+	return func() tea.Msg {
+		msg := deleteChecksLoadedMsg{path: path, discard: discard}
+		if countCommits {
+			count, err := devcontainer.UnpushedCommits(wt.MainRepo, wt.Branch)
+			if err != nil {
+				count = -1
+			}
+			msg.unpushed = count
+		}
+		// The discard dialog already says the container is stopped
+		if !discard {
+			status, _ := devcontainer.GetContainerStatus(path)
+			msg.running = status == devcontainer.StatusRunning
+		}
+		return msg
 	}
 }
 
@@ -238,59 +628,280 @@ func (m Model) createWorktree(branchName string) tea.Cmd {
 		if m.selectedInstance == nil {
 			return containerErrorMsg{err: errNoInstanceSelected}
 		}
-		worktreePath, pushWarning, err := devcontainer.CreateWorktree(
+		worktreePath, notice, err := devcontainer.CreateWorktree(
 			m.selectedInstance.Path,
 			branchName,
-			m.config.IsAutoPushWorktree(),
+			m.worktreeBase,
+			m.config.AutoPushRemote(),
+			m.config.IsNestWorktreeDirs(),
 		)
 		if err != nil {
 			return containerErrorMsg{err: err}
 		}
-		return worktreeCreatedMsg{worktreePath: worktreePath, pushWarning: pushWarning}
+		notice = joinNotice(notice, m.runPostCreateCommand(worktreePath))
+		return worktreeCreatedMsg{worktreePath: worktreePath, notice: notice}
+	}
+}
+
+// runPostCreateCommand runs worktree_post_create_command in a new worktree,
+// returning a warning if it fails ("" if it succeeds or isn't configured)
+func (m Model) runPostCreateCommand(worktreePath string) string {
+	if m.config == nil || m.config.PostCreateCommand == "" {
+		return ""
+	}
+	if err := devcontainer.RunPostCreateCommand(worktreePath, m.config.PostCreateCommand); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// joinNotice appends extra to a worktree creation notice, skipping empty parts
+func joinNotice(notice, extra string) string {
+	return strings.TrimPrefix(strings.TrimSuffix(notice+"; "+extra, "; "), "; ")
+}
+
+// loadContainerLogs reads the selected container's logs. With a pager available
+// the full log is read for paging; otherwise the tail is loaded for the in-TUI viewer.
+func (m Model) loadContainerLogs() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		pager := pagerCommand()
+		tail := 0
+		if pager == nil {
+			tail = constants.ContainerLogTailLines
+		}
+		logs, err := devcontainer.ContainerLogs(m.selectedInstance.Path, tail)
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return containerLogsLoadedMsg{logs: logs, pager: pager}
+	}
+}
+
+// pagerCommand returns the user's pager ($PAGER, else less) split into
+// command and arguments, or nil if neither is available
+func pagerCommand() []string {
+	if fields := strings.Fields(os.Getenv("PAGER")); len(fields) > 0 {
+		if _, err := exec.LookPath(fields[0]); err == nil {
+			return fields
+		}
+	}
+	if _, err := exec.LookPath("less"); err == nil {
+		return []string{"less"}
+	}
+	return nil
+}
+
+// openInPager suspends the TUI and shows logs in the external pager
+func (m Model) openInPager(pager []string, logs string) (tea.Model, tea.Cmd) {
+	c := exec.Command(pager[0], pager[1:]...)
+	c.Stdin = strings.NewReader(logs)
+	return m, tea.ExecProcess(c, func(err error) tea.Msg {
+		return pagerClosedMsg{err: err}
+	})
+}
+
+// loadWorktreeCommits lists the commits on the selected worktree since its base branch
+func (m Model) loadWorktreeCommits() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		base, err := devcontainer.ResolveBaseRef(m.selectedInstance.Worktree)
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
+		commits, err := devcontainer.CommitsSinceBase(m.selectedInstance.Path, base)
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return worktreeCommitsLoadedMsg{base: base, commits: commits}
+	}
+}
+
+// loadWorktreeDiskUsage measures the selected worktree's size. It's only run
+// from the commits panel since walking a large tree is too slow for every refresh.
+func (m Model) loadWorktreeDiskUsage() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		size, err := devcontainer.WorktreeDiskUsage(m.selectedInstance.Path)
+		return worktreeDiskUsageMsg{path: m.selectedInstance.Path, size: size, err: err}
+	}
+}
+
+// checkImageStatus compares the selected container's image against its latest local tag
+func (m Model) checkImageStatus() tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
+		}
+		status, err := devcontainer.CheckImageStatus(m.selectedInstance.Path)
+		if err != nil {
+			return containerErrorMsg{err: err}
+		}
+		return imageCheckedMsg{path: m.selectedInstance.Path, status: status}
+	}
+}
+
+// loadDevcontainerJSON reads the devcontainer.json at path for the viewer,
+// keeping at most MaxConfigViewBytes of it
+func loadDevcontainerJSON(path string) tea.Cmd {
+	return func() tea.Msg {
+		f, err := os.Open(path)
+		if err != nil {
+			return devcontainerJSONLoadedMsg{path: path, err: err}
+		}
+		defer f.Close()
+		data, err := io.ReadAll(io.LimitReader(f, constants.MaxConfigViewBytes+1))
+		if err != nil {
+			return devcontainerJSONLoadedMsg{path: path, err: err}
+		}
+		truncated := len(data) > constants.MaxConfigViewBytes
+		if truncated {
+			data = data[:constants.MaxConfigViewBytes]
+		}
+		return devcontainerJSONLoadedMsg{path: path, content: string(data), truncated: truncated}
+	}
+}
+
+// saveFavorites persists the current favorites by saving the loaded config
+func (m Model) saveFavorites() tea.Cmd {
+	cfg := *m.config // Snapshot so later toggles don't race with the write
+	return func() tea.Msg {
+		return favoritesSavedMsg{err: config.Save(&cfg, config.ConfigPath())}
+	}
+}
+
+// addSearchPath checks that path is a directory, then saves the config with
+// it appended to the search paths, creating the config file if needed
+func (m Model) addSearchPath(path string) tea.Cmd {
+	cfg := *m.config
+	return func() tea.Msg {
+		if info, err := os.Stat(util.ExpandPath(path)); err != nil || !info.IsDir() {
+			return searchPathAddedMsg{path: path, err: fmt.Errorf("search path %q is not a directory", path)}
+		}
+		cfg.SearchPaths = config.NormalizeSearchPaths(append(slices.Clone(cfg.SearchPaths), path), cfg.IsPreserveTilde())
+		if err := config.Save(&cfg, config.ConfigPath()); err != nil {
+			return searchPathAddedMsg{path: path, err: fmt.Errorf("failed to save search path: %w", err)}
+		}
+		return searchPathAddedMsg{path: path, paths: cfg.SearchPaths}
+	}
+}
+
+// saveTheme persists the current dark_mode setting by saving the loaded
+// config, creating the config file if there isn't one yet
+func (m Model) saveTheme() tea.Cmd {
+	cfg := *m.config
+	return func() tea.Msg {
+		return themeSavedMsg{err: config.Save(&cfg, config.ConfigPath())}
+	}
+}
+
+// cloneParentDir returns the search path new repositories are cloned into
+func (m Model) cloneParentDir() string {
+	if m.config == nil || len(m.config.SearchPaths) == 0 {
+		return ""
+	}
+	return util.ExpandPath(m.config.SearchPaths[0])
+}
+
+// startClone runs git clone in the background, streaming progress back to the UI.
+// Progress lines are dropped if the UI falls behind; the final result is always delivered.
+func (m Model) startClone(url, destDir string) (Model, tea.Cmd) {
+	updates := make(chan tea.Msg, 1)
+	go func() {
+		defer close(updates)
+		err := devcontainer.CloneRepo(url, destDir, func(line string) {
+			select {
+			case updates <- cloneProgressMsg{line: line}:
+			default:
+			}
+		})
+		if err != nil {
+			updates <- containerErrorMsg{err: err}
+			return
+		}
+		updates <- repoClonedMsg{path: destDir}
+	}()
+
+	m.cloneDest = destDir
+	m.cloneProgress = ""
+	m.cloneUpdates = updates
+	return m, waitForClone(updates)
+}
+
+// waitForClone waits for the next progress or completion message from a running clone
+func waitForClone(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-updates
+		if !ok {
+			return nil
+		}
+		return msg
 	}
 }
 
 // loadGitHubIssues fetches issues from the current repository
-func (m Model) loadGitHubIssues() tea.Cmd {
+// The context bounds the gh calls and is cancelled if the user backs out
+func (m Model) loadGitHubIssues(ctx context.Context) tea.Cmd {
+	request := m.githubRequest
 	return func() tea.Msg {
 		if m.selectedInstance == nil {
-			return githubIssuesErrorMsg{err: errNoInstanceSelected}
+			return githubIssuesErrorMsg{err: errNoInstanceSelected, request: request}
 		}
 
 		// Detect repo from git remote
-		owner, repo, err := github.DetectRepository(m.selectedInstance.Path)
+		owner, repo, err := github.DetectRepository(ctx, m.selectedInstance.Path, m.config.GitHub)
 		if err != nil {
-			return githubIssuesErrorMsg{err: err}
+			return githubIssuesErrorMsg{err: err, request: request}
 		}
 
 		// Fetch issues using gh CLI
-		issues, err := github.FetchIssues(owner, repo, m.config.GitHub)
+		issues, err := github.FetchIssues(ctx, owner, repo, m.issueStateFilter(), m.issueSearch(), m.config.GitHub)
 		if err != nil {
-			return githubIssuesErrorMsg{err: err}
+			return githubIssuesErrorMsg{err: err, request: request}
 		}
 
 		return githubIssuesLoadedMsg{
-			issues: issues,
-			owner:  owner,
-			repo:   repo,
+			issues:  issues,
+			owner:   owner,
+			repo:    repo,
+			request: request,
+		}
+	}
+}
+
+// createGitHubIssue opens a new issue in the repository the list was loaded from
+func (m Model) createGitHubIssue(ctx context.Context, title, body string) tea.Cmd {
+	owner, repo, request := m.githubRepoOwner, m.githubRepoName, m.githubRequest
+	return func() tea.Msg {
+		number, err := github.CreateIssue(ctx, owner, repo, title, body)
+		if err != nil {
+			return githubIssuesErrorMsg{err: err, request: request}
 		}
+		return githubIssueCreatedMsg{number: number, request: request}
 	}
 }
 
 // loadGitHubIssueDetail fetches the full body of a single issue
-func (m Model) loadGitHubIssueDetail() tea.Cmd {
+func (m Model) loadGitHubIssueDetail(ctx context.Context) tea.Cmd {
+	request := m.githubRequest
 	return func() tea.Msg {
 		if m.selectedIssue == nil {
-			return githubIssuesErrorMsg{err: errors.New("no issue selected")}
+			return githubIssuesErrorMsg{err: errors.New("no issue selected"), request: request}
 		}
 
 		// Fetch issue body
-		body, err := github.FetchIssueBody(m.githubRepoOwner, m.githubRepoName, m.selectedIssue.Number)
+		body, err := github.FetchIssueBody(ctx, m.githubRepoOwner, m.githubRepoName, m.selectedIssue.Number)
 		if err != nil {
-			return githubIssuesErrorMsg{err: err}
+			return githubIssuesErrorMsg{err: err, request: request}
 		}
 
-		return githubIssueDetailLoadedMsg{body: body}
+		return githubIssueDetailLoadedMsg{body: body, request: request}
 	}
 }
 
@@ -304,51 +915,106 @@ func (m Model) createWorktreeFromIssue() tea.Cmd {
 			return containerErrorMsg{err: errors.New("no issue selected")}
 		}
 
-		// Generate branch name from issue
-		branchName := github.GenerateBranchName(m.selectedIssue, m.config.GitHub.BranchPrefix)
+		result := m.createIssueWorktree(m.selectedIssue)
+		if result.err != nil {
+			return containerErrorMsg{err: result.err}
+		}
 
-		// Validate branch name (reuse existing validation)
-		if err := devcontainer.ValidateBranchName(branchName); err != nil {
-			return containerErrorMsg{err: err}
+		return githubWorktreeCreatedMsg{
+			worktreePath: result.worktreePath,
+			branchName:   result.branchName,
+			notice:       result.notice,
+			labelWarning: result.labelWarning,
 		}
+	}
+}
 
-		// Create worktree
-		worktreePath, pushWarning, err := devcontainer.CreateWorktree(
-			m.selectedInstance.Path,
-			branchName,
-			m.config.IsAutoPushWorktree(),
-		)
-		if err != nil {
-			return containerErrorMsg{err: err}
+// createWorktreesFromIssues creates a worktree for each of the given issues sequentially.
+// Failures are recorded per issue and do not stop the remaining issues from being processed.
+func (m Model) createWorktreesFromIssues(issues []github.Issue) tea.Cmd {
+	return func() tea.Msg {
+		if m.selectedInstance == nil {
+			return containerErrorMsg{err: errNoInstanceSelected}
 		}
 
-		// Add "in-progress" label if enabled
-		var labelWarning string
-		if m.config.GitHub.IsAutoLabelEnabled() {
-			label := m.config.GitHub.InProgressLabel
-			if label == "" {
-				label = "in-progress"
-			}
-			if err := github.AddLabelToIssue(
-				m.githubRepoOwner,
-				m.githubRepoName,
-				m.selectedIssue.Number,
-				label,
-				m.config.GitHub.LabelColor,
-				m.config.GitHub.LabelDescription,
-				m.config.GitHub.ShouldCreateLabelIfMissing(),
-			); err != nil {
-				labelWarning = fmt.Sprintf("Failed to add '%s' label: %v", label, err)
-			}
+		results := make([]issueWorktreeResult, 0, len(issues))
+		for i := range issues {
+			results = append(results, m.createIssueWorktree(&issues[i]))
 		}
+		return githubBatchWorktreesCreatedMsg{results: results}
+	}
+}
 
-		return githubWorktreeCreatedMsg{
-			worktreePath: worktreePath,
-			branchName:   branchName,
-			pushWarning:  pushWarning,
-			labelWarning: labelWarning,
+// createIssueWorktree creates a worktree for a single issue and labels it as in-progress.
+// This is the shared implementation behind the single and batch issue flows.
+func (m Model) createIssueWorktree(issue *github.Issue) issueWorktreeResult {
+	result := issueWorktreeResult{issueNumber: issue.Number}
+
+	// Generate branch name from issue
+	branchName := github.GenerateBranchName(issue, m.config.GitHub.BranchPrefix)
+	result.branchName = branchName
+
+	// Validate branch name (reuse existing validation)
+	if err := devcontainer.ValidateBranchName(branchName); err != nil {
+		result.err = err
+		return result
+	}
+
+	// A token without repo scope is the usual cause of a failed push when gh
+	// is the git credential helper, so check before pushing and skip the push
+	// with the fix rather than report git's failure
+	pushRemote, scopeNotice := m.config.AutoPushRemote(), ""
+	if pushRemote != "" {
+		var scopeErr *github.ScopeError
+		if err := github.CheckTokenScopes("repo"); errors.As(err, &scopeErr) {
+			pushRemote = ""
+			scopeNotice = "Branch not pushed: " + scopeErr.Error()
 		}
 	}
+
+	// Create worktree
+	worktreePath, notice, err := devcontainer.CreateWorktree(
+		m.selectedInstance.Path,
+		branchName,
+		"",
+		pushRemote,
+		m.config.IsNestWorktreeDirs(),
+	)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	result.worktreePath = worktreePath
+	result.notice = joinNotice(joinNotice(scopeNotice, notice), m.runPostCreateCommand(worktreePath))
+
+	// Add "in-progress" label if enabled
+	if m.config.GitHub.IsAutoLabelEnabled() {
+		label := m.config.GitHub.InProgressLabel
+		if label == "" {
+			label = "in-progress"
+		}
+		if err := github.AddLabelToIssue(
+			m.githubRepoOwner,
+			m.githubRepoName,
+			issue.Number,
+			label,
+			m.config.GitHub.LabelColor,
+			m.config.GitHub.LabelDescription,
+			m.config.GitHub.ShouldCreateLabelIfMissing(),
+		); err != nil {
+			result.labelWarning = fmt.Sprintf("Failed to add '%s' label: %v", label, err)
+		}
+	}
+
+	return result
+}
+
+// copyToClipboard copies text to the system clipboard
+// label describes what was copied for the confirmation message
+func copyToClipboard(text, label string) tea.Cmd {
+	return func() tea.Msg {
+		return clipboardCopiedMsg{label: label, err: util.CopyToClipboard(text)}
+	}
 }
 
 // validateWizardPath checks if a path exists
@@ -386,6 +1052,28 @@ func (m Model) validateAllWizardPaths() tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+// validateWizardCredential checks that a credential's source looks usable.
+// Command sources are not validated since running them could have side effects.
+func (m Model) validateWizardCredential(cred auth.Credential) tea.Cmd {
+	if cred.Source == auth.SourceCommand {
+		return nil
+	}
+	return func() tea.Msg {
+		var warning string
+		switch cred.Source {
+		case auth.SourceFile:
+			if _, err := os.Stat(util.ExpandPath(cred.Value)); err != nil {
+				warning = "file not found"
+			}
+		case auth.SourceEnv:
+			if _, ok := os.LookupEnv(cred.Value); !ok {
+				warning = "environment variable is not set"
+			}
+		}
+		return wizardCredentialValidatedMsg{key: credentialKey(cred), warning: warning}
+	}
+}
+
 // saveWizardConfig saves the wizard configuration to disk
 func (m Model) saveWizardConfig() tea.Cmd {
 	return func() tea.Msg {
@@ -443,6 +1131,50 @@ func (m Model) buildWizardConfig() *config.Config {
 		},
 		GitHub: github.DefaultConfig(),
 	}
+	if m.config != nil {
+		cfg.PreserveTilde = m.config.PreserveTilde
+		cfg.Favorites = m.config.Favorites
+		cfg.ProjectAliases = m.config.ProjectAliases
+		cfg.Profiles = m.config.Profiles
+		cfg.SessionEnv = m.config.SessionEnv
+		cfg.DefaultProfile = m.config.DefaultProfile
+		cfg.DashboardLayout = m.config.DashboardLayout
+		cfg.FullPaths = m.config.FullPaths
+		cfg.GroupWorktrees = m.config.GroupWorktrees
+		cfg.LaunchFirstOnly = m.config.LaunchFirstOnly
+		cfg.UsePostAttach = m.config.UsePostAttach
+		cfg.AutoAttachSingle = m.config.AutoAttachSingle
+		cfg.PersistTheme = m.config.PersistTheme
+		cfg.ShowSessionCounts = m.config.ShowSessionCounts
+		cfg.ShowLastCommit = m.config.ShowLastCommit
+		cfg.DeleteBranch = m.config.DeleteBranch
+		cfg.TmuxAttachMouse = m.config.TmuxAttachMouse
+		cfg.FastDiscovery = m.config.FastDiscovery
+		cfg.FollowSymlinks = m.config.FollowSymlinks
+		cfg.MaxInstances = m.config.MaxInstances
+		cfg.FocusRefresh = m.config.FocusRefresh
+		cfg.ContainerShell = m.config.ContainerShell
+		cfg.CredentialFile = m.config.CredentialFile
+		cfg.CredentialFormat = m.config.CredentialFormat
+		cfg.ShowWorktrees = m.config.ShowWorktrees
+		cfg.GitOnly = m.config.GitOnly
+		cfg.ReservedBranches = m.config.ReservedBranches
+		cfg.UpArgs = m.config.UpArgs
+		cfg.OverrideConfig = m.config.OverrideConfig
+		cfg.SuppressLegacyWarn = m.config.SuppressLegacyWarn
+		cfg.SessionFromBranch = m.config.SessionFromBranch
+		cfg.WorktreePushRemote = m.config.WorktreePushRemote
+		cfg.PostCreateCommand = m.config.PostCreateCommand
+		cfg.TmuxWindowName = m.config.TmuxWindowName
+		cfg.TmuxStartDir = m.config.TmuxStartDir
+		cfg.PresetSessions = m.config.PresetSessions
+		cfg.Theme = m.config.Theme
+		cfg.NestWorktreeDirs = m.config.NestWorktreeDirs
+		cfg.AutoPushWorktree = m.config.AutoPushWorktree
+		cfg.AutoAttach = m.config.AutoAttach
+		cfg.ContainerLabelKey = m.config.ContainerLabelKey
+		cfg.GitHub = m.config.GitHub
+	}
 
 	return cfg
 }
@@ -2,10 +2,32 @@ package tui
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/christophergyman/claude-quick/internal/auth"
 	"github.com/christophergyman/claude-quick/internal/config"
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
 )
 
+// ApplyRuntimeConfig passes the settings the devcontainer and auth packages
+// keep for the whole process on to them. Call it each time the config is
+// loaded, so a reload after the wizard takes effect too.
+func ApplyRuntimeConfig(cfg *config.Config) {
+	devcontainer.SetContainerLabelKey(cfg.ContainerLabelKey)
+	devcontainer.SetReservedBranches(cfg.ReservedBranches)
+	devcontainer.SetUpArgs(cfg.UpArgs)
+	devcontainer.SetOverrideConfig(cfg.OverrideConfig)
+	devcontainer.SetProjectAliases(cfg.ProjectAliases)
+	devcontainer.SetFastDiscovery(cfg.IsFastDiscovery())
+	devcontainer.SetFollowSymlinks(cfg.IsFollowSymlinks())
+	devcontainer.SetShowWorktrees(cfg.IsShowWorktrees())
+	devcontainer.SetGitOnly(cfg.IsGitOnly())
+	auth.SetCredentialFile(cfg.CredentialFile, auth.CredentialFormat(cfg.CredentialFormat))
+}
+
 // RenderConfigDisplay renders the configuration view
 func RenderConfigDisplay(cfg *config.Config) string {
 	b := renderWithHeader("Configuration")
@@ -21,6 +43,11 @@ func RenderConfigDisplay(cfg *config.Config) string {
 
 	// Search Paths
 	b.WriteString(ColumnHeaderStyle.Render("Search Paths"))
+	if profile := cfg.ActiveProfile(); profile != "" {
+		b.WriteString(DimmedStyle.Render(" (profile: " + profile + ")"))
+	} else {
+		b.WriteString(sourceTag(cfg, "search_paths"))
+	}
 	b.WriteString("\n")
 	for _, p := range cfg.SearchPaths {
 		b.WriteString("  " + p + "\n")
@@ -30,10 +57,12 @@ func RenderConfigDisplay(cfg *config.Config) string {
 	// Max Depth
 	b.WriteString(ColumnHeaderStyle.Render("Max Depth: "))
 	b.WriteString(fmt.Sprintf("%d", cfg.MaxDepth))
+	b.WriteString(sourceTag(cfg, "max_depth"))
 	b.WriteString("\n\n")
 
 	// Excluded Dirs (show all)
 	b.WriteString(ColumnHeaderStyle.Render("Excluded Dirs"))
+	b.WriteString(sourceTag(cfg, "excluded_dirs"))
 	b.WriteString("\n")
 	for _, d := range cfg.ExcludedDirs {
 		b.WriteString("  " + DimmedStyle.Render(d) + "\n")
@@ -43,13 +72,33 @@ func RenderConfigDisplay(cfg *config.Config) string {
 	// Default Session Name
 	b.WriteString(ColumnHeaderStyle.Render("Default Session: "))
 	b.WriteString(cfg.DefaultSessionName)
+	b.WriteString(sourceTag(cfg, "default_session_name"))
 	b.WriteString("\n\n")
 
 	// Container Timeout
 	b.WriteString(ColumnHeaderStyle.Render("Container Timeout: "))
 	b.WriteString(fmt.Sprintf("%ds", cfg.ContainerTimeout))
+	b.WriteString(sourceTag(cfg, "container_timeout_seconds"))
 	b.WriteString("\n\n")
 
+	// Everything else set in the file; unlisted settings use their defaults
+	shown := []string{"search_paths", "max_depth", "excluded_dirs", "default_session_name", "container_timeout_seconds"}
+	var others []string
+	for _, key := range cfg.FileKeys() {
+		if !slices.Contains(shown, key) {
+			others = append(others, key)
+		}
+	}
+	b.WriteString(ColumnHeaderStyle.Render("Also Set in File"))
+	b.WriteString("\n")
+	if len(others) == 0 {
+		b.WriteString("  " + DimmedStyle.Render("(none, all other settings are defaults)") + "\n")
+	}
+	for _, key := range others {
+		b.WriteString("  " + key + "\n")
+	}
+	b.WriteString("\n")
+
 	// Footer
 	b.WriteString("  " + RenderSeparator(defaultWidth-4))
 	b.WriteString("\n")
@@ -57,3 +106,51 @@ func RenderConfigDisplay(cfg *config.Config) string {
 
 	return b.String()
 }
+
+// sourceTag renders where a setting's value came from, e.g. " (default)"
+func sourceTag(cfg *config.Config, key string) string {
+	return DimmedStyle.Render(" (" + string(cfg.Source(key)) + ")")
+}
+
+// RenderProfilePicker renders the config profiles to switch search paths to,
+// with a first row for the top-level search paths. active is the profile in use.
+func RenderProfilePicker(profiles map[string]config.Profile, active string, cursor, width int) string {
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	var b strings.Builder
+
+	b.WriteString(RenderBorderedHeader("claude-quick", "Profiles", width))
+	b.WriteString("\n\n")
+
+	names := append([]string{""}, (&config.Config{Profiles: profiles}).ProfileNames()...)
+	for i, name := range names {
+		label, paths := name, strings.Join(profiles[name].SearchPaths, ", ")
+		if name == "" {
+			label, paths = "(none)", "search_paths"
+		}
+		if name == active {
+			label += " ✓"
+		}
+		if i == cursor {
+			b.WriteString(Cursor() + SelectedStyle.Render(label))
+		} else {
+			b.WriteString(NoCursor() + ItemStyle.Render(label))
+		}
+		b.WriteString("  " + DimmedStyle.Render(truncateText(paths, width-lipgloss.Width(label)-10)))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	b.WriteString("  " + RenderSeparator(width-4))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("  %s  %s  %s",
+		RenderKeyBinding("↑↓", "navigate"),
+		RenderKeyBinding("enter", "switch"),
+		RenderKeyBinding("q", "back"),
+	))
+
+	return b.String()
+}
@@ -1,13 +1,18 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/christophergyman/claude-quick/internal/auth"
 	"github.com/christophergyman/claude-quick/internal/config"
 	"github.com/christophergyman/claude-quick/internal/constants"
 	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/tmux"
 )
 
 const defaultWidth = 65
@@ -35,20 +40,95 @@ func renderSimpleHeader(subtitle string) *strings.Builder {
 
 // RenderContainerStarting renders the loading state while container starts
 func RenderContainerStarting(projectName string, spinnerView string) string {
-	return renderSpinnerWithHint(spinnerView, "Starting", projectName, "This may take a moment...")
+	return renderSpinnerWithHint(spinnerView, "Starting", projectName, "This may take a moment... (esc to cancel)")
 }
 
-// RenderError renders an error message
-func RenderError(err error, hint string) string {
+// RenderError renders an error message, wrapped to the terminal width.
+// canRetry advertises r to re-run the failed operation.
+// scroll is the index of the first visible line when the message is taller
+// than the view; height <= 0 shows the whole message.
+func RenderError(err error, hint string, canRetry bool, scroll, width, height int) string {
 	b := renderWithHeader("")
-	b.WriteString(ErrorStyle.Render("Error: "))
-	b.WriteString(fmt.Sprintf("%v", err))
+	lines := errorLines(err, width)
+
+	// Only scroll when the message doesn't fit
+	rows := len(lines)
+	if height > 0 && scrollViewRows(height) < rows {
+		rows = scrollViewRows(height)
+	}
+	scroll = clampScroll(scroll, len(lines), rows)
+	end := scroll + rows
+
+	b.WriteString(strings.Join(lines[scroll:end], "\n"))
 	b.WriteString("\n\n")
+	if rows < len(lines) {
+		b.WriteString(DimmedStyle.Render(fmt.Sprintf("Lines %d-%d of %d", scroll+1, end, len(lines))))
+		b.WriteString("\n\n")
+	}
+	if tailored := errorHint(err); tailored != "" {
+		b.WriteString(WarningStyle.Render(tailored))
+		b.WriteString("\n\n")
+	}
 	if hint != "" {
 		b.WriteString(DimmedStyle.Render(hint))
 		b.WriteString("\n\n")
 	}
-	b.WriteString(HelpStyle.Render("Press any key to continue"))
+	help := "Press any key to continue"
+	switch {
+	case rows < len(lines) && canRetry:
+		help = "↑↓ PgUp PgDn to scroll, r to retry, any other key to continue"
+	case rows < len(lines):
+		help = "↑↓ PgUp PgDn to scroll, any other key to continue"
+	case canRetry:
+		help = "Press r to retry, any other key to continue"
+	}
+	b.WriteString(HelpStyle.Render(help))
+	return b.String()
+}
+
+// errorHint suggests a fix for container errors the user can act on,
+// or returns "" when there's nothing more specific to say
+func errorHint(err error) string {
+	switch {
+	case errors.Is(err, devcontainer.ErrDaemonUnreachable):
+		return "Start Docker (or check DOCKER_HOST), then press R on the dashboard to refresh"
+	case errors.Is(err, devcontainer.ErrImagePullFailed):
+		return "Check the image name in devcontainer.json and run 'docker login' if the registry is private"
+	case errors.Is(err, devcontainer.ErrNoContainer):
+		return "The container may have been removed; press R on the dashboard to refresh"
+	}
+	return ""
+}
+
+// errorLines returns the rendered error message wrapped to fit within width
+func errorLines(err error, width int) []string {
+	if width <= 0 {
+		width = defaultWidth
+	}
+	text := ErrorStyle.Render("Error: ") + fmt.Sprintf("%v", err)
+	return strings.Split(wrapText(text, width-4), "\n")
+}
+
+// RenderAuthWarning lists the credentials that failed to resolve when a container started
+func RenderAuthWarning(projectName string, failures []auth.CredentialError) string {
+	b := renderWithHeader("Credential Problems")
+	b.WriteString("Container ")
+	b.WriteString(SuccessStyle.Render(projectName))
+	b.WriteString(" started, but these credentials could not be resolved:")
+	b.WriteString("\n\n")
+	for _, f := range failures {
+		b.WriteString("  ")
+		b.WriteString(WarningStyle.Render(f.Credential.Name))
+		b.WriteString(" ")
+		b.WriteString(DimmedStyle.Render(fmt.Sprintf("(%s: %s)", f.Credential.Source, f.Credential.Value)))
+		b.WriteString("\n    ")
+		b.WriteString(fmt.Sprintf("%v", f.Err))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(DimmedStyle.Render("Fix the auth section of your config, then restart the container."))
+	b.WriteString("\n\n")
+	b.WriteString(HelpStyle.Render("Enter: Continue to sessions"))
 	return b.String()
 }
 
@@ -56,6 +136,12 @@ func RenderError(err error, hint string) string {
 // entityType: "container", "tmux session", etc.
 // labelType: "Project", "Session", etc.
 func renderConfirmDialog(operation, entityType, labelType, name string) string {
+	return renderConfirmDialogWithDetails(operation, entityType, labelType, name, "")
+}
+
+// renderConfirmDialogWithDetails renders a confirm dialog with an optional
+// details block shown between the target name and the key hints
+func renderConfirmDialogWithDetails(operation, entityType, labelType, name, details string) string {
 	b := renderWithHeader("")
 	actionText := "Stop"
 	if operation == "restart" {
@@ -66,13 +152,40 @@ func renderConfirmDialog(operation, entityType, labelType, name string) string {
 	b.WriteString(labelType + ": ")
 	b.WriteString(SuccessStyle.Render(name))
 	b.WriteString("\n\n")
+	if details != "" {
+		b.WriteString(details)
+		b.WriteString("\n\n")
+	}
 	b.WriteString(HelpStyle.Render("y: Confirm  n/Esc: Cancel"))
 	return b.String()
 }
 
-// RenderConfirmDialog renders a confirmation dialog for stop/restart operations
-func RenderConfirmDialog(operation, projectName string) string {
-	return renderConfirmDialog(operation, "container", "Project", projectName)
+// RenderConfirmDialog renders a confirmation dialog for stop/restart operations.
+// Any tmux sessions passed in are listed as the ones the operation will interrupt.
+func RenderConfirmDialog(operation, projectName string, sessions []tmux.Session) string {
+	details := ""
+	if len(sessions) > 0 {
+		details = renderInterruptedSessions(sessions)
+	}
+	return renderConfirmDialogWithDetails(operation, "container", "Project", projectName, details)
+}
+
+// renderInterruptedSessions lists the sessions an operation will interrupt,
+// summarizing them as a count when there are too many to list
+func renderInterruptedSessions(sessions []tmux.Session) string {
+	if len(sessions) > constants.MaxListedSessions {
+		return WarningStyle.Render(fmt.Sprintf("%d sessions will be interrupted", len(sessions)))
+	}
+	var b strings.Builder
+	b.WriteString(WarningStyle.Render("Sessions that will be interrupted:"))
+	for _, s := range sessions {
+		b.WriteString("\n  ")
+		b.WriteString(s.Name)
+		if s.Attached > 0 {
+			b.WriteString(DimmedStyle.Render(" (attached)"))
+		}
+	}
+	return b.String()
 }
 
 // renderOperation renders a generic spinner operation view
@@ -95,20 +208,36 @@ func RenderContainerOperation(operation, projectName, spinnerView string) string
 	return renderOperation(operation, "", projectName, spinnerView)
 }
 
-// RenderDiscovering renders the project discovery loading state
-func RenderDiscovering(spinnerView string) string {
-	return renderSpinnerWithHint(spinnerView, "Discovering projects", "", "Searching for devcontainer.json files...")
+// RenderDiscovering renders the project discovery loading state. With a
+// previous run over the same search paths it adds a rough estimate: entries
+// walked against that run's total, and the time it took beyond elapsed.
+func RenderDiscovering(spinnerView string, walked int, elapsed time.Duration, last devcontainer.DiscoveryStats) string {
+	hint := "Searching for devcontainer.json files..."
+	if last.Walked > 0 {
+		// Capped below 100% since this run may walk more than the last one
+		percent := min(walked*100/last.Walked, 99)
+		hint += fmt.Sprintf(" ~%d%%", percent)
+		if remaining := last.Duration - elapsed; remaining >= time.Second {
+			hint += fmt.Sprintf(", about %s left", remaining.Round(time.Second))
+		}
+		hint += fmt.Sprintf("\n%d of ~%d entries (estimated from the last run)", walked, last.Walked)
+	}
+	return renderSpinnerWithHint(spinnerView, "Discovering projects", "", hint)
 }
 
-// truncatePath shortens a path to fit within maxLen
+// truncatePath shortens a path to fit within maxLen runes, keeping its end
 func truncatePath(path string, maxLen int) string {
 	if maxLen <= 0 {
 		maxLen = constants.DefaultPathTruncateLen
 	}
-	if len(path) <= maxLen {
+	runes := []rune(path)
+	if len(runes) <= maxLen {
 		return path
 	}
-	return "..." + path[len(path)-maxLen+3:]
+	if maxLen <= 3 {
+		return string(runes[len(runes)-maxLen:])
+	}
+	return "..." + string(runes[len(runes)-maxLen+3:])
 }
 
 // RenderRefreshingStatus renders the loading state while refreshing container status
@@ -116,8 +245,95 @@ func RenderRefreshingStatus(spinnerView string) string {
 	return renderSpinnerAction(spinnerView, "Refreshing container status", "")
 }
 
+// RenderCheckingImage renders the loading state while checking for an image update
+func RenderCheckingImage(projectName, spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView, "Checking image for", projectName, "Comparing with the latest pulled image...")
+}
+
+// RenderAdoptingContainer renders the loading state while adopting a container
+func RenderAdoptingContainer(projectName, spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView, "Adopting", projectName, "Writing credentials and opening the default session...")
+}
+
+// instanceHint returns the dashboard hint shown after an instance's path:
+// a NEW tag if the last discovery run didn't find it, its last commit's age,
+// then its image check result, else a note if it was started outside claude-quick
+func instanceHint(instance devcontainer.ContainerInstanceWithStatus, imageStatus map[string]devcontainer.ImageStatus, isNew bool) string {
+	var hints []string
+	if isNew {
+		hints = append(hints, SuccessStyle.Render("NEW"))
+	}
+	if !instance.LastCommit.IsZero() {
+		hints = append(hints, DimmedStyle.Render(formatAge(time.Since(instance.LastCommit))))
+	}
+	if hint := imageStatusHint(imageStatus[instance.Path]); hint != "" {
+		hints = append(hints, hint)
+	} else if instance.Unmanaged {
+		hints = append(hints, WarningStyle.Render("started outside claude-quick (A to adopt)"))
+	}
+	return strings.Join(hints, "  ")
+}
+
+// formatAge renders a duration in the past compactly (e.g., "2d ago")
+func formatAge(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < day:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	case d < 60*day:
+		return fmt.Sprintf("%dd ago", int(d/day))
+	case d < 365*day:
+		return fmt.Sprintf("%dmo ago", int(d/(30*day)))
+	}
+	return fmt.Sprintf("%dy ago", int(d/(365*day)))
+}
+
+// imageStatusHint returns the dashboard hint for an image check result
+func imageStatusHint(status devcontainer.ImageStatus) string {
+	switch status {
+	case devcontainer.ImageOutdated:
+		return WarningStyle.Render("update available")
+	case devcontainer.ImageUpToDate:
+		return DimmedStyle.Render("image up to date")
+	case devcontainer.ImageLocalBuild:
+		return DimmedStyle.Render("local image build")
+	}
+	return ""
+}
+
+// DashboardOptions controls how the dashboard lays out instances
+type DashboardOptions struct {
+	Compact        bool            // One line per instance instead of name and path lines with spacing
+	GroupWorktrees bool            // Show a repository header above each group of worktrees
+	Profile        string          // Active config profile shown in the header ("" for none)
+	Collapsed      map[string]bool // Worktree groups (by main-repo path) folded to one row
+	FullPaths      bool            // Never truncate paths, even if lines overflow the width
+	InstanceLimit  int             // max_instances when discovery stopped early (0 if it found everything)
+
+	// Which instances are listed, by status
+	Visibility instanceVisibility
+
+	// Instance paths the previous discovery run didn't find (may be nil)
+	New map[string]bool
+}
+
+// fitPath shortens path to maxLen unless full paths were asked for
+func (o DashboardOptions) fitPath(path string, maxLen int) string {
+	if o.FullPaths {
+		return path
+	}
+	return truncatePath(path, maxLen)
+}
+
 // RenderDashboard renders the container dashboard with status indicators
-func RenderDashboard(instances []devcontainer.ContainerInstanceWithStatus, cursor int, width int, warning string) string {
+// favorites holds pinned instance paths, shown with a star (may be nil)
+// imageStatus holds on-demand image check results keyed by instance path (may be nil)
+// flash is a transient status message, already styled, shown above the footer (empty for none)
+func RenderDashboard(instances []devcontainer.ContainerInstanceWithStatus, favorites map[string]bool, imageStatus map[string]devcontainer.ImageStatus, cursor int, opts DashboardOptions, width int, warning, flash string) string {
 	if width <= 0 {
 		width = defaultWidth
 	}
@@ -125,21 +341,39 @@ func RenderDashboard(instances []devcontainer.ContainerInstanceWithStatus, curso
 	var b strings.Builder
 
 	// Bordered header
-	b.WriteString(RenderBorderedHeader("claude-quick", "Container Dashboard", width))
+	subtitle := "Container Dashboard"
+	if opts.Profile != "" {
+		subtitle += " (profile: " + opts.Profile + ")"
+	}
+	b.WriteString(RenderBorderedHeader("claude-quick", subtitle, width))
 	b.WriteString("\n\n")
 
-	// Show warning if present
+	// Show warning if present, cut to one line
 	if warning != "" {
-		b.WriteString(WarningStyle.Render("Warning: " + warning))
+		text := "Warning: " + warning
+		banner := truncateText(text, width-4)
+		b.WriteString(WarningStyle.Render(banner))
+		b.WriteString("\n")
+		hint := "esc dismiss"
+		if banner != text {
+			hint = "W view full warning  " + hint
+		}
+		b.WriteString(DimmedStyle.Render(hint))
 		b.WriteString("\n\n")
 	}
 
 	if len(instances) == 0 {
 		b.WriteString(ErrorStyle.Render("No devcontainer projects found."))
 		b.WriteString("\n\n")
-		b.WriteString(DimmedStyle.Render("Add search paths to: "))
+		b.WriteString("Press " + KeyStyle.Render("a") + " to add a search path, " + KeyStyle.Render("w") + " to run the setup wizard,")
+		b.WriteString("\n")
+		b.WriteString("or " + KeyStyle.Render("R") + " to search again after adding projects.")
+		b.WriteString("\n\n")
+		b.WriteString(DimmedStyle.Render("Search paths are stored in: "))
 		b.WriteString("\n")
 		b.WriteString(DimmedStyle.Render(config.ConfigPath()))
+		b.WriteString("\n\n")
+		b.WriteString(HelpStyle.Render("a: Add search path  w: Wizard  R: Search again  C: Clone a repository  q: Quit"))
 		return b.String()
 	}
 
@@ -160,46 +394,26 @@ func RenderDashboard(instances []devcontainer.ContainerInstanceWithStatus, curso
 	b.WriteString("\n")
 
 	// Render each project
-	for i, instance := range instances {
-		// Get status indicator with text
-		statusText := getStatusText(instance.Status)
-		statusWidth := lipgloss.Width(statusText)
-
-		// Session info for running containers
-		sessionInfo := ""
-		if instance.Status == devcontainer.StatusRunning && instance.SessionCount > 0 {
-			sessionInfo = fmt.Sprintf(" [%d]", instance.SessionCount)
-		}
-
-		// Project name
-		displayName := instance.DisplayName() + sessionInfo
-
-		// Calculate spacing for right alignment
-		nameWidth := lipgloss.Width(displayName)
-		spacing := width - 4 - nameWidth - statusWidth
-		if spacing < 1 {
-			spacing = 1
-		}
-
-		// Render project line
-		var line string
-		if i == cursor {
-			line = Cursor() + SelectedStyle.Render(displayName) + repeatChar(" ", spacing) + statusText
-		} else {
-			line = NoCursor() + ItemStyle.Render(displayName) + repeatChar(" ", spacing) + statusText
-		}
-		b.WriteString(line)
+	if len(visibleRows(instances, groupCollapsed(opts), opts.Visibility)) == 0 {
+		b.WriteString("  " + DimmedStyle.Render("No instances match the filter (F to show more)"))
 		b.WriteString("\n")
+	} else if opts.Compact {
+		renderCompactRows(&b, instances, favorites, imageStatus, cursor, opts, width)
+	} else {
+		renderComfortableRows(&b, instances, favorites, imageStatus, cursor, opts, width)
+	}
 
-		// Show path on next line (dimmed, indented)
-		pathLine := "    " + DimmedStyle.Render(truncatePath(instance.Path, width-constants.PathTruncatePadding))
-		b.WriteString(pathLine)
+	if opts.InstanceLimit > 0 {
 		b.WriteString("\n")
+		b.WriteString("  " + DimmedStyle.Render(fmt.Sprintf("Showing the first %d of many projects (max_instances)", opts.InstanceLimit)))
+		b.WriteString("\n")
+	}
 
-		// Add spacing between entries except for the last one
-		if i < len(instances)-1 {
-			b.WriteString("\n")
-		}
+	// Transient status message
+	if flash != "" {
+		b.WriteString("\n")
+		b.WriteString("  " + flash)
+		b.WriteString("\n")
 	}
 
 	// Footer section
@@ -207,12 +421,25 @@ func RenderDashboard(instances []devcontainer.ContainerInstanceWithStatus, curso
 	b.WriteString("  " + RenderSeparator(width-4))
 	b.WriteString("\n")
 
+	// Git actions are grayed out when the selected project can't use them,
+	// and the shell when its container isn't running
+	isGit, isLinkedWorktree, isRunning := true, true, true
+	if cursor >= 0 && cursor < len(instances) {
+		wt := instances[cursor].Worktree
+		isGit = wt != nil
+		isLinkedWorktree = wt != nil && !wt.IsMain
+		isRunning = instances[cursor].Status == devcontainer.StatusRunning
+	}
+
 	// Key bindings - first row
-	keybindings1 := fmt.Sprintf("  %s  %s  %s  %s  %s  %s",
+	keybindings1 := fmt.Sprintf("  %s  %s  %s  %s  %s  %s  %s  %s  %s",
 		RenderKeyBinding("↑↓", "navigate"),
 		RenderKeyBinding("enter", "connect"),
-		RenderKeyBinding("n", "new"),
-		RenderKeyBinding("d", "delete"),
+		renderKeyBindingIf("s", "shell", isRunning),
+		renderKeyBindingIf("n", "new", isGit),
+		renderKeyBindingIf("b", "branch off", isLinkedWorktree),
+		renderKeyBindingIf("d", "delete", isLinkedWorktree),
+		renderKeyBindingIf("c", "commits", isGit),
 		RenderKeyBinding("x", "stop"),
 		RenderKeyBinding("r", "restart"),
 	)
@@ -220,8 +447,9 @@ func RenderDashboard(instances []devcontainer.ContainerInstanceWithStatus, curso
 	b.WriteString("\n")
 
 	// Key bindings - second row with right-aligned detach hint
-	leftKeys := fmt.Sprintf("  %s  %s  %s  %s  %s  %s",
-		RenderKeyBinding("g", "issues"),
+	leftKeys := fmt.Sprintf("  %s  %s  %s  %s  %s  %s  %s",
+		renderKeyBindingIf("g", "issues", isGit),
+		renderKeyBindingIf("v", "worktrees", isGit),
 		RenderKeyBinding("R", "refresh"),
 		RenderKeyBinding("t", "theme"),
 		RenderKeyBinding("w", "wizard"),
@@ -237,10 +465,220 @@ func RenderDashboard(instances []devcontainer.ContainerInstanceWithStatus, curso
 		footerSpacing = 1
 	}
 	b.WriteString(leftKeys + repeatChar(" ", footerSpacing) + rightKey)
+	b.WriteString("\n")
+
+	// Key bindings - third row
+	b.WriteString(fmt.Sprintf("  %s  %s  %s  %s  %s  %s  %s  %s  %s  %s",
+		RenderKeyBinding("*", "pin"),
+		RenderKeyBinding("l", "log"),
+		RenderKeyBinding("L", "container logs"),
+		RenderKeyBinding("J", "devcontainer.json"),
+		RenderKeyBinding("Y", "copy exec"),
+		RenderKeyBinding("u", "check image"),
+		RenderKeyBinding("C", "clone"),
+		RenderKeyBinding("f", "jump"),
+		RenderKeyBinding("F", "show: "+opts.Visibility.String()),
+		renderKeyBindingIf("z", "fold", opts.GroupWorktrees),
+	))
 
 	return b.String()
 }
 
+// dashboardDisplayName returns the project name with session count and favorite star
+func dashboardDisplayName(instance devcontainer.ContainerInstanceWithStatus, favorites map[string]bool) string {
+	displayName := instance.DisplayName()
+	if instance.Status == devcontainer.StatusRunning && instance.SessionCount > 0 {
+		displayName += fmt.Sprintf(" [%d]", instance.SessionCount)
+	}
+	if favorites[instance.Path] {
+		displayName = "★ " + displayName
+	}
+	return displayName
+}
+
+// renderComfortableRows renders each instance as a name/status line and a path line,
+// with a blank line between entries
+func renderComfortableRows(b *strings.Builder, instances []devcontainer.ContainerInstanceWithStatus, favorites map[string]bool, imageStatus map[string]devcontainer.ImageStatus, cursor int, opts DashboardOptions, width int) {
+	collapsed := groupCollapsed(opts)
+	rows := visibleRows(instances, collapsed, opts.Visibility)
+	for n, i := range rows {
+		instance := instances[i]
+		if inCollapsedGroup(instances, collapsed, i) {
+			b.WriteString(collapsedGroupLine(instances, i, cursor, width))
+			b.WriteString("\n")
+			b.WriteString("    " + DimmedStyle.Render(opts.fitPath(worktreeGroupKey(instance), width-constants.PathTruncatePadding)))
+			b.WriteString("\n")
+			if n < len(rows)-1 {
+				b.WriteString("\n")
+			}
+			continue
+		}
+		if opts.GroupWorktrees {
+			writeGroupHeader(b, instances, rows, n)
+		}
+		statusText := getStatusText(instance.Status)
+		displayName := dashboardDisplayName(instance, favorites)
+
+		// Calculate spacing for right alignment
+		spacing := width - 4 - lipgloss.Width(displayName) - lipgloss.Width(statusText)
+		if spacing < 1 {
+			spacing = 1
+		}
+
+		// Render project line
+		if i == cursor {
+			b.WriteString(Cursor() + SelectedStyle.Render(displayName))
+		} else {
+			b.WriteString(NoCursor() + ItemStyle.Render(displayName))
+		}
+		b.WriteString(repeatChar(" ", spacing) + statusText)
+		b.WriteString("\n")
+
+		// Show path on next line (dimmed, indented), followed by any image check result
+		pathLine := "    " + DimmedStyle.Render(opts.fitPath(instance.Path, width-constants.PathTruncatePadding))
+		if hint := instanceHint(instance, imageStatus, opts.New[instance.Path]); hint != "" {
+			pathLine += "  " + hint
+		}
+		b.WriteString(pathLine)
+		b.WriteString("\n")
+
+		// Add spacing between entries except for the last one
+		if n < len(rows)-1 {
+			b.WriteString("\n")
+		}
+	}
+}
+
+// renderCompactRows renders each instance on a single line: name, inline path and
+// image check result, then right-aligned status. Names are padded to a shared column
+// so paths line up; the path is dropped when the terminal is too narrow for it.
+func renderCompactRows(b *strings.Builder, instances []devcontainer.ContainerInstanceWithStatus, favorites map[string]bool, imageStatus map[string]devcontainer.ImageStatus, cursor int, opts DashboardOptions, width int) {
+	names := make([]string, len(instances))
+	nameCol := 0
+	for i, instance := range instances {
+		names[i] = dashboardDisplayName(instance, favorites)
+		if w := lipgloss.Width(names[i]); w > nameCol {
+			nameCol = w
+		}
+	}
+	if maxCol := (width - 4) / 2; nameCol > maxCol {
+		nameCol = maxCol
+	}
+
+	collapsed := groupCollapsed(opts)
+	rows := visibleRows(instances, collapsed, opts.Visibility)
+	for n, i := range rows {
+		instance := instances[i]
+		if inCollapsedGroup(instances, collapsed, i) {
+			b.WriteString(collapsedGroupLine(instances, i, cursor, width))
+			b.WriteString("\n")
+			continue
+		}
+		if opts.GroupWorktrees {
+			writeGroupHeader(b, instances, rows, n)
+		}
+		statusText := getStatusText(instance.Status)
+		statusWidth := lipgloss.Width(statusText)
+
+		name := truncateText(names[i], nameCol)
+		name += repeatChar(" ", nameCol-lipgloss.Width(name))
+
+		// Fit the path (and image hint, if any) between the name column and status.
+		// Full paths are always shown, pushing the status out as far as needed.
+		inline := ""
+		budget := width - 4 - nameCol - 2 - statusWidth - 1
+		hint := instanceHint(instance, imageStatus, opts.New[instance.Path])
+		if hint != "" && (opts.FullPaths || budget-lipgloss.Width(hint)-2 >= constants.MinCompactPathWidth) {
+			budget -= lipgloss.Width(hint) + 2
+		} else {
+			hint = ""
+		}
+		if opts.FullPaths || budget >= constants.MinCompactPathWidth {
+			inline = "  " + DimmedStyle.Render(opts.fitPath(instance.Path, budget))
+			if hint != "" {
+				inline += "  " + hint
+			}
+		}
+
+		spacing := width - 4 - nameCol - lipgloss.Width(inline) - statusWidth
+		if spacing < 1 {
+			spacing = 1
+		}
+
+		if i == cursor {
+			b.WriteString(Cursor() + SelectedStyle.Render(name))
+		} else {
+			b.WriteString(NoCursor() + ItemStyle.Render(name))
+		}
+		b.WriteString(inline + repeatChar(" ", spacing) + statusText)
+		b.WriteString("\n")
+	}
+}
+
+// writeGroupHeader writes the repository name above the first listed instance
+// of a group with several listed worktrees; n is the instance's position in rows.
+// Headers aren't selectable, so the cursor still indexes instances directly.
+func writeGroupHeader(b *strings.Builder, instances []devcontainer.ContainerInstanceWithStatus, rows []int, n int) {
+	key := worktreeGroupKey(instances[rows[n]])
+	if n > 0 && worktreeGroupKey(instances[rows[n-1]]) == key {
+		return
+	}
+	if n+1 >= len(rows) || worktreeGroupKey(instances[rows[n+1]]) != key {
+		return
+	}
+	b.WriteString("  " + ColumnHeaderStyle.Render(instances[rows[n]].ProjectName()))
+	b.WriteString("\n")
+}
+
+// groupCollapsed returns the folded groups to honor, none unless grouping is on
+func groupCollapsed(opts DashboardOptions) map[string]bool {
+	if !opts.GroupWorktrees {
+		return nil
+	}
+	return opts.Collapsed
+}
+
+// collapsedGroupLine renders a folded worktree group as one selectable row:
+// the repository name with its worktree count, and how many are running.
+// The row is selected while the cursor is on any of the group's members.
+func collapsedGroupLine(instances []devcontainer.ContainerInstanceWithStatus, i, cursor, width int) string {
+	start, end := worktreeGroupBounds(instances, i)
+	name := fmt.Sprintf("▸ %s (%d worktrees)", instances[start].ProjectName(), end-start)
+
+	running := 0
+	for _, inst := range instances[start:end] {
+		if inst.Status == devcontainer.StatusRunning {
+			running++
+		}
+	}
+	statusText := getStatusText(devcontainer.StatusStopped)
+	if running > 0 {
+		statusText = StatusRunning.Render(fmt.Sprintf("● %d running", running))
+	}
+
+	spacing := width - 4 - lipgloss.Width(name) - lipgloss.Width(statusText)
+	if spacing < 1 {
+		spacing = 1
+	}
+	line := NoCursor() + ItemStyle.Render(name)
+	if cursor >= start && cursor < end {
+		line = Cursor() + SelectedStyle.Render(name)
+	}
+	return line + repeatChar(" ", spacing) + statusText
+}
+
+// truncateText shortens text to maxWidth runes, ending with "..." when cut
+func truncateText(text string, maxWidth int) string {
+	runes := []rune(text)
+	if len(runes) <= maxWidth {
+		return text
+	}
+	if maxWidth <= 3 {
+		return string(runes[:maxWidth])
+	}
+	return string(runes[:maxWidth-3]) + "..."
+}
+
 // getStatusText returns a visual indicator with text label for container status
 func getStatusText(status devcontainer.ContainerStatus) string {
 	switch status {
@@ -266,16 +704,26 @@ func getStatusIcon(status devcontainer.ContainerStatus) string {
 }
 
 // RenderNewWorktreeInput renders the text input for creating a new worktree
-func RenderNewWorktreeInput(projectName string, input interface{ View() string }) string {
+func RenderNewWorktreeInput(projectName, base string, input interface{ View() string }) string {
 	b := renderWithHeader("New Git Worktree")
 	b.WriteString("Project: ")
 	b.WriteString(SuccessStyle.Render(projectName))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if base != "" {
+		b.WriteString("Base: ")
+		b.WriteString(SuccessStyle.Render(base))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 	b.WriteString("Enter branch name (e.g., feature-auth, bugfix-123):")
 	b.WriteString("\n\n")
 	b.WriteString(input.View())
 	b.WriteString("\n\n")
-	b.WriteString(DimmedStyle.Render("Will create worktree in sibling directory with new branch"))
+	hint := "Will create worktree in sibling directory with new branch"
+	if base != "" {
+		hint += " off " + base
+	}
+	b.WriteString(DimmedStyle.Render(hint))
 	b.WriteString("\n\n")
 	b.WriteString(HelpStyle.Render("Enter: Create  Esc: Cancel"))
 	return b.String()
@@ -286,21 +734,467 @@ func RenderCreatingWorktree(branchName string, spinnerView string) string {
 	return renderSpinnerWithHint(spinnerView, "Creating worktree", branchName, "Running git worktree add...")
 }
 
+// RenderCloneInput renders the repository URL input for cloning into parentDir
+func RenderCloneInput(parentDir string, input interface{ View() string }) string {
+	b := renderWithHeader("Clone Repository")
+	b.WriteString("Enter repository URL (https:// or git@host:owner/repo):")
+	b.WriteString("\n\n")
+	b.WriteString(input.View())
+	b.WriteString("\n\n")
+	b.WriteString(DimmedStyle.Render("Will clone into " + parentDir + " and rescan projects"))
+	b.WriteString("\n\n")
+	b.WriteString(HelpStyle.Render("Enter: Clone  Esc: Cancel"))
+	return b.String()
+}
+
+// RenderCloning renders the loading state while cloning, with git's latest progress line
+func RenderCloning(destDir, progress, spinnerView string) string {
+	if progress == "" {
+		progress = "Running git clone..."
+	}
+	return renderSpinnerWithHint(spinnerView, "Cloning into", destDir, progress)
+}
+
 // RenderConfirmDeleteWorktree renders the confirmation dialog for deleting a worktree
-func RenderConfirmDeleteWorktree(branchName string) string {
+// deleteBranch says whether the local branch goes too; unpushed is its count
+// of commits on no remote (-1 if unknown); running says the worktree's
+// container is up and will be stopped first
+func RenderConfirmDeleteWorktree(branchName string, deleteBranch bool, unpushed int, running bool) string {
 	b := renderWithHeader("")
 	b.WriteString(ErrorStyle.Render("Delete worktree?"))
 	b.WriteString("\n\n")
 	b.WriteString("Branch: ")
 	b.WriteString(SuccessStyle.Render(branchName))
 	b.WriteString("\n\n")
-	b.WriteString(DimmedStyle.Render("This will remove the worktree directory and branch"))
+	b.WriteString(renderWorktreeDeletionScope(deleteBranch, unpushed))
+	if running {
+		b.WriteString("\n")
+		b.WriteString(WarningStyle.Render("The container is running and will be stopped."))
+	}
 	b.WriteString("\n\n")
 	b.WriteString(HelpStyle.Render("y: Confirm  n/Esc: Cancel"))
 	return b.String()
 }
 
+// renderWorktreeDeletionScope states what deleting a worktree removes: the
+// directory always, the local branch only when deleteBranch is set, in which
+// case unpushed commits (or a failed check, -1) are warned about
+func renderWorktreeDeletionScope(deleteBranch bool, unpushed int) string {
+	if !deleteBranch {
+		return DimmedStyle.Render("This removes the worktree directory. The branch is kept.")
+	}
+	scope := DimmedStyle.Render("This removes the worktree directory and deletes the local branch.")
+	switch {
+	case unpushed < 0:
+		scope += "\n" + WarningStyle.Render("Could not check the branch for unpushed commits.")
+	case unpushed == 1:
+		scope += "\n" + WarningStyle.Render("1 unpushed commit will be lost.")
+	case unpushed > 1:
+		scope += "\n" + WarningStyle.Render(fmt.Sprintf("%d unpushed commits will be lost.", unpushed))
+	}
+	return scope
+}
+
 // RenderDeletingWorktree renders the loading state while deleting a worktree
 func RenderDeletingWorktree(branchName string, spinnerView string) string {
 	return renderSpinnerWithHint(spinnerView, "Deleting worktree", branchName, "Running git worktree remove...")
 }
+
+// RenderMoveWorktreeInput renders the input for a worktree's new location
+func RenderMoveWorktreeInput(branchName string, input interface{ View() string }) string {
+	b := renderWithHeader("Move Worktree")
+	b.WriteString("Branch: ")
+	b.WriteString(SuccessStyle.Render(branchName))
+	b.WriteString("\n\n")
+	b.WriteString("Enter the new worktree directory:")
+	b.WriteString("\n\n")
+	b.WriteString(input.View())
+	b.WriteString("\n\n")
+	b.WriteString(DimmedStyle.Render("A running container is recreated at the new path"))
+	b.WriteString("\n\n")
+	b.WriteString(HelpStyle.Render("Enter: Move  Esc: Cancel"))
+	return b.String()
+}
+
+// RenderAddSearchPathInput renders the input for adding a search path from
+// the empty dashboard
+func RenderAddSearchPathInput(configPath string, input interface{ View() string }) string {
+	b := renderWithHeader("Add Search Path")
+	b.WriteString("Enter a directory to search for devcontainer projects:")
+	b.WriteString("\n\n")
+	b.WriteString(input.View())
+	b.WriteString("\n\n")
+	b.WriteString(DimmedStyle.Render("Saved to " + configPath))
+	b.WriteString("\n\n")
+	b.WriteString(HelpStyle.Render("Enter: Add and search  Esc: Cancel"))
+	return b.String()
+}
+
+// RenderMovingWorktree renders the loading state while moving a worktree
+func RenderMovingWorktree(branchName string, spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView, "Moving worktree", branchName, "Removing the old container and running git worktree move...")
+}
+
+// RenderConfirmDiscardWorktree asks for the branch name before stopping the
+// container and removing the worktree
+func RenderConfirmDiscardWorktree(projectName, branchName string, deleteBranch bool, unpushed int, ti textinput.Model) string {
+	b := renderWithHeader("")
+	b.WriteString(ErrorStyle.Render("Stop container and delete worktree?"))
+	b.WriteString("\n\n")
+	b.WriteString("Project: ")
+	b.WriteString(SuccessStyle.Render(projectName))
+	b.WriteString("\n")
+	b.WriteString("Branch: ")
+	b.WriteString(SuccessStyle.Render(branchName))
+	b.WriteString("\n\n")
+	b.WriteString(DimmedStyle.Render("The container is stopped first. Uncommitted changes will be lost."))
+	b.WriteString("\n")
+	b.WriteString(renderWorktreeDeletionScope(deleteBranch, unpushed))
+	b.WriteString("\n\n")
+	b.WriteString("Type the branch name to confirm:")
+	b.WriteString("\n\n")
+	b.WriteString(ti.View())
+	b.WriteString("\n\n")
+	b.WriteString(HelpStyle.Render("Enter: Confirm  Esc: Cancel"))
+	return b.String()
+}
+
+// RenderDiscardingWorktree renders the loading state while stopping the container and removing the worktree
+func RenderDiscardingWorktree(branchName string, spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView, "Stopping and deleting", branchName, "Running docker stop and git worktree remove...")
+}
+
+// RenderContainerLogsLoading renders the loading state while reading container logs
+func RenderContainerLogsLoading(projectName, spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView, "Reading logs for", projectName, "Running docker logs...")
+}
+
+// RenderContainerLogs renders container log output, used when no pager is available
+// scroll is the index of the first visible line
+func RenderContainerLogs(name string, lines []string, scroll, height, width int) string {
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	var b strings.Builder
+
+	// Bordered header
+	b.WriteString(RenderBorderedHeader("claude-quick", "Logs: "+name, width))
+	b.WriteString("\n\n")
+
+	if len(lines) == 0 || (len(lines) == 1 && lines[0] == "") {
+		b.WriteString(DimmedStyle.Render("No log output."))
+		b.WriteString("\n")
+	} else {
+		rows := scrollViewRows(height)
+		scroll = clampScroll(scroll, len(lines), rows)
+		end := scroll + rows
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		for _, line := range lines[scroll:end] {
+			b.WriteString("  " + truncateText(line, width-4))
+			b.WriteString("\n")
+		}
+
+		// Position indicator when the log doesn't fit
+		if len(lines) > rows {
+			b.WriteString("\n")
+			b.WriteString(DimmedStyle.Render(fmt.Sprintf("  %d-%d of %d lines (last %d shown; install less or set $PAGER for full logs)",
+				scroll+1, end, len(lines), constants.ContainerLogTailLines)))
+			b.WriteString("\n")
+		}
+	}
+
+	// Footer
+	b.WriteString("\n")
+	b.WriteString("  " + RenderSeparator(width-4))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("  %s  %s",
+		RenderKeyBinding("↑↓ PgUp PgDn", "scroll"),
+		RenderKeyBinding("q", "back"),
+	))
+
+	return b.String()
+}
+
+// RenderDevcontainerJSON renders a devcontainer.json with line numbers
+// scroll is the index of the first visible line; truncated notes that only
+// the start of a large file was read
+func RenderDevcontainerJSON(path string, lines []string, truncated bool, scroll, height, width int) string {
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	var b strings.Builder
+
+	// Bordered header
+	b.WriteString(RenderBorderedHeader("claude-quick", "devcontainer.json", width))
+	b.WriteString("\n\n")
+	b.WriteString(DimmedStyle.Render("  " + truncateText(path, width-4)))
+	b.WriteString("\n\n")
+
+	rows := scrollViewRows(height)
+	scroll = clampScroll(scroll, len(lines), rows)
+	end := scroll + rows
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	numWidth := len(fmt.Sprint(len(lines)))
+	for i, line := range lines[scroll:end] {
+		// Tabs would throw off truncation, which counts them as one column
+		line = strings.ReplaceAll(line, "\t", "    ")
+		num := fmt.Sprintf("%*d", numWidth, scroll+i+1)
+		b.WriteString("  " + DimmedStyle.Render(num) + "  " + truncateText(line, width-numWidth-6))
+		b.WriteString("\n")
+	}
+	if len(lines) > rows || truncated {
+		b.WriteString("\n")
+		status := fmt.Sprintf("  Lines %d-%d of %d", scroll+1, end, len(lines))
+		if truncated {
+			status += fmt.Sprintf(" (file cut off after %d KB)", constants.MaxConfigViewBytes/1024)
+		}
+		b.WriteString(DimmedStyle.Render(status))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	b.WriteString("  " + RenderSeparator(width-4))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("  %s  %s",
+		RenderKeyBinding("↑↓ PgUp PgDn", "scroll"),
+		RenderKeyBinding("any key", "back"),
+	))
+
+	return b.String()
+}
+
+// RenderWarningDetail renders the full dashboard warning, one line per
+// joined warning, wrapped to the terminal width
+func RenderWarningDetail(warning string, scroll, height, width int) string {
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	var b strings.Builder
+
+	// Bordered header
+	b.WriteString(RenderBorderedHeader("claude-quick", "Warning", width))
+	b.WriteString("\n\n")
+
+	lines := warningLines(warning, width)
+	rows := scrollViewRows(height)
+	scroll = clampScroll(scroll, len(lines), rows)
+	end := scroll + rows
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	for _, line := range lines[scroll:end] {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if len(lines) > rows {
+		b.WriteString("\n")
+		b.WriteString(DimmedStyle.Render(fmt.Sprintf("  Lines %d-%d of %d", scroll+1, end, len(lines))))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	b.WriteString("  " + RenderSeparator(width-4))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("  %s  %s  %s",
+		RenderKeyBinding("↑↓ PgUp PgDn", "scroll"),
+		RenderKeyBinding("d", "dismiss"),
+		RenderKeyBinding("q", "back"),
+	))
+
+	return b.String()
+}
+
+// warningLines splits a warning joined with "; " into one wrapped entry per warning
+func warningLines(warning string, width int) []string {
+	if width <= 0 {
+		width = defaultWidth
+	}
+	var lines []string
+	for _, part := range strings.Split(warning, "; ") {
+		for _, line := range strings.Split(wrapText(part, width-6), "\n") {
+			lines = append(lines, "  "+WarningStyle.Render(line))
+		}
+	}
+	return lines
+}
+
+// RenderWorktreeList renders the linked worktrees of a repository, one per
+// line with its branch and directory
+func RenderWorktreeList(projectName string, worktrees []devcontainer.WorktreeInfo, cursor, width int) string {
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	var b strings.Builder
+
+	// Bordered header
+	b.WriteString(RenderBorderedHeader("claude-quick", "Worktrees: "+projectName, width))
+	b.WriteString("\n\n")
+
+	if len(worktrees) == 0 {
+		b.WriteString(DimmedStyle.Render("  No worktrees besides the main one. Press n to create one."))
+		b.WriteString("\n")
+	}
+	for i, wt := range worktrees {
+		if i == cursor {
+			b.WriteString(Cursor() + SelectedStyle.Render(wt.Branch))
+		} else {
+			b.WriteString(NoCursor() + ItemStyle.Render(wt.Branch))
+		}
+		b.WriteString("  " + DimmedStyle.Render(truncateText(wt.Path, width-lipgloss.Width(wt.Branch)-10)))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	b.WriteString("  " + RenderSeparator(width-4))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("  %s  %s  %s  %s  %s",
+		RenderKeyBinding("↑↓", "navigate"),
+		RenderKeyBinding("enter", "connect"),
+		RenderKeyBinding("n", "new"),
+		RenderKeyBinding("d", "delete"),
+		RenderKeyBinding("q", "back"),
+	))
+
+	return b.String()
+}
+
+// RenderWorktreeCommitsLoading renders the loading state while listing commits
+func RenderWorktreeCommitsLoading(branchName string, spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView, "Loading commits for", branchName, "Running git log...")
+}
+
+// formatDiskUsage renders a worktree size for display. A partial measurement
+// is shown as a lower bound; a failed one as unknown.
+func formatDiskUsage(size int64, err error) string {
+	switch {
+	case err == nil:
+		return formatBytes(size)
+	case errors.Is(err, devcontainer.ErrPartialDiskUsage):
+		return "at least " + formatBytes(size) + " (some files unreadable)"
+	default:
+		return "unknown"
+	}
+}
+
+// formatBytes renders a byte count with a binary unit (e.g. "1.5 MB")
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// scrollViewRows returns how many list rows fit in the given terminal height
+func scrollViewRows(height int) int {
+	rows := height - constants.ScrollViewChrome
+	if rows < constants.MinScrollViewRows {
+		rows = constants.MinScrollViewRows
+	}
+	return rows
+}
+
+// clampScroll keeps the first visible line of a scrolled view of total lines
+// within range, so the last page is always full
+func clampScroll(scroll, total, rows int) int {
+	return max(0, min(scroll, total-rows))
+}
+
+// scrollKey moves the first visible line of a scrolled view for the up/k,
+// down/j, pgup and pgdown keys; handled is false for any other key
+func scrollKey(key string, scroll, total, rows int) (newScroll int, handled bool) {
+	switch key {
+	case "up", "k":
+		scroll--
+	case "down", "j":
+		scroll++
+	case "pgup":
+		scroll -= rows
+	case "pgdown":
+		scroll += rows
+	default:
+		return scroll, false
+	}
+	return clampScroll(scroll, total, rows), true
+}
+
+// RenderWorktreeCommits renders the commits on a worktree branch since its base
+// scroll is the index of the first visible commit; size is the worktree's disk
+// usage, or "" while it's still being measured
+func RenderWorktreeCommits(name, base, size string, commits []string, scroll, height, width int) string {
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	var b strings.Builder
+
+	// Bordered header
+	b.WriteString(RenderBorderedHeader("claude-quick", "Commits: "+name, width))
+	b.WriteString("\n\n")
+
+	b.WriteString(DimmedStyle.Render("Since: "))
+	b.WriteString(SuccessStyle.Render(base))
+	b.WriteString("\n")
+	b.WriteString(DimmedStyle.Render("Size:  "))
+	if size == "" {
+		b.WriteString(DimmedStyle.Render("calculating..."))
+	} else {
+		b.WriteString(ItemStyle.Render(size))
+	}
+	b.WriteString("\n\n")
+
+	if len(commits) == 0 {
+		b.WriteString(DimmedStyle.Render("No commits since " + base + "."))
+		b.WriteString("\n")
+	} else {
+		rows := scrollViewRows(height)
+		scroll = clampScroll(scroll, len(commits), rows)
+		end := scroll + rows
+		if end > len(commits) {
+			end = len(commits)
+		}
+
+		for _, commit := range commits[scroll:end] {
+			sha, subject, _ := strings.Cut(commit, " ")
+			b.WriteString("  " + WarningStyle.Render(sha) + " " + ItemStyle.Render(subject))
+			b.WriteString("\n")
+		}
+
+		// Position indicator when the list doesn't fit
+		if len(commits) > rows {
+			b.WriteString("\n")
+			b.WriteString(DimmedStyle.Render(fmt.Sprintf("  %d-%d of %d commits", scroll+1, end, len(commits))))
+			b.WriteString("\n")
+		}
+	}
+
+	// Footer
+	b.WriteString("\n")
+	b.WriteString("  " + RenderSeparator(width-4))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("  %s  %s",
+		RenderKeyBinding("↑↓ PgUp PgDn", "scroll"),
+		RenderKeyBinding("q", "back"),
+	))
+
+	return b.String()
+}
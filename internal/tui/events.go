@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/christophergyman/claude-quick/internal/constants"
+)
+
+// eventEntry is a single timestamped entry in the session activity log
+type eventEntry struct {
+	at   time.Time
+	text string
+}
+
+// logEvent appends an entry to the activity log, dropping the oldest
+// entries once the log exceeds constants.MaxEventLogEntries
+func (m *Model) logEvent(format string, args ...any) {
+	m.events = append(m.events, eventEntry{at: time.Now(), text: fmt.Sprintf(format, args...)})
+	if over := len(m.events) - constants.MaxEventLogEntries; over > 0 {
+		m.events = m.events[over:]
+	}
+}
+
+// RenderEventLog renders the session activity log, newest entry first
+// scroll is the index of the first visible entry
+func RenderEventLog(events []eventEntry, scroll, height, width int) string {
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	var b strings.Builder
+
+	// Bordered header
+	b.WriteString(RenderBorderedHeader("claude-quick", "Activity Log", width))
+	b.WriteString("\n\n")
+
+	if len(events) == 0 {
+		b.WriteString(DimmedStyle.Render("Nothing has happened yet this session."))
+		b.WriteString("\n")
+	} else {
+		rows := scrollViewRows(height)
+		scroll = clampScroll(scroll, len(events), rows)
+		end := scroll + rows
+		if end > len(events) {
+			end = len(events)
+		}
+
+		for i := scroll; i < end; i++ {
+			e := events[len(events)-1-i]
+			b.WriteString("  " + DimmedStyle.Render(e.at.Format("15:04:05")) + " " + ItemStyle.Render(e.text))
+			b.WriteString("\n")
+		}
+
+		// Position indicator when the log doesn't fit
+		if len(events) > rows {
+			b.WriteString("\n")
+			b.WriteString(DimmedStyle.Render(fmt.Sprintf("  %d-%d of %d events", scroll+1, end, len(events))))
+			b.WriteString("\n")
+		}
+	}
+
+	// Footer
+	b.WriteString("\n")
+	b.WriteString("  " + RenderSeparator(width-4))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("  %s  %s",
+		RenderKeyBinding("↑↓ PgUp PgDn", "scroll"),
+		RenderKeyBinding("q", "back"),
+	))
+
+	return b.String()
+}
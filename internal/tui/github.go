@@ -4,17 +4,24 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/christophergyman/claude-quick/internal/constants"
 	"github.com/christophergyman/claude-quick/internal/github"
 )
 
 // RenderGitHubIssuesLoading renders the loading state while fetching issues
 func RenderGitHubIssuesLoading(spinnerView string) string {
-	return renderSpinnerWithHint(spinnerView, "Fetching GitHub issues", "", "Querying repository issues via gh CLI...")
+	return renderSpinnerWithHint(spinnerView, "Fetching GitHub issues", "", "Querying repository issues via gh CLI... (esc to cancel)")
 }
 
 // RenderGitHubIssuesList renders the GitHub issues list view
-func RenderGitHubIssuesList(issues []github.Issue, cursor int, repoOwner, repoName string, width int) string {
+// marked holds issue numbers selected for batch worktree creation (may be nil)
+// state is the issue state filter the list was fetched with
+// query is the name of the saved search query applied ("" for none)
+// flash is a transient status message, already styled, shown above the footer (empty for none)
+// titleMax caps the width of issue titles (0 for no cap beyond the terminal width)
+func RenderGitHubIssuesList(issues []github.Issue, marked map[int]bool, cursor int, repoOwner, repoName string, state github.IssueState, query, flash string, titleMax, width int) string {
 	if width <= 0 {
 		width = defaultWidth
 	}
@@ -22,14 +29,23 @@ func RenderGitHubIssuesList(issues []github.Issue, cursor int, repoOwner, repoNa
 	var b strings.Builder
 
 	// Header
-	subtitle := fmt.Sprintf("GitHub Issues: %s/%s", repoOwner, repoName)
+	subtitle := fmt.Sprintf("GitHub Issues: %s/%s (%s)", repoOwner, repoName, state)
+	if query != "" {
+		subtitle = fmt.Sprintf("GitHub Issues: %s/%s (%s, %s)", repoOwner, repoName, state, query)
+	}
 	b.WriteString(RenderBorderedHeader("claude-quick", subtitle, width))
 	b.WriteString("\n\n")
 
 	if len(issues) == 0 {
-		b.WriteString(DimmedStyle.Render("No issues found."))
-		b.WriteString("\n\n")
-		b.WriteString(DimmedStyle.Render("Try changing the default_state filter in config."))
+		if query != "" {
+			b.WriteString(DimmedStyle.Render(fmt.Sprintf("No %s issues match %q.", state, query)))
+			b.WriteString("\n\n")
+			b.WriteString(DimmedStyle.Render("Press o to change the state filter or s to change the query."))
+		} else {
+			b.WriteString(DimmedStyle.Render(fmt.Sprintf("No %s issues found.", state)))
+			b.WriteString("\n\n")
+			b.WriteString(DimmedStyle.Render("Press o to change the state filter."))
+		}
 		b.WriteString("\n")
 	} else {
 		// Column headers
@@ -54,20 +70,37 @@ func RenderGitHubIssuesList(issues []github.Issue, cursor int, repoOwner, repoNa
 
 		// Render issues
 		for i, issue := range issues {
-			renderIssueRow(&b, issue, i == cursor, width)
+			renderIssueRow(&b, issue, i == cursor, marked[issue.Number], titleMax, width)
 		}
 	}
 
+	// Transient status message
+	if flash != "" {
+		b.WriteString("\n")
+		b.WriteString("  " + flash)
+		b.WriteString("\n")
+	}
+
 	// Footer
 	b.WriteString("\n")
 	b.WriteString("  " + RenderSeparator(width-4))
 	b.WriteString("\n")
 
 	// Key bindings
-	keybindings := fmt.Sprintf("  %s  %s  %s  %s  %s",
+	createLabel := "create worktree"
+	if len(marked) > 0 {
+		createLabel = fmt.Sprintf("create %d worktrees", len(marked))
+	}
+	keybindings := fmt.Sprintf("  %s  %s  %s  %s  %s  %s  %s  %s  %s  %s  %s",
 		RenderKeyBinding("↑↓", "navigate"),
-		RenderKeyBinding("enter", "create worktree"),
+		RenderKeyBinding("space", "mark"),
+		RenderKeyBinding("enter", createLabel),
 		RenderKeyBinding("v", "view"),
+		RenderKeyBinding("c", "new issue"),
+		RenderKeyBinding("y", "copy url"),
+		RenderKeyBinding("b", "copy branch"),
+		RenderKeyBinding("o", "state"),
+		RenderKeyBinding("s", "query"),
 		RenderKeyBinding("r", "refresh"),
 		RenderKeyBinding("q", "back"),
 	)
@@ -76,9 +109,54 @@ func RenderGitHubIssuesList(issues []github.Issue, cursor int, repoOwner, repoNa
 	return b.String()
 }
 
+// RenderSavedQueryPicker renders the saved gh search queries to filter issues
+// by, with a first row that clears the query. active is the applied query name.
+func RenderSavedQueryPicker(queries map[string]string, active string, cursor, width int) string {
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	var b strings.Builder
+
+	b.WriteString(RenderBorderedHeader("claude-quick", "Saved Queries", width))
+	b.WriteString("\n\n")
+
+	names := append([]string{""}, github.Config{SavedQueries: queries}.QueryNames()...)
+	for i, name := range names {
+		label, search := name, queries[name]
+		if name == "" {
+			label, search = "(none)", "all issues in the state filter"
+		}
+		if name == active {
+			label += " ✓"
+		}
+		if i == cursor {
+			b.WriteString(Cursor() + SelectedStyle.Render(label))
+		} else {
+			b.WriteString(NoCursor() + ItemStyle.Render(label))
+		}
+		b.WriteString("  " + DimmedStyle.Render(truncateText(search, width-lipgloss.Width(label)-10)))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	b.WriteString("\n")
+	b.WriteString("  " + RenderSeparator(width-4))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("  %s  %s  %s",
+		RenderKeyBinding("↑↓", "navigate"),
+		RenderKeyBinding("enter", "apply"),
+		RenderKeyBinding("q", "back"),
+	))
+
+	return b.String()
+}
+
 // renderIssueRow renders a single issue row
-func renderIssueRow(b *strings.Builder, issue github.Issue, selected bool, width int) {
-	// Format: #123   Title truncated...            open
+// marked issues show a check mark between the number and title
+// titles fill the row up to titleMax cells (0 for no cap)
+func renderIssueRow(b *strings.Builder, issue github.Issue, selected bool, marked bool, titleMax, width int) {
+	// Format: #123  ✓ Title truncated...            open
 	numberStr := fmt.Sprintf("#%-5d", issue.Number)
 
 	// State indicator with priority: in-progress > open > closed
@@ -92,18 +170,18 @@ func renderIssueRow(b *strings.Builder, issue github.Issue, selected bool, width
 	}
 	stateWidth := lipgloss.Width(stateIndicator)
 
-	// Calculate title width
+	// Title gets everything between the marker and a one-space gap before the state
 	numberWidth := lipgloss.Width(numberStr)
-	titleMaxWidth := width - 4 - numberWidth - 2 - stateWidth - 2
-	if titleMaxWidth < 10 {
-		titleMaxWidth = 10 // Minimum reasonable width for title
+	titleMaxWidth := width - 4 - numberWidth - 2 - stateWidth - 1
+	if titleMax > 0 && titleMax < titleMaxWidth {
+		titleMaxWidth = titleMax
 	}
-
-	title := issue.Title
-	if len(title) > titleMaxWidth {
-		title = title[:titleMaxWidth-3] + "..."
+	if titleMaxWidth < constants.MinIssueTitleWidth {
+		titleMaxWidth = constants.MinIssueTitleWidth
 	}
 
+	title := truncateToWidth(issue.Title, titleMaxWidth)
+
 	// Calculate spacing for right-aligned state
 	titleWidth := lipgloss.Width(title)
 	spacing := width - 4 - numberWidth - 2 - titleWidth - stateWidth
@@ -111,16 +189,22 @@ func renderIssueRow(b *strings.Builder, issue github.Issue, selected bool, width
 		spacing = 1
 	}
 
+	// Marker occupies the first column of the gap so titles stay aligned
+	marker := "  "
+	if marked {
+		marker = SuccessStyle.Render("✓") + " "
+	}
+
 	// Build line
 	if selected {
 		b.WriteString(Cursor())
 		b.WriteString(SelectedStyle.Render(numberStr))
-		b.WriteString("  ")
+		b.WriteString(marker)
 		b.WriteString(SelectedStyle.Render(title))
 	} else {
 		b.WriteString(NoCursor())
 		b.WriteString(ItemStyle.Render(numberStr))
-		b.WriteString("  ")
+		b.WriteString(marker)
 		b.WriteString(ItemStyle.Render(title))
 	}
 	b.WriteString(repeatChar(" ", spacing))
@@ -129,14 +213,15 @@ func renderIssueRow(b *strings.Builder, issue github.Issue, selected bool, width
 }
 
 // RenderGitHubIssueDetail renders the detailed view of a single issue
-func RenderGitHubIssueDetail(issue *github.Issue, body string, width int) string {
+// flash is a transient status message, already styled, shown above the footer (empty for none)
+func RenderGitHubIssueDetail(issue *github.Issue, body, flash string, width int) string {
 	if width <= 0 {
 		width = defaultWidth
 	}
 
 	// Handle nil issue
 	if issue == nil {
-		return RenderError(fmt.Errorf("no issue to display"), "Press any key to go back")
+		return RenderError(fmt.Errorf("no issue to display"), "Press any key to go back", false, 0, width, 0)
 	}
 
 	var b strings.Builder
@@ -185,13 +270,21 @@ func RenderGitHubIssueDetail(issue *github.Issue, body string, width int) string
 
 	b.WriteString("\n\n")
 
+	// Transient status message
+	if flash != "" {
+		b.WriteString(flash)
+		b.WriteString("\n\n")
+	}
+
 	// Footer
 	b.WriteString(RenderSeparator(width - 4))
 	b.WriteString("\n")
 
 	// Key bindings
-	keybindings := fmt.Sprintf("  %s  %s  %s",
+	keybindings := fmt.Sprintf("  %s  %s  %s  %s  %s",
 		RenderKeyBinding("enter", "create worktree"),
+		RenderKeyBinding("y", "copy url"),
+		RenderKeyBinding("b", "copy branch"),
 		RenderKeyBinding("t", "theme"),
 		RenderKeyBinding("q", "back"),
 	)
@@ -200,6 +293,45 @@ func RenderGitHubIssueDetail(issue *github.Issue, body string, width int) string
 	return b.String()
 }
 
+// RenderNewIssueInput renders the prompts for a new issue in owner/repo.
+// title is empty while the title is being entered, then shown above the
+// optional body input.
+func RenderNewIssueInput(owner, repo, title string, ti textinput.Model) string {
+	b := renderWithHeader(fmt.Sprintf("New Issue: %s/%s", owner, repo))
+	if title == "" {
+		b.WriteString("Title:")
+	} else {
+		b.WriteString(DimmedStyle.Render("Title: " + title))
+		b.WriteString("\n\n")
+		b.WriteString("Body (optional):")
+	}
+	b.WriteString("\n\n")
+	b.WriteString(ti.View())
+	b.WriteString("\n\n")
+
+	// Footer
+	action := "next"
+	if title != "" {
+		action = "create issue"
+	}
+	b.WriteString(RenderSeparator(defaultWidth - 4))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s  %s",
+		RenderKeyBinding("enter", action),
+		RenderKeyBinding("esc", "cancel"),
+	))
+
+	return b.String()
+}
+
+// RenderGitHubIssueCreating renders the loading state while a new issue is created
+func RenderGitHubIssueCreating(title, spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView,
+		"Creating issue",
+		title,
+		"Running gh issue create...")
+}
+
 // RenderGitHubWorktreeCreating renders the loading state during worktree creation from issue
 func RenderGitHubWorktreeCreating(issueNumber int, spinnerView string) string {
 	return renderSpinnerWithHint(spinnerView,
@@ -208,15 +340,25 @@ func RenderGitHubWorktreeCreating(issueNumber int, spinnerView string) string {
 		"Running git worktree add...")
 }
 
+// RenderGitHubBatchWorktreeCreating renders the loading state while creating worktrees for marked issues
+func RenderGitHubBatchWorktreeCreating(count int, spinnerView string) string {
+	return renderSpinnerWithHint(spinnerView,
+		fmt.Sprintf("Creating worktrees for %d issues", count),
+		"",
+		"Running git worktree add for each issue...")
+}
+
 // RenderGitHubIssueDetailLoading renders the loading state while fetching issue details
 func RenderGitHubIssueDetailLoading(issueNumber int, spinnerView string) string {
 	return renderSpinnerWithHint(spinnerView,
 		fmt.Sprintf("Loading issue #%d", issueNumber),
 		"",
-		"Fetching issue details...")
+		"Fetching issue details... (esc to cancel)")
 }
 
-// wrapText wraps text at word boundaries to fit within maxWidth
+// wrapText wraps text at word boundaries to fit within maxWidth.
+// Widths are measured with lipgloss so styled text wraps by its visible width,
+// and words longer than a full line are broken across lines.
 func wrapText(text string, maxWidth int) string {
 	if maxWidth <= 0 {
 		maxWidth = 60
@@ -238,15 +380,20 @@ func wrapText(text string, maxWidth int) string {
 		// Simple word wrap
 		words := strings.Fields(line)
 		currentLine := ""
+		currentWidth := 0
 		for _, word := range words {
-			if currentLine == "" {
-				currentLine = word
-			} else if len(currentLine)+1+len(word) <= maxWidth {
-				currentLine += " " + word
-			} else {
-				result.WriteString(currentLine)
-				result.WriteString("\n")
-				currentLine = word
+			for _, part := range splitLongWord(word, maxWidth) {
+				partWidth := lipgloss.Width(part)
+				if currentLine == "" {
+					currentLine, currentWidth = part, partWidth
+				} else if currentWidth+1+partWidth <= maxWidth {
+					currentLine += " " + part
+					currentWidth += 1 + partWidth
+				} else {
+					result.WriteString(currentLine)
+					result.WriteString("\n")
+					currentLine, currentWidth = part, partWidth
+				}
 			}
 		}
 		if currentLine != "" {
@@ -256,3 +403,37 @@ func wrapText(text string, maxWidth int) string {
 
 	return result.String()
 }
+
+// splitLongWord breaks a word wider than maxWidth into maxWidth-sized chunks.
+// Styled words are returned unchanged since cutting them could split an escape sequence.
+func splitLongWord(word string, maxWidth int) []string {
+	if lipgloss.Width(word) <= maxWidth || strings.Contains(word, "\x1b") {
+		return []string{word}
+	}
+	var parts []string
+	runes := []rune(word)
+	for len(runes) > maxWidth {
+		parts = append(parts, string(runes[:maxWidth]))
+		runes = runes[maxWidth:]
+	}
+	return append(parts, string(runes))
+}
+
+// truncateToWidth cuts text to at most maxWidth terminal cells, ending it
+// with "..." when cut. Widths are ANSI-aware and count wide runes as two cells.
+func truncateToWidth(text string, maxWidth int) string {
+	if lipgloss.Width(text) <= maxWidth {
+		return text
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range text {
+		w := lipgloss.Width(string(r))
+		if used+w > maxWidth-3 {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return b.String() + "..."
+}
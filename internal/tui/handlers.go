@@ -2,11 +2,18 @@ package tui
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/christophergyman/claude-quick/internal/constants"
 	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/github"
+	"github.com/christophergyman/claude-quick/internal/util"
 )
 
 // handleKeyPress processes keyboard input based on current state
@@ -14,27 +21,62 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch m.state {
 	case StateDashboard:
 		return m.handleDashboardKey(msg)
+	case StateContainerStarting:
+		return m.handleContainerStartingKey(msg)
 	case StateConfirmStop, StateConfirmRestart:
 		return m.handleConfirmKey(msg)
 	case StateConfirmDeleteWorktree:
 		return m.handleConfirmDeleteWorktreeKey(msg)
 	case StateConfirmTmuxStop, StateConfirmTmuxRestart:
 		return m.handleTmuxConfirmKey(msg)
+	case StateConfirmTmuxKillAll:
+		return m.handleTmuxKillAllConfirmKey(msg)
+	case StateConfirmTmuxServerRestart:
+		return m.handleTmuxServerRestartConfirmKey(msg)
 	case StateTmuxSelect:
 		return m.handleTmuxSelectKey(msg)
 	case StateNewSessionInput:
 		return m.handleNewSessionInputKey(msg)
+	case StatePipeSessionInput:
+		return m.handlePipeSessionInputKey(msg)
 	case StateNewWorktreeInput:
 		return m.handleNewWorktreeInputKey(msg)
+	case StateCloneInput:
+		return m.handleCloneInputKey(msg)
+	case StateAuthWarning:
+		return m.handleAuthWarningKey(msg)
+	case StateGitHubIssuesLoading, StateGitHubIssueDetailLoading, StateGitHubIssueCreating:
+		return m.handleGitHubLoadingKey(msg)
 	case StateGitHubIssuesList:
 		return m.handleGitHubIssuesListKey(msg)
+	case StateGitHubIssueTitleInput, StateGitHubIssueBodyInput:
+		return m.handleNewIssueInputKey(msg)
 	case StateGitHubIssueDetail:
 		return m.handleGitHubIssueDetailKey(msg)
+	case StateWorktreeCommits:
+		return m.handleWorktreeCommitsKey(msg)
+	case StateEventLog:
+		return m.handleEventLogKey(msg)
+	case StateContainerLogs:
+		return m.handleContainerLogsKey(msg)
+	case StateWarningDetail:
+		return m.handleWarningDetailKey(msg)
+	case StateConfirmDiscardWorktree:
+		return m.handleConfirmDiscardWorktreeKey(msg)
+	case StateMoveWorktreeInput:
+		return m.handleMoveWorktreeInputKey(msg)
+	case StateAddSearchPathInput:
+		return m.handleAddSearchPathInputKey(msg)
+	case StateShowDevcontainerJSON:
+		return m.handleDevcontainerJSONKey(msg)
+	case StateWorktreeList:
+		return m.handleWorktreeListKey(msg)
+	case StateGitHubQuerySelect:
+		return m.handleQuerySelectKey(msg)
+	case StateProfileSelect:
+		return m.handleProfileSelectKey(msg)
 	case StateError:
-		// Any key returns to container select
-		m.state = StateDashboard
-		m.err = nil
-		return m, nil
+		return m.handleErrorKey(msg)
 	case StateShowConfig:
 		// Any key returns to previous state
 		m.state = m.previousState
@@ -48,29 +90,46 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.jumpPending {
+		m.jumpPending = false
+		return m.jumpToProject(msg)
+	}
+
+	// With every instance filtered out the cursor selects nothing, so only
+	// keys that don't act on an instance apply
+	if m.allInstancesHidden() {
+		switch msg.String() {
+		case "q", "ctrl+c", "F", "R", "t", "w", "?", "C", "P", "l", "W", "esc":
+		default:
+			return m, nil
+		}
+	}
+
 	switch msg.String() {
 	case "q", "ctrl+c":
 		return m, tea.Quit
 
 	case "up", "k":
-		if m.cursor > 0 {
-			m.cursor--
-		}
+		m = m.moveDashboardCursor(-1)
 
 	case "down", "j":
-		if m.cursor < len(m.instancesStatus)-1 {
-			m.cursor++
-		}
+		m = m.moveDashboardCursor(1)
 
 	case "enter":
+		if len(m.instancesStatus) > 0 && inCollapsedGroup(m.instancesStatus, m.dashboardCollapsed(), m.cursor) {
+			// Enter on a folded group unfolds it rather than connecting
+			return m.toggleGroupCollapsed()
+		}
 		if len(m.instancesStatus) > 0 {
 			m.selectedInstance = &m.instancesStatus[m.cursor].ContainerInstance
 			if m.instancesStatus[m.cursor].Status == devcontainer.StatusRunning {
 				// Container is running, load tmux sessions
+				m.pendingSingleAttach = m.config.IsAutoAttachSingleSession()
 				m.state = StateLoadingTmuxSessions
 				return m, tea.Batch(m.spinner.Tick, m.loadTmuxSessions())
 			}
 			// Container is stopped or unknown, start it
+			m.beginContainerStart()
 			m.state = StateContainerStarting
 			return m, tea.Batch(
 				m.spinner.Tick,
@@ -82,16 +141,39 @@ func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if len(m.instancesStatus) > 0 {
 			m.selectedInstance = &m.instancesStatus[m.cursor].ContainerInstance
 			m.state = StateConfirmStop
+			// A second x in quick succession confirms (see handleConfirmKey)
+			m.stopPressedAt = time.Now()
 		}
 
 	case "r":
 		if len(m.instancesStatus) > 0 {
 			m.selectedInstance = &m.instancesStatus[m.cursor].ContainerInstance
+			m.restartSessions = nil
+			// Fetch the sessions a restart would interrupt before confirming
+			if m.instancesStatus[m.cursor].Status == devcontainer.StatusRunning {
+				return m, m.loadRestartSessions()
+			}
 			m.state = StateConfirmRestart
 		}
 
+	case "a":
+		// Add a search path without going through the wizard - only offered
+		// when nothing was found
+		if len(m.instancesStatus) == 0 && m.config != nil {
+			m.state = StateAddSearchPathInput
+			m.textInput.Placeholder = "~/projects"
+			m.textInput.SetValue("")
+			m.textInput.Focus()
+			return m, textinput.Blink
+		}
+
 	case "R":
-		// Manual refresh
+		// Manual refresh. With nothing discovered yet, search the paths again
+		// in case projects were added since startup.
+		if len(m.instancesStatus) == 0 {
+			m.state = StateDiscovering
+			return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
+		}
 		m.state = StateRefreshingStatus
 		return m, tea.Batch(m.spinner.Tick, m.refreshInstanceStatus())
 
@@ -107,6 +189,25 @@ func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.selectedInstance = selected
+			m.worktreeBase = ""
+			m.state = StateNewWorktreeInput
+			m.worktreeInput.SetValue("")
+			m.worktreeInput.Focus()
+			return m, textinput.Blink
+		}
+
+	case "b":
+		// Create a new worktree branching off the selected worktree's branch
+		if len(m.instancesStatus) > 0 {
+			selected := &m.instancesStatus[m.cursor].ContainerInstance
+			if err := checkLinkedWorktree(selected, "branch off"); err != nil {
+				m.state = StateError
+				m.err = err
+				m.errHint = "Press any key to go back"
+				return m, nil
+			}
+			m.selectedInstance = selected
+			m.worktreeBase = selected.Worktree.Branch
 			m.state = StateNewWorktreeInput
 			m.worktreeInput.SetValue("")
 			m.worktreeInput.Focus()
@@ -117,23 +218,209 @@ func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Delete worktree - only for non-main worktrees
 		if len(m.instancesStatus) > 0 {
 			selected := &m.instancesStatus[m.cursor].ContainerInstance
-			// Only allow deleting non-main worktrees
+			if err := checkLinkedWorktree(selected, "delete"); err != nil {
+				m.state = StateError
+				m.err = err
+				m.errHint = "Press any key to go back"
+				return m, nil
+			}
+			m.selectedInstance = selected
+			// Check for unpushed work and a running container before asking
+			return m, m.loadDeleteChecks(false)
+		}
+
+	case "D":
+		// Stop the container and delete the worktree in one step
+		if len(m.instancesStatus) > 0 {
+			selected := &m.instancesStatus[m.cursor].ContainerInstance
+			if err := checkLinkedWorktree(selected, "delete"); err != nil {
+				m.state = StateError
+				m.err = err
+				m.errHint = "Press any key to go back"
+				return m, nil
+			}
+			m.selectedInstance = selected
+			if m.deletesBranch() {
+				return m, m.loadDeleteChecks(true)
+			}
+			return m.openDiscardConfirm()
+		}
+
+	case "m":
+		// Move worktree - only for non-main worktrees
+		if len(m.instancesStatus) > 0 {
+			selected := &m.instancesStatus[m.cursor].ContainerInstance
+			if err := checkLinkedWorktree(selected, "move"); err != nil {
+				m.state = StateError
+				m.err = err
+				m.errHint = "Press any key to go back"
+				return m, nil
+			}
+			m.selectedInstance = selected
+			m.state = StateMoveWorktreeInput
+			m.textInput.Placeholder = ""
+			m.textInput.SetValue(selected.Path)
+			m.textInput.CursorEnd()
+			m.textInput.Focus()
+			return m, textinput.Blink
+		}
+
+	case "c":
+		// Show commits since base branch - requires a git project
+		if len(m.instancesStatus) > 0 {
+			selected := &m.instancesStatus[m.cursor].ContainerInstance
 			if selected.Worktree == nil {
 				m.state = StateError
-				m.err = fmt.Errorf("cannot delete: not a git worktree")
+				m.err = fmt.Errorf("cannot show commits: not a git repository")
 				m.errHint = "Press any key to go back"
 				return m, nil
 			}
-			if selected.Worktree.IsMain {
+			m.selectedInstance = selected
+			m.state = StateWorktreeCommitsLoading
+			return m, tea.Batch(m.spinner.Tick, m.loadWorktreeCommits())
+		}
+
+	case "s":
+		// Open a plain shell in the running container, without tmux
+		if len(m.instancesStatus) > 0 {
+			if m.instancesStatus[m.cursor].Status != devcontainer.StatusRunning {
+				return m.showFlash("Start the container (enter) before opening a shell")
+			}
+			m.selectedInstance = &m.instancesStatus[m.cursor].ContainerInstance
+			return m.openShell(StateDashboard)
+		}
+
+	case "L":
+		// Page through the selected container's logs
+		if len(m.instancesStatus) > 0 {
+			m.selectedInstance = &m.instancesStatus[m.cursor].ContainerInstance
+			m.state = StateContainerLogsLoading
+			return m, tea.Batch(m.spinner.Tick, m.loadContainerLogs())
+		}
+
+	case "v":
+		// List the repository's worktrees - the way to reach them when
+		// show_worktrees keeps them off the dashboard
+		if len(m.instancesStatus) > 0 {
+			selected := &m.instancesStatus[m.cursor].ContainerInstance
+			if selected.Worktree == nil {
 				m.state = StateError
-				m.err = fmt.Errorf("cannot delete the main worktree")
+				m.err = fmt.Errorf("cannot list worktrees: not a git repository")
 				m.errHint = "Press any key to go back"
 				return m, nil
 			}
 			m.selectedInstance = selected
-			m.state = StateConfirmDeleteWorktree
+			return m, m.loadWorktreeList()
+		}
+
+	case "J":
+		// View the raw devcontainer.json the selected instance starts from
+		if len(m.instancesStatus) > 0 {
+			m.selectedInstance = &m.instancesStatus[m.cursor].ContainerInstance
+			return m, loadDevcontainerJSON(m.selectedInstance.ConfigPath)
+		}
+
+	case "Y":
+		// Copy the devcontainer exec prefix for running commands from another terminal
+		if len(m.instancesStatus) > 0 {
+			path := m.instancesStatus[m.cursor].Path
+			return m, copyToClipboard(devcontainer.ExecCommandPrefix(path), "exec command")
+		}
+
+	case "P":
+		// Switch to another profile's search paths, starting on the active one
+		names := m.config.ProfileNames()
+		if len(names) == 0 {
+			return m.showFlash("No profiles (set profiles in the config)")
+		}
+		m.profileCursor = 0
+		for i, name := range names {
+			if name == m.config.ActiveProfile() {
+				m.profileCursor = i + 1
+			}
+		}
+		m.state = StateProfileSelect
+
+	case "A":
+		// Adopt a container started outside claude-quick
+		if len(m.instancesStatus) > 0 && m.instancesStatus[m.cursor].Unmanaged {
+			m.selectedInstance = &m.instancesStatus[m.cursor].ContainerInstance
+			m.state = StateAdoptingContainer
+			return m, tea.Batch(m.spinner.Tick, m.adoptContainer())
+		}
+
+	case "C":
+		// Clone a repository into the first search path
+		if m.cloneParentDir() == "" {
+			m.state = StateError
+			m.err = fmt.Errorf("cannot clone: no search paths configured")
+			m.errHint = "Press any key to go back"
+			return m, nil
+		}
+		m.cloneInput = newTextInput("https://github.com/owner/repo.git")
+		m.cloneInput.CharLimit = constants.CloneURLCharLimit
+		m.cloneInput.Width = constants.CloneURLInputWidth
+		m.cloneInput.Focus()
+		m.state = StateCloneInput
+		return m, textinput.Blink
+
+	case "z":
+		if len(m.instancesStatus) > 0 {
+			return m.toggleGroupCollapsed()
+		}
+
+	case "F":
+		// Cycle which instances are listed: all, running+stopped, running only
+		m.visibility = m.visibility.next()
+		m = m.snapDashboardCursor()
+
+	case "f":
+		// Type-ahead: the next key picks the letter to jump to
+		if len(m.instancesStatus) > 0 {
+			m.jumpPending = true
+			return m.showFlash("Jump to project: type its first letter")
+		}
+
+	case "*":
+		// Toggle favorite and re-sort, keeping the cursor on the same instance
+		if len(m.instancesStatus) > 0 {
+			path := m.instancesStatus[m.cursor].Path
+			if m.config.ToggleFavorite(path) {
+				m.logEvent("Pinned %s", m.instancesStatus[m.cursor].DisplayName())
+			} else {
+				m.logEvent("Unpinned %s", m.instancesStatus[m.cursor].DisplayName())
+			}
+			m.sortInstances()
+			for i, inst := range m.instancesStatus {
+				if inst.Path == path {
+					m.cursor = i
+					break
+				}
+			}
+			return m, m.saveFavorites()
 		}
 
+	case "u":
+		// Check whether a newer image has been pulled - requires a running container
+		if len(m.instancesStatus) > 0 {
+			selected := &m.instancesStatus[m.cursor]
+			if selected.Status != devcontainer.StatusRunning {
+				m.state = StateError
+				m.err = fmt.Errorf("cannot check image: container is not running")
+				m.errHint = "Press any key to go back"
+				return m, nil
+			}
+			m.selectedInstance = &selected.ContainerInstance
+			m.state = StateCheckingImage
+			return m, tea.Batch(m.spinner.Tick, m.checkImageStatus())
+		}
+
+	case "l":
+		// Show session activity log
+		m.eventScroll = 0
+		m.state = StateEventLog
+		return m, nil
+
 	case "?":
 		m.previousState = m.state
 		m.state = StateShowConfig
@@ -141,9 +428,7 @@ func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "t":
 		// Toggle dark/light theme
-		m.darkMode = !m.darkMode
-		ApplyTheme(m.darkMode)
-		return m, nil
+		return m.toggleTheme()
 
 	case "g":
 		// Open GitHub Issues - requires selecting a git project first
@@ -156,9 +441,31 @@ func (m Model) handleDashboardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.errHint = "Press any key to go back"
 				return m, nil
 			}
+			// Fail fast with a friendly message if gh is missing
+			// (the auth check runs gh, so it happens in the async load)
+			if err := github.CheckInstalled(); err != nil {
+				m.state = StateError
+				m.err = err
+				m.errHint = "Press any key to go back"
+				return m, nil
+			}
 			m.selectedInstance = selected
+			m.issueState = "" // Each visit starts from the configured filter
+			m.issueQuery = ""
 			m.state = StateGitHubIssuesLoading
-			return m, tea.Batch(m.spinner.Tick, m.loadGitHubIssues())
+			ctx := m.startGitHubRequest()
+			return m, tea.Batch(m.spinner.Tick, m.loadGitHubIssues(ctx))
+		}
+
+	case "esc":
+		// Dismiss the warning banner
+		m.warning = ""
+
+	case "W":
+		// Show the full warning when the banner is truncated
+		if m.warning != "" {
+			m.state = StateWarningDetail
+			m.warningScroll = 0
 		}
 
 	case "w":
@@ -181,6 +488,12 @@ func (m Model) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.state = StateContainerRestarting
 		return m, tea.Batch(m.spinner.Tick, m.restartContainer())
+	case "x":
+		// Double-pressed x stops without waiting for the dialog
+		if m.state == StateConfirmStop && time.Since(m.stopPressedAt) <= constants.StopDoublePressMs*time.Millisecond {
+			m.state = StateContainerStopping
+			return m, tea.Batch(m.spinner.Tick, m.stopContainer())
+		}
 	case "n", "N", "esc":
 		m.state = StateDashboard
 		m.selectedInstance = nil
@@ -210,8 +523,37 @@ func (m Model) handleTmuxConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m Model) handleTmuxKillAllConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		m.state = StateTmuxKillingAll
+		return m, tea.Batch(m.spinner.Tick, m.killAllTmuxSessions())
+	case "n", "N", "esc":
+		m.state = StateTmuxSelect
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m Model) handleTmuxServerRestartConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		m.state = StateTmuxServerRestarting
+		return m, tea.Batch(m.spinner.Tick, m.restartTmuxServer())
+	case "n", "N", "esc":
+		m.state = StateTmuxSelect
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
 func (m Model) handleTmuxSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	totalOptions := TotalTmuxOptions(m.tmuxSessions)
+	presets := m.presetSessions()
+	totalOptions := TotalTmuxOptions(m.tmuxSessions, presets)
 
 	switch msg.String() {
 	case "q", "esc":
@@ -241,6 +583,31 @@ func (m Model) handleTmuxSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.state = StateConfirmTmuxStop
 		}
 
+	case "X":
+		// Kill all tmux sessions (only when there are sessions to kill)
+		if len(m.tmuxSessions) > 0 {
+			m.state = StateConfirmTmuxKillAll
+		}
+
+	case "p":
+		// Pipe the selected session's output to a file (only for existing sessions)
+		if m.cursor < len(m.tmuxSessions) {
+			m.selectedSession = &m.tmuxSessions[m.cursor]
+			m.state = StatePipeSessionInput
+			m.textInput.SetValue("")
+			m.textInput.Placeholder = defaultPipePath(m.selectedSession.Name)
+			m.textInput.Focus()
+			return m, textinput.Blink
+		}
+
+	case "s":
+		// Open a plain shell in the container instead of a tmux session
+		return m.openShell(StateTmuxSelect)
+
+	case "R":
+		// Restart the tmux server, e.g. when it has wedged (even with no sessions listed)
+		m.state = StateConfirmTmuxServerRestart
+
 	case "r":
 		// Restart selected tmux session (only for existing sessions)
 		if m.cursor < len(m.tmuxSessions) {
@@ -255,15 +622,19 @@ func (m Model) handleTmuxSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "t":
 		// Toggle dark/light theme
-		m.darkMode = !m.darkMode
-		ApplyTheme(m.darkMode)
-		return m, nil
+		return m.toggleTheme()
 
 	case "enter":
-		if IsNewSessionSelected(m.tmuxSessions, m.cursor) {
+		if name, ok := SelectedPreset(m.tmuxSessions, presets, m.cursor); ok {
+			// Create the preset session and attach to it
+			m.state = StateAttaching
+			return m, tea.Batch(m.spinner.Tick, m.createTmuxSession(name))
+		}
+		if IsNewSessionSelected(m.tmuxSessions, presets, m.cursor) {
 			// Show text input for new session name
 			m.state = StateNewSessionInput
-			m.textInput.SetValue("")
+			m.textInput.SetValue(m.newSessionPrefill())
+			m.textInput.Placeholder = m.defaultSessionName()
 			m.textInput.Focus()
 			return m, textinput.Blink
 		}
@@ -288,7 +659,7 @@ func (m Model) handleNewSessionInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		name := m.textInput.Value()
 		if name == "" {
-			name = m.config.DefaultSessionName
+			name = m.defaultSessionName()
 		}
 		m.state = StateAttaching
 		return m, tea.Batch(
@@ -340,6 +711,37 @@ func (m Model) handleNewWorktreeInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m Model) handleCloneInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		// Cancel and go back to dashboard
+		m.state = StateDashboard
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "enter":
+		url := strings.TrimSpace(m.cloneInput.Value())
+		if err := devcontainer.ValidateCloneURL(url); err != nil {
+			m.state = StateError
+			m.err = err
+			m.errHint = "Press any key to go back"
+			return m, nil
+		}
+		destDir := filepath.Join(m.cloneParentDir(), devcontainer.RepoNameFromURL(url))
+		m.state = StateCloning
+		var cmd tea.Cmd
+		m, cmd = m.startClone(url, destDir)
+		return m, tea.Batch(m.spinner.Tick, cmd)
+	}
+
+	// Pass other keys to text input
+	var cmd tea.Cmd
+	m.cloneInput, cmd = m.cloneInput.Update(msg)
+	return m, cmd
+}
+
 func (m Model) handleConfirmDeleteWorktreeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y":
@@ -362,6 +764,7 @@ func (m Model) handleGitHubIssuesListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.state = StateDashboard
 		m.githubIssues = nil
 		m.selectedIssue = nil
+		m.markedIssues = nil
 		m.cursor = 0
 		return m, nil
 
@@ -381,9 +784,65 @@ func (m Model) handleGitHubIssuesListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "r":
 		// Refresh issues
 		m.state = StateGitHubIssuesLoading
-		return m, tea.Batch(m.spinner.Tick, m.loadGitHubIssues())
+		ctx := m.startGitHubRequest()
+		return m, tea.Batch(m.spinner.Tick, m.loadGitHubIssues(ctx))
+
+	case "o":
+		// Cycle the state filter and re-fetch. Marks are cleared since
+		// marked issues may not be in the new list.
+		m.issueState = m.issueStateFilter().Next()
+		m.markedIssues = nil
+		m.state = StateGitHubIssuesLoading
+		ctx := m.startGitHubRequest()
+		return m, tea.Batch(m.spinner.Tick, m.loadGitHubIssues(ctx))
+
+	case "s":
+		// Pick a saved search query, starting on the active one
+		names := m.config.GitHub.QueryNames()
+		if len(names) == 0 {
+			return m.showFlash("No saved queries (set github.saved_queries)")
+		}
+		m.queryCursor = 0
+		for i, name := range names {
+			if name == m.issueQuery {
+				m.queryCursor = i + 1
+			}
+		}
+		m.state = StateGitHubQuerySelect
+
+	case "c":
+		// Create a new issue: title first, then an optional body
+		m.newIssueTitle = ""
+		m.state = StateGitHubIssueTitleInput
+		m.textInput.SetValue("")
+		m.textInput.Placeholder = "Issue title"
+		m.textInput.Focus()
+		return m, textinput.Blink
+
+	case " ":
+		// Mark/unmark issue for batch worktree creation
+		if m.cursor < len(m.githubIssues) {
+			if m.markedIssues == nil {
+				m.markedIssues = make(map[int]bool)
+			}
+			number := m.githubIssues[m.cursor].Number
+			if m.markedIssues[number] {
+				delete(m.markedIssues, number)
+			} else {
+				m.markedIssues[number] = true
+			}
+		}
 
 	case "enter":
+		// Create worktrees for all marked issues
+		if marked := m.getMarkedIssues(); len(marked) > 0 {
+			m.selectedIssue = nil
+			m.state = StateGitHubWorktreeCreating
+			return m, tea.Batch(
+				m.spinner.Tick,
+				m.createWorktreesFromIssues(marked),
+			)
+		}
 		// Create worktree from selected issue
 		if len(m.githubIssues) > 0 && m.cursor < len(m.githubIssues) {
 			m.selectedIssue = &m.githubIssues[m.cursor]
@@ -394,19 +853,30 @@ func (m Model) handleGitHubIssuesListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			)
 		}
 
+	case "y":
+		// Copy issue URL to clipboard
+		if m.cursor < len(m.githubIssues) && m.githubIssues[m.cursor].URL != "" {
+			return m, copyToClipboard(m.githubIssues[m.cursor].URL, "issue URL")
+		}
+
+	case "b":
+		// Copy the branch name a worktree for this issue would get
+		if m.cursor < len(m.githubIssues) {
+			return m.copyIssueBranch(&m.githubIssues[m.cursor])
+		}
+
 	case "v":
 		// View issue details
 		if len(m.githubIssues) > 0 && m.cursor < len(m.githubIssues) {
 			m.selectedIssue = &m.githubIssues[m.cursor]
 			m.state = StateGitHubIssueDetailLoading
-			return m, tea.Batch(m.spinner.Tick, m.loadGitHubIssueDetail())
+			ctx := m.startGitHubRequest()
+			return m, tea.Batch(m.spinner.Tick, m.loadGitHubIssueDetail(ctx))
 		}
 
 	case "t":
 		// Toggle theme
-		m.darkMode = !m.darkMode
-		ApplyTheme(m.darkMode)
-		return m, nil
+		return m.toggleTheme()
 	}
 	return m, nil
 }
@@ -421,9 +891,22 @@ func (m Model) handleGitHubIssueDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "ctrl+c":
 		return m, tea.Quit
 
+	case "y":
+		// Copy issue URL to clipboard
+		if m.selectedIssue != nil && m.selectedIssue.URL != "" {
+			return m, copyToClipboard(m.selectedIssue.URL, "issue URL")
+		}
+
+	case "b":
+		// Copy the branch name a worktree for this issue would get
+		if m.selectedIssue != nil {
+			return m.copyIssueBranch(m.selectedIssue)
+		}
+
 	case "enter":
 		// Create worktree from this issue
 		if m.selectedIssue != nil {
+			m.markedIssues = nil
 			m.state = StateGitHubWorktreeCreating
 			return m, tea.Batch(
 				m.spinner.Tick,
@@ -433,9 +916,581 @@ func (m Model) handleGitHubIssueDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "t":
 		// Toggle theme
-		m.darkMode = !m.darkMode
-		ApplyTheme(m.darkMode)
+		return m.toggleTheme()
+	}
+	return m, nil
+}
+
+// copyIssueBranch copies the branch name generated for issue, as a worktree
+// created from it would use, or flashes why that name can't be used
+func (m Model) copyIssueBranch(issue *github.Issue) (tea.Model, tea.Cmd) {
+	branchName := github.GenerateBranchName(issue, m.config.GitHub.BranchPrefix)
+	if err := devcontainer.ValidateBranchName(branchName); err != nil {
+		return m.showErrorFlash(fmt.Sprintf("Invalid branch name %s: %v", branchName, err))
+	}
+	return m, copyToClipboard(branchName, "branch name "+branchName)
+}
+
+func (m Model) handleGitHubLoadingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.cancelGitHubRequest()
+		if m.state == StateGitHubIssueDetailLoading || m.state == StateGitHubIssueCreating {
+			// Back to the issues list that was already loaded. A cancelled
+			// create may still have gone through; r reloads the list.
+			m.selectedIssue = nil
+			m.state = StateGitHubIssuesList
+			return m, nil
+		}
+		m.state = StateDashboard
+		m.selectedInstance = nil
+		m.createdIssue = 0
+		return m, nil
+
+	case "ctrl+c":
+		m.cancelGitHubRequest()
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m Model) handleWorktreeCommitsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		// Go back to dashboard
+		m.state = StateDashboard
+		m.worktreeCommits = nil
+		m.commitsBase = ""
+		m.commitsScroll = 0
+		m.selectedInstance = nil
 		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	}
+	if scroll, ok := scrollKey(msg.String(), m.commitsScroll, len(m.worktreeCommits), scrollViewRows(m.height)); ok {
+		m.commitsScroll = scroll
 	}
 	return m, nil
 }
+
+func (m Model) handleEventLogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		// Go back to dashboard
+		m.state = StateDashboard
+		m.eventScroll = 0
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	}
+	if scroll, ok := scrollKey(msg.String(), m.eventScroll, len(m.events), scrollViewRows(m.height)); ok {
+		m.eventScroll = scroll
+	}
+	return m, nil
+}
+
+func (m Model) handleAuthWarningKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "esc", " ":
+		// The container is already up; continue to its tmux sessions
+		m.authFailures = nil
+		return m.handleContainerStarted()
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m Model) handleErrorKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Scroll keys only apply when the error is taller than the view
+	if m.height > 0 {
+		total, rows := len(errorLines(m.err, m.width)), scrollViewRows(m.height)
+		if total > rows {
+			if scroll, ok := scrollKey(msg.String(), m.errScroll, total, rows); ok {
+				m.errScroll = scroll
+				return m, nil
+			}
+		}
+	}
+
+	if msg.String() == "r" && m.retry != retryNone && m.selectedInstance != nil {
+		return m.retryFailedOperation()
+	}
+
+	// Any other key returns to container select
+	m.state = StateDashboard
+	m.err = nil
+	m.errScroll = 0
+	m.retry = retryNone
+	return m, nil
+}
+
+// retryFailedOperation re-runs the operation that led to the error screen
+func (m Model) retryFailedOperation() (tea.Model, tea.Cmd) {
+	op := m.retry
+	m.err = nil
+	m.errScroll = 0
+	m.retry = retryNone
+	m.logEvent("Retrying after error in %s", m.getInstanceName())
+
+	var cmd tea.Cmd
+	switch op {
+	case retryStart:
+		m.beginContainerStart()
+		m.state = StateContainerStarting
+		cmd = m.startContainer()
+	case retryStop:
+		m.state = StateContainerStopping
+		cmd = m.stopContainer()
+	case retryRestart:
+		m.state = StateContainerRestarting
+		cmd = m.restartContainer()
+	case retryLoadSessions:
+		m.state = StateLoadingTmuxSessions
+		cmd = m.loadTmuxSessions()
+	}
+	return m, tea.Batch(m.spinner.Tick, cmd)
+}
+
+func (m Model) handleContainerLogsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		// Go back to dashboard
+		m.state = StateDashboard
+		m.logLines = nil
+		m.logScroll = 0
+		m.selectedInstance = nil
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	}
+	if scroll, ok := scrollKey(msg.String(), m.logScroll, len(m.logLines), scrollViewRows(m.height)); ok {
+		m.logScroll = scroll
+	}
+	return m, nil
+}
+
+func (m Model) handleWarningDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		m.state = StateDashboard
+		m.warningScroll = 0
+		return m, nil
+
+	case "d":
+		// Dismiss the warning and go back
+		m.state = StateDashboard
+		m.warning = ""
+		m.warningScroll = 0
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	}
+	if scroll, ok := scrollKey(msg.String(), m.warningScroll, len(warningLines(m.warning, m.width)), scrollViewRows(m.height)); ok {
+		m.warningScroll = scroll
+	}
+	return m, nil
+}
+
+// checkLinkedWorktree returns an error naming action unless instance is a
+// non-main git worktree
+func checkLinkedWorktree(instance *devcontainer.ContainerInstance, action string) error {
+	if instance.Worktree == nil {
+		return fmt.Errorf("cannot %s: not a git worktree", action)
+	}
+	if instance.Worktree.IsMain {
+		return fmt.Errorf("cannot %s the main worktree", action)
+	}
+	return nil
+}
+
+func (m Model) handleConfirmDiscardWorktreeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.state = StateDashboard
+		m.selectedInstance = nil
+		m.textInput.Blur()
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "enter":
+		// Only proceed once the branch name has been typed exactly
+		if m.textInput.Value() != m.getWorktreeBranch() {
+			return m, nil
+		}
+		m.textInput.Blur()
+		m.state = StateDiscardingWorktree
+		return m, tea.Batch(m.spinner.Tick, m.discardWorktree())
+	}
+
+	// Pass other keys to text input
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleMoveWorktreeInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.state = StateDashboard
+		m.selectedInstance = nil
+		m.textInput.Blur()
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "enter":
+		value := strings.TrimSpace(m.textInput.Value())
+		if value == "" {
+			return m, nil
+		}
+		newPath, err := filepath.Abs(util.ExpandPath(value))
+		if err != nil {
+			m.state = StateError
+			m.err = fmt.Errorf("invalid path %q: %w", value, err)
+			m.errHint = "Press any key to go back"
+			return m, nil
+		}
+		if newPath == m.selectedInstance.Path {
+			// Nothing to move
+			m.state = StateDashboard
+			m.selectedInstance = nil
+			return m, nil
+		}
+		m.textInput.Blur()
+		m.state = StateMovingWorktree
+		return m, tea.Batch(m.spinner.Tick, m.moveWorktree(newPath))
+	}
+
+	// Pass other keys to text input
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleContainerStartingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		// Cancelling the context kills devcontainer up; its result is ignored
+		m.cancelContainerStart()
+		m.logEvent("Cancelled starting %s", m.getInstanceName())
+		m.pendingAutoAttach = false
+		m.state = StateDashboard
+		m.selectedInstance = nil
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) handleAddSearchPathInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.state = StateDashboard
+		m.textInput.Blur()
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "enter":
+		value := strings.TrimSpace(m.textInput.Value())
+		if value == "" {
+			return m, nil
+		}
+		m.textInput.Blur()
+		m.state = StateDiscovering
+		return m, tea.Batch(m.spinner.Tick, m.addSearchPath(value))
+	}
+
+	// Pass other keys to text input
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// handleDevcontainerJSONKey scrolls the devcontainer.json viewer; any other
+// key goes back to the dashboard
+func (m Model) handleDevcontainerJSONKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	}
+	if scroll, ok := scrollKey(msg.String(), m.jsonScroll, len(m.jsonLines), scrollViewRows(m.height)); ok {
+		m.jsonScroll = scroll
+		return m, nil
+	}
+
+	m.state = StateDashboard
+	m.jsonLines = nil
+	m.jsonTruncated = false
+	m.jsonScroll = 0
+	m.selectedInstance = nil
+	return m, nil
+}
+
+// openDiscardConfirm asks for the selected worktree's branch name before
+// stopping its container and deleting it
+func (m Model) openDiscardConfirm() (tea.Model, tea.Cmd) {
+	m.state = StateConfirmDiscardWorktree
+	m.textInput.SetValue("")
+	m.textInput.Placeholder = m.selectedInstance.Worktree.Branch
+	m.textInput.Focus()
+	return m, textinput.Blink
+}
+
+func (m Model) handleWorktreeListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		m.state = StateDashboard
+		m.worktreeList = nil
+		m.worktreeCursor = 0
+		m.selectedInstance = nil
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.worktreeCursor > 0 {
+			m.worktreeCursor--
+		}
+
+	case "down", "j":
+		if m.worktreeCursor < len(m.worktreeList)-1 {
+			m.worktreeCursor++
+		}
+
+	case "enter":
+		// Start (or reuse) the worktree's container, then pick a session
+		if len(m.worktreeList) > 0 {
+			m.selectedInstance = m.worktreeInstance(m.worktreeList[m.worktreeCursor])
+			m.beginContainerStart()
+			m.state = StateContainerStarting
+			return m, tea.Batch(m.spinner.Tick, m.startContainer())
+		}
+
+	case "d":
+		if len(m.worktreeList) > 0 {
+			m.selectedInstance = m.worktreeInstance(m.worktreeList[m.worktreeCursor])
+			return m, m.loadDeleteChecks(false)
+		}
+
+	case "n":
+		// New worktree for the repository, as n on the dashboard
+		m.worktreeBase = ""
+		m.state = StateNewWorktreeInput
+		m.worktreeInput.SetValue("")
+		m.worktreeInput.Focus()
+		return m, textinput.Blink
+	}
+	return m, nil
+}
+
+// handleQuerySelectKey handles the saved query picker. Row 0 clears the
+// query; the rest are the saved queries in name order.
+func (m Model) handleQuerySelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	names := m.config.GitHub.QueryNames()
+	switch msg.String() {
+	case "q", "esc":
+		m.state = StateGitHubIssuesList
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.queryCursor > 0 {
+			m.queryCursor--
+		}
+
+	case "down", "j":
+		if m.queryCursor < len(names) {
+			m.queryCursor++
+		}
+
+	case "enter":
+		// Re-fetch with the chosen query. Marks are cleared since marked
+		// issues may not be in the new list.
+		m.issueQuery = ""
+		if m.queryCursor > 0 && m.queryCursor <= len(names) {
+			m.issueQuery = names[m.queryCursor-1]
+		}
+		m.markedIssues = nil
+		m.state = StateGitHubIssuesLoading
+		ctx := m.startGitHubRequest()
+		return m, tea.Batch(m.spinner.Tick, m.loadGitHubIssues(ctx))
+	}
+	return m, nil
+}
+
+// handleProfileSelectKey handles the profile switcher. Row 0 returns to the
+// top-level search paths; the rest are the profiles in name order.
+func (m Model) handleProfileSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	names := m.config.ProfileNames()
+	switch msg.String() {
+	case "q", "esc":
+		m.state = StateDashboard
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.profileCursor > 0 {
+			m.profileCursor--
+		}
+
+	case "down", "j":
+		if m.profileCursor < len(names) {
+			m.profileCursor++
+		}
+
+	case "enter":
+		name := ""
+		if m.profileCursor > 0 && m.profileCursor <= len(names) {
+			name = names[m.profileCursor-1]
+		}
+		// Switch on a copy so in-flight saves keep the config they captured
+		cfg := *m.config
+		if err := cfg.ApplyProfile(name); err != nil {
+			m.state = StateError
+			m.err = err
+			m.errHint = "Press any key to go back"
+			return m, nil
+		}
+		m.config = &cfg
+		if name == "" {
+			m.logEvent("Switched to the default search paths")
+		} else {
+			m.logEvent("Switched to profile %s", name)
+		}
+		m.state = StateDiscovering
+		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
+	}
+	return m, nil
+}
+
+// defaultPipePath is the file a session is piped to when no path is entered:
+// relative, so it lands in the mounted project directory
+func defaultPipePath(session string) string {
+	return session + ".log"
+}
+
+func (m Model) handlePipeSessionInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.state = StateTmuxSelect
+		m.selectedSession = nil
+		m.textInput.Blur()
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "enter":
+		outPath := strings.TrimSpace(m.textInput.Value())
+		if outPath == "" {
+			outPath = defaultPipePath(m.getSessionName())
+		}
+		m.textInput.Blur()
+		m.state = StateTmuxSelect
+		return m, m.pipeSession(outPath)
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// toggleGroupCollapsed folds or unfolds the worktree group under the cursor,
+// leaving the cursor on the group's first row
+func (m Model) toggleGroupCollapsed() (tea.Model, tea.Cmd) {
+	if m.config == nil || !m.config.IsGroupWorktrees() {
+		return m.showFlash("Worktree groups need group_worktrees enabled")
+	}
+	start, end := worktreeGroupBounds(m.instancesStatus, m.cursor)
+	if end-start < 2 {
+		return m.showFlash("Not part of a worktree group")
+	}
+	key := worktreeGroupKey(m.instancesStatus[m.cursor])
+	if m.collapsedGroups[key] {
+		delete(m.collapsedGroups, key)
+	} else {
+		if m.collapsedGroups == nil {
+			m.collapsedGroups = make(map[string]bool)
+		}
+		m.collapsedGroups[key] = true
+	}
+	m.cursor = start
+	return m, nil
+}
+
+// handleNewIssueInputKey handles the title and body prompts for a new issue.
+// Enter on the title moves on to the body, which may be left empty.
+func (m Model) handleNewIssueInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.state = StateGitHubIssuesList
+		m.newIssueTitle = ""
+		m.textInput.Blur()
+		return m, nil
+
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "enter":
+		value := strings.TrimSpace(m.textInput.Value())
+		if m.state == StateGitHubIssueTitleInput {
+			if value == "" {
+				return m, nil
+			}
+			m.newIssueTitle = value
+			m.state = StateGitHubIssueBodyInput
+			m.textInput.SetValue("")
+			m.textInput.Placeholder = "Description (optional)"
+			return m, nil
+		}
+		m.textInput.Blur()
+		m.state = StateGitHubIssueCreating
+		ctx := m.startGitHubRequest()
+		return m, tea.Batch(m.spinner.Tick, m.createGitHubIssue(ctx, m.newIssueTitle, value))
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// jumpToProject moves the dashboard cursor to the next visible project whose
+// name starts with the typed letter, wrapping around. Any other key cancels.
+func (m Model) jumpToProject(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.flash = ""
+	if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 {
+		return m, nil
+	}
+	letter := unicode.ToLower(msg.Runes[0])
+
+	rows := m.dashboardRows()
+	current := cursorRow(rows, m.cursor)
+	for step := 1; step <= len(rows); step++ {
+		i := rows[(current+step)%len(rows)]
+		name := []rune(m.instancesStatus[i].DisplayName())
+		if len(name) > 0 && unicode.ToLower(name[0]) == letter {
+			m.cursor = i
+			return m, nil
+		}
+	}
+	return m.showFlash(fmt.Sprintf("No project starting with %q", string(msg.Runes[0])))
+}
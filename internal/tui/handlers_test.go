@@ -0,0 +1,2368 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/christophergyman/claude-quick/internal/auth"
+	"github.com/christophergyman/claude-quick/internal/config"
+	"github.com/christophergyman/claude-quick/internal/constants"
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/github"
+	"github.com/christophergyman/claude-quick/internal/tmux"
+)
+
+// keyMsg builds a KeyMsg whose String() matches the given key name
+func keyMsg(key string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// ============================================================================
+// GitHub issues list tests
+// ============================================================================
+
+func TestHandleGitHubIssuesListKey_MarkIssue(t *testing.T) {
+	m := Model{
+		state: StateGitHubIssuesList,
+		githubIssues: []github.Issue{
+			{Number: 1, Title: "First"},
+			{Number: 2, Title: "Second"},
+		},
+		cursor: 1,
+	}
+
+	newModel, _ := m.handleGitHubIssuesListKey(keyMsg(" "))
+	model := newModel.(Model)
+	if !model.markedIssues[2] {
+		t.Fatal("space should mark the issue under the cursor")
+	}
+
+	newModel, _ = model.handleGitHubIssuesListKey(keyMsg(" "))
+	model = newModel.(Model)
+	if model.markedIssues[2] {
+		t.Error("space on a marked issue should unmark it")
+	}
+}
+
+func TestHandleGitHubIssuesListKey_CopyBranch(t *testing.T) {
+	m := Model{
+		state:        StateGitHubIssuesList,
+		config:       &config.Config{GitHub: github.Config{BranchPrefix: "issue-"}},
+		githubIssues: []github.Issue{{Number: 7, Title: "Fix login"}},
+	}
+
+	if _, cmd := m.handleGitHubIssuesListKey(keyMsg("b")); cmd == nil {
+		t.Error("b should copy the generated branch name")
+	}
+
+	// A prefix git rejects is reported instead of copied
+	m.config.GitHub.BranchPrefix = "-"
+	newModel, _ := m.handleGitHubIssuesListKey(keyMsg("b"))
+	if got := newModel.(Model).flash; !strings.Contains(got, "Invalid branch name -7-fix-login") {
+		t.Errorf("flash = %q, want it to name the invalid branch", got)
+	}
+}
+
+func TestGetMarkedIssues_ListOrder(t *testing.T) {
+	m := Model{
+		githubIssues: []github.Issue{
+			{Number: 5},
+			{Number: 3},
+			{Number: 9},
+		},
+		markedIssues: map[int]bool{9: true, 5: true},
+	}
+
+	marked := m.getMarkedIssues()
+	if len(marked) != 2 {
+		t.Fatalf("getMarkedIssues() returned %d issues, want 2", len(marked))
+	}
+	if marked[0].Number != 5 || marked[1].Number != 9 {
+		t.Errorf("getMarkedIssues() = [#%d #%d], want [#5 #9]", marked[0].Number, marked[1].Number)
+	}
+}
+
+func TestHandleGitHubIssuesListKey_EscClearsMarks(t *testing.T) {
+	m := Model{
+		state:        StateGitHubIssuesList,
+		githubIssues: []github.Issue{{Number: 1}},
+		markedIssues: map[int]bool{1: true},
+	}
+
+	newModel, _ := m.handleGitHubIssuesListKey(keyMsg("esc"))
+	model := newModel.(Model)
+	if model.state != StateDashboard {
+		t.Errorf("state = %v, want %v", model.state, StateDashboard)
+	}
+	if len(model.markedIssues) != 0 {
+		t.Error("leaving the issues list should clear marked issues")
+	}
+}
+
+func TestHandleGitHubIssuesListKey_CopyURL(t *testing.T) {
+	m := Model{
+		state:        StateGitHubIssuesList,
+		githubIssues: []github.Issue{{Number: 1, URL: "https://github.com/o/r/issues/1"}},
+	}
+
+	_, cmd := m.handleGitHubIssuesListKey(keyMsg("y"))
+	if cmd == nil {
+		t.Error("y should return a clipboard copy command")
+	}
+
+	m.githubIssues[0].URL = ""
+	_, cmd = m.handleGitHubIssuesListKey(keyMsg("y"))
+	if cmd != nil {
+		t.Error("y should do nothing for an issue without a URL")
+	}
+}
+
+func TestHandleGitHubIssuesListKey_CycleStateFilter(t *testing.T) {
+	cfg := &config.Config{GitHub: github.DefaultConfig()}
+	cfg.GitHub.DefaultState = github.IssueStateClosed
+	m := Model{
+		state:        StateGitHubIssuesList,
+		config:       cfg,
+		githubIssues: []github.Issue{{Number: 1}},
+		markedIssues: map[int]bool{1: true},
+	}
+	if got := m.issueStateFilter(); got != github.IssueStateClosed {
+		t.Fatalf("issueStateFilter() = %q, want the configured %q", got, github.IssueStateClosed)
+	}
+
+	newModel, cmd := m.handleGitHubIssuesListKey(keyMsg("o"))
+	model := newModel.(Model)
+	if model.issueStateFilter() != github.IssueStateAll {
+		t.Errorf("issueStateFilter() = %q, want %q", model.issueStateFilter(), github.IssueStateAll)
+	}
+	if model.state != StateGitHubIssuesLoading || cmd == nil {
+		t.Errorf("state = %v, want %v with a fetch command", model.state, StateGitHubIssuesLoading)
+	}
+	if len(model.markedIssues) != 0 {
+		t.Error("changing the filter should clear marked issues")
+	}
+	model.cancelGitHubRequest()
+}
+
+func TestFlashExpiry(t *testing.T) {
+	m, _ := Model{}.showFlash("Copied issue URL")
+	if m.flash != "Copied issue URL" {
+		t.Fatalf("flash = %q, want %q", m.flash, "Copied issue URL")
+	}
+
+	// A stale expiry from an earlier flash must not clear the current one
+	newModel, _ := m.Update(flashExpiredMsg{id: m.flashID - 1})
+	if newModel.(Model).flash == "" {
+		t.Error("stale flashExpiredMsg should not clear the flash")
+	}
+
+	newModel, _ = m.Update(flashExpiredMsg{id: m.flashID})
+	if newModel.(Model).flash != "" {
+		t.Error("matching flashExpiredMsg should clear the flash")
+	}
+}
+
+// ============================================================================
+// tmux select tests
+// ============================================================================
+
+func TestHandleTmuxSelectKey_KillAll(t *testing.T) {
+	m := Model{
+		state:        StateTmuxSelect,
+		tmuxSessions: []tmux.Session{{Name: "main"}, {Name: "dev"}},
+	}
+
+	newModel, _ := m.handleTmuxSelectKey(keyMsg("X"))
+	if newModel.(Model).state != StateConfirmTmuxKillAll {
+		t.Errorf("state = %v, want %v", newModel.(Model).state, StateConfirmTmuxKillAll)
+	}
+
+	// Nothing to kill when there are no sessions
+	m.tmuxSessions = nil
+	newModel, _ = m.handleTmuxSelectKey(keyMsg("X"))
+	if newModel.(Model).state != StateTmuxSelect {
+		t.Errorf("state = %v, want %v", newModel.(Model).state, StateTmuxSelect)
+	}
+}
+
+func TestHandleTmuxKillAllConfirmKey_Cancel(t *testing.T) {
+	m := Model{state: StateConfirmTmuxKillAll}
+
+	newModel, _ := m.handleTmuxKillAllConfirmKey(keyMsg("n"))
+	if newModel.(Model).state != StateTmuxSelect {
+		t.Errorf("state = %v, want %v", newModel.(Model).state, StateTmuxSelect)
+	}
+}
+
+func TestRenderTmuxKillAllConfirmDialog(t *testing.T) {
+	result := RenderTmuxKillAllConfirmDialog("myproject", 3)
+	if !strings.Contains(result, "Kill ALL 3 tmux sessions?") {
+		t.Error("should show the session count")
+	}
+	if !strings.Contains(result, "myproject") {
+		t.Error("should show the project name")
+	}
+
+	if !strings.Contains(RenderTmuxKillAllConfirmDialog("p", 1), "1 tmux session?") {
+		t.Error("should use singular for one session")
+	}
+}
+
+// ============================================================================
+// GitHub loading cancellation tests
+// ============================================================================
+
+func TestHandleGitHubLoadingKey_EscCancelsIssuesLoad(t *testing.T) {
+	cancelled := false
+	m := Model{
+		state:        StateGitHubIssuesLoading,
+		githubCancel: func() { cancelled = true },
+	}
+
+	newModel, _ := m.handleKeyPress(keyMsg("esc"))
+	got := newModel.(Model)
+	if got.state != StateDashboard {
+		t.Errorf("state = %v, want %v", got.state, StateDashboard)
+	}
+	if !cancelled {
+		t.Error("esc should cancel the in-flight request")
+	}
+	if got.githubCancel != nil {
+		t.Error("cancel func should be cleared")
+	}
+}
+
+func TestHandleGitHubLoadingKey_EscCancelsDetailLoad(t *testing.T) {
+	issue := github.Issue{Number: 7}
+	m := Model{
+		state:         StateGitHubIssueDetailLoading,
+		githubIssues:  []github.Issue{issue},
+		selectedIssue: &issue,
+	}
+
+	newModel, _ := m.handleKeyPress(keyMsg("esc"))
+	got := newModel.(Model)
+	if got.state != StateGitHubIssuesList {
+		t.Errorf("state = %v, want %v", got.state, StateGitHubIssuesList)
+	}
+	if got.selectedIssue != nil {
+		t.Error("selected issue should be cleared")
+	}
+}
+
+func TestHandleGitHubLoadingKey_EscCancelsIssueCreate(t *testing.T) {
+	cancelled := false
+	m := Model{
+		state:        StateGitHubIssueCreating,
+		githubIssues: []github.Issue{{Number: 1}},
+		githubCancel: func() { cancelled = true },
+	}
+
+	newModel, _ := m.handleKeyPress(keyMsg("esc"))
+	got := newModel.(Model)
+	if got.state != StateGitHubIssuesList || !cancelled {
+		t.Errorf("state = %v, cancelled = %v; want %v with gh cancelled", got.state, cancelled, StateGitHubIssuesList)
+	}
+
+	// A create that finishes after cancelling leaves the list alone
+	newModel, cmd := got.Update(githubIssueCreatedMsg{number: 2, request: got.githubRequest})
+	if got := newModel.(Model); got.state != StateGitHubIssuesList || cmd != nil {
+		t.Errorf("late create result should be ignored, state = %v", got.state)
+	}
+
+	if _, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlC}); cmd == nil {
+		t.Error("ctrl+c should quit while creating an issue")
+	}
+}
+
+func TestUpdate_IgnoresStaleGitHubResults(t *testing.T) {
+	m := Model{state: StateDashboard}
+
+	newModel, _ := m.Update(githubIssuesLoadedMsg{issues: []github.Issue{{Number: 1}}})
+	if got := newModel.(Model); got.state != StateDashboard || got.githubIssues != nil {
+		t.Error("issues loaded after cancel should be ignored")
+	}
+
+	newModel, _ = m.Update(githubIssuesErrorMsg{err: context.Canceled})
+	if got := newModel.(Model); got.state != StateDashboard {
+		t.Errorf("state = %v, want %v", got.state, StateDashboard)
+	}
+}
+
+func TestUpdate_IgnoresSupersededGitHubResults(t *testing.T) {
+	// A load was cancelled and another started: the first one's late
+	// result or error must not land on the second
+	m := Model{state: StateGitHubIssuesLoading, githubRequest: 2}
+
+	newModel, _ := m.Update(githubIssuesLoadedMsg{issues: []github.Issue{{Number: 1}}, request: 1})
+	if got := newModel.(Model); got.state != StateGitHubIssuesLoading || got.githubIssues != nil {
+		t.Error("issues from a superseded request should be ignored")
+	}
+	newModel, _ = m.Update(githubIssuesErrorMsg{err: context.Canceled, request: 1})
+	if got := newModel.(Model); got.state != StateGitHubIssuesLoading {
+		t.Errorf("state = %v after a superseded error, want %v", got.state, StateGitHubIssuesLoading)
+	}
+
+	newModel, _ = m.Update(githubIssuesLoadedMsg{issues: []github.Issue{{Number: 2}}, request: 2})
+	if got := newModel.(Model); got.state != StateGitHubIssuesList {
+		t.Errorf("state = %v, want %v for the current request", got.state, StateGitHubIssuesList)
+	}
+}
+
+func TestUpdate_GitHubTimeoutHint(t *testing.T) {
+	m := Model{state: StateGitHubIssuesLoading}
+
+	err := fmt.Errorf("timed out fetching issues: %w", context.DeadlineExceeded)
+	newModel, _ := m.Update(githubIssuesErrorMsg{err: err})
+	got := newModel.(Model)
+	if got.state != StateError {
+		t.Errorf("state = %v, want %v", got.state, StateError)
+	}
+	if !strings.Contains(got.errHint, "fetch_timeout_seconds") {
+		t.Errorf("errHint = %q, should mention the timeout setting", got.errHint)
+	}
+}
+
+func TestHandleWorktreeCommitsKey_ScrollStopsAtLastPage(t *testing.T) {
+	commits := make([]string, constants.MinScrollViewRows+2)
+	m := Model{state: StateWorktreeCommits, worktreeCommits: commits}
+	for range len(commits) {
+		newModel, _ := m.handleWorktreeCommitsKey(keyMsg("j"))
+		m = newModel.(Model)
+	}
+	// The last page stays full rather than scrolling down to one commit
+	if m.commitsScroll != 2 {
+		t.Errorf("commitsScroll = %d, want 2", m.commitsScroll)
+	}
+}
+
+func TestHandleContainerLogsKey_ScrollStopsAtLastPage(t *testing.T) {
+	lines := make([]string, constants.MinScrollViewRows+3)
+	m := Model{state: StateContainerLogs, logLines: lines}
+	for range len(lines) {
+		newModel, _ := m.handleContainerLogsKey(keyMsg("j"))
+		m = newModel.(Model)
+	}
+	if m.logScroll != 3 {
+		t.Errorf("logScroll = %d, want 3", m.logScroll)
+	}
+}
+
+// ============================================================================
+// Event log tests
+// ============================================================================
+
+func TestHandleEventLogKey(t *testing.T) {
+	m := Model{state: StateDashboard}
+	for i := range constants.MinScrollViewRows + 1 {
+		m.logEvent("event %d", i)
+	}
+
+	newModel, _ := m.handleDashboardKey(keyMsg("l"))
+	m = newModel.(Model)
+	if m.state != StateEventLog {
+		t.Fatalf("state = %v, want %v", m.state, StateEventLog)
+	}
+
+	newModel, _ = m.handleKeyPress(keyMsg("j"))
+	m = newModel.(Model)
+	if m.eventScroll != 1 {
+		t.Errorf("eventScroll = %d, want 1", m.eventScroll)
+	}
+	newModel, _ = m.handleKeyPress(keyMsg("j"))
+	if newModel.(Model).eventScroll != 1 {
+		t.Error("should not scroll past the last full page of events")
+	}
+
+	newModel, _ = m.handleKeyPress(keyMsg("q"))
+	if newModel.(Model).state != StateDashboard {
+		t.Errorf("state = %v, want %v", newModel.(Model).state, StateDashboard)
+	}
+}
+
+// ============================================================================
+// Auto-attach tests
+// ============================================================================
+
+func TestUpdate_AutoAttachCreatesDefaultSession(t *testing.T) {
+	m := Model{
+		state:             StateLoadingTmuxSessions,
+		selectedInstance:  &devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "alpha"}},
+		config:            &config.Config{DefaultSessionName: "main"},
+		pendingAutoAttach: true,
+	}
+
+	newModel, cmd := m.Update(tmuxSessionsLoadedMsg{sessions: []string{"other"}})
+	got := newModel.(Model)
+	if got.state != StateAttaching {
+		t.Errorf("state = %v, want %v", got.state, StateAttaching)
+	}
+	if got.pendingAutoAttach {
+		t.Error("pendingAutoAttach should be cleared")
+	}
+	if cmd == nil {
+		t.Error("should return a command to create the session")
+	}
+}
+
+func TestDefaultSessionName(t *testing.T) {
+	enabled := true
+	worktree := &devcontainer.ContainerInstance{
+		Project:  devcontainer.Project{Name: "alpha"},
+		Worktree: &devcontainer.WorktreeInfo{Branch: "release-1.2"},
+	}
+	plain := &devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "alpha"}}
+
+	tests := []struct {
+		name     string
+		instance *devcontainer.ContainerInstance
+		cfg      *config.Config
+		want     string
+	}{
+		{"disabled", worktree, &config.Config{DefaultSessionName: "main"}, "main"},
+		{"branch sanitized", worktree, &config.Config{DefaultSessionName: "main", SessionFromBranch: &enabled}, "release-1-2"},
+		{"non-worktree falls back", plain, &config.Config{DefaultSessionName: "main", SessionFromBranch: &enabled}, "main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{selectedInstance: tt.instance, config: tt.cfg}
+			if got := m.defaultSessionName(); got != tt.want {
+				t.Errorf("defaultSessionName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdate_NoAutoAttachShowsSessions(t *testing.T) {
+	m := Model{
+		state:  StateLoadingTmuxSessions,
+		config: &config.Config{DefaultSessionName: "main"},
+	}
+
+	newModel, _ := m.Update(tmuxSessionsLoadedMsg{sessions: []string{"main"}})
+	if got := newModel.(Model); got.state != StateTmuxSelect {
+		t.Errorf("state = %v, want %v", got.state, StateTmuxSelect)
+	}
+}
+
+// ============================================================================
+// Image check tests
+// ============================================================================
+
+func TestHandleDashboardKey_CheckImageRequiresRunning(t *testing.T) {
+	m := Model{
+		state: StateDashboard,
+		instancesStatus: []devcontainer.ContainerInstanceWithStatus{
+			{ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "alpha"}}, Status: devcontainer.StatusStopped},
+		},
+	}
+
+	newModel, _ := m.handleDashboardKey(keyMsg("u"))
+	if got := newModel.(Model); got.state != StateError {
+		t.Errorf("state = %v, want %v", got.state, StateError)
+	}
+
+	m.instancesStatus[0].Status = devcontainer.StatusRunning
+	newModel, cmd := m.handleDashboardKey(keyMsg("u"))
+	if got := newModel.(Model); got.state != StateCheckingImage || cmd == nil {
+		t.Errorf("state = %v, want %v with a check command", got.state, StateCheckingImage)
+	}
+}
+
+func TestUpdate_ImageChecked(t *testing.T) {
+	m := Model{state: StateCheckingImage}
+
+	newModel, _ := m.Update(imageCheckedMsg{path: "/src/alpha", status: devcontainer.ImageOutdated})
+	got := newModel.(Model)
+	if got.state != StateDashboard {
+		t.Errorf("state = %v, want %v", got.state, StateDashboard)
+	}
+	if got.imageStatus["/src/alpha"] != devcontainer.ImageOutdated {
+		t.Errorf("imageStatus = %v, want outdated for /src/alpha", got.imageStatus)
+	}
+}
+
+func TestHandleDashboardKey_GitHubWithoutCLI(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	m := Model{
+		state: StateDashboard,
+		instancesStatus: []devcontainer.ContainerInstanceWithStatus{
+			{ContainerInstance: devcontainer.ContainerInstance{
+				Project:  devcontainer.Project{Name: "alpha", Path: "/src/alpha"},
+				Worktree: &devcontainer.WorktreeInfo{Path: "/src/alpha", IsMain: true},
+			}},
+		},
+	}
+
+	newModel, cmd := m.handleDashboardKey(keyMsg("g"))
+	got := newModel.(Model)
+	if got.state != StateError {
+		t.Errorf("state = %v, want %v", got.state, StateError)
+	}
+	if !errors.Is(got.err, github.ErrCLINotFound) {
+		t.Errorf("err = %v, want ErrCLINotFound", got.err)
+	}
+	if cmd != nil {
+		t.Error("should not start loading issues")
+	}
+}
+
+func TestUpdate_GitHubNotAuthenticatedHint(t *testing.T) {
+	m := Model{state: StateGitHubIssuesLoading}
+
+	newModel, _ := m.Update(githubIssuesErrorMsg{err: github.ErrNotAuthenticated})
+	if got := newModel.(Model); !strings.Contains(got.errHint, "gh auth login") {
+		t.Errorf("errHint = %q, should suggest gh auth login", got.errHint)
+	}
+}
+
+// ============================================================================
+// Clone tests
+// ============================================================================
+
+func TestHandleDashboardKey_CloneRequiresSearchPath(t *testing.T) {
+	m := Model{state: StateDashboard, config: &config.Config{}}
+
+	newModel, _ := m.handleDashboardKey(keyMsg("C"))
+	if got := newModel.(Model); got.state != StateError {
+		t.Errorf("state = %v, want %v", got.state, StateError)
+	}
+
+	m.config.SearchPaths = []string{"/src"}
+	newModel, _ = m.handleDashboardKey(keyMsg("C"))
+	if got := newModel.(Model); got.state != StateCloneInput {
+		t.Errorf("state = %v, want %v", got.state, StateCloneInput)
+	}
+}
+
+func TestHandleCloneInputKey_InvalidURL(t *testing.T) {
+	m := Model{state: StateDashboard, config: &config.Config{SearchPaths: []string{"/src"}}}
+	newModel, _ := m.handleDashboardKey(keyMsg("C"))
+	m = newModel.(Model)
+	m.cloneInput.SetValue("not a url")
+
+	newModel, _ = m.handleCloneInputKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := newModel.(Model); got.state != StateError {
+		t.Errorf("state = %v, want %v", got.state, StateError)
+	}
+
+	// A failure that arrives after leaving the session list only flashes
+	m.state = StateDashboard
+	newModel, _ = m.Update(sessionPipedMsg{session: "main", err: errors.New("failed to pipe tmux session")})
+	if got := newModel.(Model); got.state != StateDashboard || !got.flashError || !strings.Contains(got.flash, "main") {
+		t.Errorf("state = %v, flash = %q; want an error flash on %v", got.state, got.flash, StateDashboard)
+	}
+}
+
+func TestWaitForClone(t *testing.T) {
+	updates := make(chan tea.Msg, 1)
+	updates <- cloneProgressMsg{line: "Receiving objects: 50%"}
+
+	m := Model{state: StateCloning, cloneUpdates: updates}
+	newModel, cmd := m.Update(waitForClone(updates)())
+	if got := newModel.(Model); got.cloneProgress != "Receiving objects: 50%" {
+		t.Errorf("cloneProgress = %q", got.cloneProgress)
+	}
+
+	// The returned command keeps listening; a closed channel ends the stream
+	close(updates)
+	if cmd == nil || cmd() != nil {
+		t.Error("should wait for the next update and stop when the clone finishes")
+	}
+}
+
+// ============================================================================
+// Favorites tests
+// ============================================================================
+
+func testInstances(paths ...string) []devcontainer.ContainerInstanceWithStatus {
+	var instances []devcontainer.ContainerInstanceWithStatus
+	for _, p := range paths {
+		instances = append(instances, devcontainer.ContainerInstanceWithStatus{
+			ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: p, Path: p}},
+		})
+	}
+	return instances
+}
+
+func TestHandleDashboardKey_EmptyDashboard(t *testing.T) {
+	m := Model{state: StateDashboard, config: &config.Config{}}
+
+	newModel, cmd := m.handleDashboardKey(keyMsg("R"))
+	if got := newModel.(Model); got.state != StateDiscovering {
+		t.Errorf("R: state = %v, want %v", got.state, StateDiscovering)
+	}
+	if cmd == nil {
+		t.Error("R should return a command to rediscover projects")
+	}
+
+	newModel, _ = m.handleDashboardKey(keyMsg("w"))
+	if got := newModel.(Model); got.state != StateWizardWelcome || !got.wizardFromDashboard {
+		t.Errorf("w: state = %v, want %v launched from the dashboard", got.state, StateWizardWelcome)
+	}
+}
+
+func TestSortFavoritesFirst(t *testing.T) {
+	instances := testInstances("/a", "/b", "/c", "/d")
+	sortFavoritesFirst(instances, map[string]bool{"/c": true, "/b": true})
+
+	var got []string
+	for _, inst := range instances {
+		got = append(got, inst.Path)
+	}
+	if strings.Join(got, ",") != "/b,/c,/a,/d" {
+		t.Errorf("order = %v, want favorites first in original order", got)
+	}
+}
+
+func TestGroupWorktrees(t *testing.T) {
+	instances := testInstances("/repo-feat", "/other", "/repo", "/repo-fix")
+	for _, i := range []int{0, 2, 3} {
+		instances[i].Worktree = &devcontainer.WorktreeInfo{MainRepo: "/repo", IsMain: instances[i].Path == "/repo"}
+	}
+	groupWorktrees(instances)
+
+	var got []string
+	for _, inst := range instances {
+		got = append(got, inst.Path)
+	}
+	if strings.Join(got, ",") != "/repo,/repo-feat,/repo-fix,/other" {
+		t.Errorf("order = %v, want main worktree first and group kept at its first position", got)
+	}
+}
+
+func TestHandleDashboardKey_FoldWorktreeGroup(t *testing.T) {
+	grouped := true
+	instances := testInstances("/repo", "/repo-feat", "/repo-fix", "/other")
+	for _, i := range []int{0, 1, 2} {
+		instances[i].Worktree = &devcontainer.WorktreeInfo{MainRepo: "/repo", IsMain: i == 0}
+	}
+	m := Model{
+		state:           StateDashboard,
+		config:          &config.Config{GroupWorktrees: &grouped},
+		instancesStatus: instances,
+		cursor:          1,
+	}
+
+	newModel, _ := m.handleDashboardKey(keyMsg("z"))
+	got := newModel.(Model)
+	if !got.collapsedGroups["/repo"] || got.cursor != 0 {
+		t.Fatalf("z should fold the group onto its first row, got collapsed %v cursor %d", got.collapsedGroups, got.cursor)
+	}
+
+	// Navigation skips the hidden members
+	newModel, _ = got.handleDashboardKey(keyMsg("j"))
+	got = newModel.(Model)
+	if got.cursor != 3 {
+		t.Errorf("down from a folded group: cursor = %d, want 3", got.cursor)
+	}
+	newModel, _ = got.handleDashboardKey(keyMsg("k"))
+	got = newModel.(Model)
+	if got.cursor != 0 {
+		t.Errorf("up onto a folded group: cursor = %d, want 0", got.cursor)
+	}
+
+	// Enter unfolds instead of connecting
+	newModel, _ = got.handleDashboardKey(tea.KeyMsg{Type: tea.KeyEnter})
+	got = newModel.(Model)
+	if got.collapsedGroups["/repo"] || got.state != StateDashboard || got.selectedInstance != nil {
+		t.Errorf("enter on a folded group should unfold it, got collapsed %v state %v", got.collapsedGroups, got.state)
+	}
+	newModel, _ = got.handleDashboardKey(keyMsg("j"))
+	if got := newModel.(Model); got.cursor != 1 {
+		t.Errorf("down in an unfolded group: cursor = %d, want 1", got.cursor)
+	}
+}
+
+func TestHandleDashboardKey_FoldNeedsGroup(t *testing.T) {
+	grouped := true
+	m := Model{
+		state:           StateDashboard,
+		config:          &config.Config{GroupWorktrees: &grouped},
+		instancesStatus: testInstances("/a", "/b"),
+	}
+
+	newModel, _ := m.handleDashboardKey(keyMsg("z"))
+	got := newModel.(Model)
+	if got.collapsedGroups != nil || got.flash == "" {
+		t.Errorf("z outside a group should only flash a hint, got collapsed %v flash %q", got.collapsedGroups, got.flash)
+	}
+}
+
+func TestHandleDashboardKey_CycleVisibility(t *testing.T) {
+	instances := testInstances("/alpha", "/beta", "/gamma", "/delta")
+	instances[0].Status = devcontainer.StatusRunning
+	instances[1].Status = devcontainer.StatusStopped
+	instances[2].Status = devcontainer.StatusUnknown
+	instances[3].Status = devcontainer.StatusRunning
+	m := Model{state: StateDashboard, config: &config.Config{}, instancesStatus: instances, cursor: 2}
+
+	// running+stopped hides the never-started instance under the cursor
+	newModel, _ := m.handleDashboardKey(keyMsg("F"))
+	got := newModel.(Model)
+	if got.visibility != showStartedInstances || got.cursor != 1 {
+		t.Fatalf("F: visibility %v cursor %d, want running+stopped with the cursor on 1", got.visibility, got.cursor)
+	}
+	if got = got.moveDashboardCursor(1); got.cursor != 3 {
+		t.Errorf("down: cursor = %d, want 3 (skipping the hidden instance)", got.cursor)
+	}
+
+	newModel, _ = got.handleDashboardKey(keyMsg("F"))
+	if got = newModel.(Model); got.visibility != showRunningInstances || got.cursor != 3 {
+		t.Errorf("F again: visibility %v cursor %d, want running only with the cursor kept", got.visibility, got.cursor)
+	}
+	if view := got.View(); strings.Contains(view, "/beta") || !strings.Contains(view, "/delta") || !strings.Contains(view, "show: running only") {
+		t.Errorf("running only should list just running instances and name the mode:\n%s", view)
+	}
+
+	newModel, _ = got.handleDashboardKey(keyMsg("F"))
+	if got = newModel.(Model); got.visibility != showAllInstances {
+		t.Errorf("F a third time: visibility %v, want all", got.visibility)
+	}
+}
+
+func TestHandleDashboardKey_AllInstancesHidden(t *testing.T) {
+	m := Model{state: StateDashboard, config: &config.Config{}, instancesStatus: testInstances("/a"), visibility: showRunningInstances}
+
+	// Nothing is listed, so instance actions do nothing
+	newModel, cmd := m.handleDashboardKey(keyMsg("enter"))
+	if got := newModel.(Model); got.state != StateDashboard || cmd != nil || got.selectedInstance != nil {
+		t.Errorf("enter with every instance hidden: state %v, want nothing to happen", got.state)
+	}
+	if view := m.View(); !strings.Contains(view, "No instances match") {
+		t.Errorf("expected a note that the filter hides everything:\n%s", view)
+	}
+
+	newModel, _ = m.handleDashboardKey(keyMsg("F"))
+	if got := newModel.(Model); got.visibility != showAllInstances {
+		t.Errorf("F should still cycle the filter, got %v", got.visibility)
+	}
+}
+
+func TestHandleDashboardKey_JumpToProject(t *testing.T) {
+	m := Model{
+		state:           StateDashboard,
+		config:          &config.Config{},
+		instancesStatus: testInstances("api", "web", "Admin", "worker"),
+	}
+
+	jump := func(m Model, letter string) Model {
+		t.Helper()
+		newModel, _ := m.handleDashboardKey(keyMsg("f"))
+		if got := newModel.(Model); !got.jumpPending {
+			t.Fatalf("f should wait for a letter")
+		}
+		newModel, _ = newModel.(Model).handleDashboardKey(keyMsg(letter))
+		return newModel.(Model)
+	}
+
+	got := jump(m, "w")
+	if got.cursor != 1 || got.jumpPending {
+		t.Fatalf("f w: cursor = %d, want 1 (web)", got.cursor)
+	}
+	if got = jump(got, "w"); got.cursor != 3 {
+		t.Errorf("f w again: cursor = %d, want 3 (worker)", got.cursor)
+	}
+	if got = jump(got, "a"); got.cursor != 0 {
+		t.Errorf("f a from the last row: cursor = %d, want 0 (wrapped to api)", got.cursor)
+	}
+	if got = jump(got, "a"); got.cursor != 2 {
+		t.Errorf("f a again: cursor = %d, want 2 (Admin, case-insensitive)", got.cursor)
+	}
+	if got = jump(got, "z"); got.cursor != 2 || got.flash == "" {
+		t.Errorf("f z with no match: cursor = %d flash %q, want the cursor kept and a hint", got.cursor, got.flash)
+	}
+
+	// The letter after f is not run as an action
+	newModel, _ := m.handleDashboardKey(keyMsg("f"))
+	newModel, _ = newModel.(Model).handleDashboardKey(keyMsg("q"))
+	if got := newModel.(Model); got.jumpPending || got.state != StateDashboard {
+		t.Errorf("f q should not quit, got state %v", got.state)
+	}
+}
+
+func TestHandleDashboardKey_ToggleFavoriteKeepsCursor(t *testing.T) {
+	m := Model{
+		state:           StateDashboard,
+		config:          &config.Config{},
+		instancesStatus: testInstances("/a", "/b", "/c"),
+		cursor:          2,
+	}
+
+	newModel, cmd := m.handleDashboardKey(keyMsg("*"))
+	got := newModel.(Model)
+	if got.instancesStatus[0].Path != "/c" {
+		t.Errorf("first instance = %q, want /c", got.instancesStatus[0].Path)
+	}
+	if got.cursor != 0 {
+		t.Errorf("cursor = %d, want 0 (following /c)", got.cursor)
+	}
+	if !got.config.IsFavorite("/c") {
+		t.Error("/c should be a favorite")
+	}
+	if cmd == nil {
+		t.Error("should return a command to save favorites")
+	}
+}
+
+// ============================================================================
+// Auth warning panel tests
+// ============================================================================
+
+func TestContainerStarted_AuthFailuresShowPanel(t *testing.T) {
+	instance := testInstances("/a")[0].ContainerInstance
+	failures := []auth.CredentialError{
+		{Credential: auth.Credential{Name: "GITHUB_TOKEN", Source: auth.SourceEnv, Value: "GH_TOKEN"}, Err: errors.New("environment variable GH_TOKEN is not set")},
+	}
+
+	m := Model{state: StateContainerStarting, selectedInstance: &instance}
+	newModel, cmd := m.Update(containerStartedMsg{path: instance.Path, authFailures: failures})
+	got := newModel.(Model)
+	if got.state != StateAuthWarning {
+		t.Fatalf("state = %v, want %v", got.state, StateAuthWarning)
+	}
+	if cmd != nil {
+		t.Error("should wait for the panel to be dismissed before loading sessions")
+	}
+	view := got.View()
+	for _, want := range []string{"GITHUB_TOKEN", "env: GH_TOKEN", "is not set"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("panel should contain %q", want)
+		}
+	}
+
+	newModel, cmd = got.handleAuthWarningKey(tea.KeyMsg{Type: tea.KeyEnter})
+	got = newModel.(Model)
+	if got.state != StateLoadingTmuxSessions || cmd == nil {
+		t.Errorf("enter should continue to loading tmux sessions, got state %v", got.state)
+	}
+	if got.authFailures != nil {
+		t.Error("dismissing the panel should clear the failures")
+	}
+}
+
+func TestContainerStarted_NoAuthFailuresSkipsPanel(t *testing.T) {
+	instance := testInstances("/a")[0].ContainerInstance
+	m := Model{state: StateContainerStarting, selectedInstance: &instance}
+
+	newModel, _ := m.Update(containerStartedMsg{path: "/a"})
+	if got := newModel.(Model).state; got != StateLoadingTmuxSessions {
+		t.Errorf("state = %v, want %v", got, StateLoadingTmuxSessions)
+	}
+}
+
+func TestContainerStarted_JoinsWorkspaceWarning(t *testing.T) {
+	instance := testInstances("/a")[0].ContainerInstance
+	m := Model{state: StateContainerStarting, selectedInstance: &instance}
+
+	newModel, _ := m.Update(containerStartedMsg{path: "/a", authWarning: "auth", workspaceWarning: "workspace"})
+	got := newModel.(Model)
+	if got.state != StateLoadingTmuxSessions {
+		t.Errorf("a workspace mismatch should not stop the start, got state %v", got.state)
+	}
+	if got.warning != "auth; workspace" {
+		t.Errorf("warning = %q, want both warnings", got.warning)
+	}
+}
+
+// ============================================================================
+// Error view tests
+// ============================================================================
+
+func TestHandleErrorKey_ScrollsLongErrors(t *testing.T) {
+	m := Model{
+		state:  StateError,
+		err:    fmt.Errorf("%slast", strings.Repeat("line\n", 30)),
+		width:  60,
+		height: 20,
+	}
+
+	newModel, _ := m.handleErrorKey(keyMsg("j"))
+	got := newModel.(Model)
+	if got.state != StateError || got.errScroll != 1 {
+		t.Fatalf("down should scroll a long error, got state %v scroll %d", got.state, got.errScroll)
+	}
+
+	newModel, _ = got.handleErrorKey(tea.KeyMsg{Type: tea.KeyEnter})
+	got = newModel.(Model)
+	if got.state != StateDashboard || got.errScroll != 0 || got.err != nil {
+		t.Errorf("other keys should dismiss the error and reset scroll, got state %v", got.state)
+	}
+}
+
+func TestHandleErrorKey_ShortErrorDismissesOnAnyKey(t *testing.T) {
+	m := Model{state: StateError, err: errors.New("boom"), width: 60, height: 40}
+
+	newModel, _ := m.handleErrorKey(keyMsg("j"))
+	if got := newModel.(Model).state; got != StateDashboard {
+		t.Errorf("state = %v, want %v", got, StateDashboard)
+	}
+}
+
+// ============================================================================
+// Container logs tests
+// ============================================================================
+
+func TestPagerCommand(t *testing.T) {
+	t.Setenv("PAGER", "cat -v")
+	if got := pagerCommand(); strings.Join(got, " ") != "cat -v" {
+		t.Errorf("pagerCommand() = %v, want $PAGER split into arguments", got)
+	}
+
+	t.Setenv("PAGER", "no-such-pager-binary")
+	t.Setenv("PATH", t.TempDir())
+	if got := pagerCommand(); got != nil {
+		t.Errorf("pagerCommand() = %v, want nil when no pager is installed", got)
+	}
+}
+
+func TestContainerLogsLoaded_InTUIViewer(t *testing.T) {
+	logs := strings.Repeat("line\n", 40) + "latest\n"
+	m := Model{state: StateContainerLogsLoading, height: 20}
+
+	newModel, cmd := m.Update(containerLogsLoadedMsg{logs: logs})
+	got := newModel.(Model)
+	if got.state != StateContainerLogs || cmd != nil {
+		t.Fatalf("without a pager logs should open in the viewer, got state %v", got.state)
+	}
+	if len(got.logLines) != 41 {
+		t.Errorf("len(logLines) = %d, want 41", len(got.logLines))
+	}
+	if !strings.Contains(got.View(), "latest") {
+		t.Error("viewer should start scrolled to the most recent output")
+	}
+
+	newModel, _ = got.handleContainerLogsKey(keyMsg("q"))
+	if got := newModel.(Model); got.state != StateDashboard || got.logLines != nil {
+		t.Errorf("q should return to the dashboard and clear logs, got state %v", got.state)
+	}
+}
+
+func TestContainerLogsLoaded_Pager(t *testing.T) {
+	m := Model{state: StateContainerLogsLoading}
+
+	_, cmd := m.Update(containerLogsLoadedMsg{logs: "hello\n", pager: []string{"less"}})
+	if cmd == nil {
+		t.Fatal("with a pager the logs should be opened in it")
+	}
+
+	newModel, _ := m.Update(pagerClosedMsg{})
+	if got := newModel.(Model).state; got != StateDashboard {
+		t.Errorf("state after pager exit = %v, want %v", got, StateDashboard)
+	}
+}
+
+func TestHandleDashboardKey_Shell(t *testing.T) {
+	instances := testInstances("/a")
+	m := Model{state: StateDashboard, config: &config.Config{}, instancesStatus: instances}
+
+	// A stopped container has nothing to open a shell in
+	newModel, _ := m.handleDashboardKey(keyMsg("s"))
+	if got := newModel.(Model); got.state != StateDashboard || !strings.Contains(got.flash, "Start the container") {
+		t.Errorf("s on a stopped container: state %v, flash %q; want a flash on the dashboard", got.state, got.flash)
+	}
+
+	m.instancesStatus[0].Status = devcontainer.StatusRunning
+	newModel, cmd := m.handleDashboardKey(keyMsg("s"))
+	if got := newModel.(Model); got.state != StateAttaching || cmd == nil {
+		t.Errorf("s on a running container: state %v, want %v with a command", got.state, StateAttaching)
+	}
+}
+
+func TestUpdate_ShellExited(t *testing.T) {
+	instance := testInstances("/a")[0].ContainerInstance
+	m := Model{state: StateAttaching, selectedInstance: &instance}
+
+	// The shell's own exit status is not an error
+	exitErr := exec.Command("sh", "-c", "exit 3").Run()
+	newModel, _ := m.Update(shellExitedMsg{err: exitErr, returnTo: StateTmuxSelect})
+	if got := newModel.(Model); got.state != StateTmuxSelect || got.selectedInstance == nil {
+		t.Errorf("state after shell exit = %v, want %v with the instance kept", got.state, StateTmuxSelect)
+	}
+
+	newModel, _ = m.Update(shellExitedMsg{returnTo: StateDashboard})
+	if got := newModel.(Model); got.state != StateDashboard || got.selectedInstance != nil {
+		t.Errorf("state after shell exit = %v, want %v with the selection cleared", got.state, StateDashboard)
+	}
+
+	// 127: not even the fallback shell could be run
+	noShell := exec.Command("sh", "-c", "exit 127").Run()
+	newModel, _ = m.Update(shellExitedMsg{err: noShell, returnTo: StateDashboard})
+	if got := newModel.(Model); got.state != StateError || !strings.Contains(got.errHint, "container_shell") {
+		t.Errorf("state = %v, hint %q; want an error pointing at container_shell", got.state, got.errHint)
+	}
+}
+
+func TestUpdate_InstancesDiscoveredDiff(t *testing.T) {
+	var instances []devcontainer.ContainerInstance
+	for _, instance := range testInstances("/a", "/c") {
+		instances = append(instances, instance.ContainerInstance)
+	}
+
+	// The first run ever has nothing to compare with
+	newModel, _ := Model{}.Update(instancesDiscoveredMsg{instances: instances, compared: true})
+	if got := newModel.(Model); got.newInstances != nil || got.warning != "" {
+		t.Errorf("first run: new = %v, warning %q; want neither", got.newInstances, got.warning)
+	}
+
+	previous := map[string]bool{"/a": true, "/b": true}
+	newModel, _ = Model{}.Update(instancesDiscoveredMsg{instances: instances, previous: previous, compared: true})
+	got := newModel.(Model)
+	if !reflect.DeepEqual(got.newInstances, map[string]bool{"/c": true}) {
+		t.Errorf("newInstances = %v, want /c", got.newInstances)
+	}
+	if got.warning != "Gone since last run: /b" {
+		t.Errorf("warning = %q, want the removed instance", got.warning)
+	}
+	if !got.snapshotTaken {
+		t.Error("the compared run should mark the snapshot as taken")
+	}
+
+	// Later discoveries in the same process keep the first run's diff
+	got.state = StateDashboard
+	newModel, _ = got.Update(instancesDiscoveredMsg{instances: instances})
+	got = newModel.(Model)
+	if !reflect.DeepEqual(got.newInstances, map[string]bool{"/c": true}) || got.warning != "Gone since last run: /b" {
+		t.Errorf("rediscovery: new = %v, warning %q; want the first run's diff unchanged", got.newInstances, got.warning)
+	}
+}
+
+func TestDiscoverInstances_SavesSnapshotOnce(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	searchDir := t.TempDir()
+	m := Model{config: &config.Config{SearchPaths: []string{searchDir}, MaxDepth: 1}}
+
+	msg := m.discoverInstances()().(instancesDiscoveredMsg)
+	if !msg.compared {
+		t.Fatal("the first discovery should compare with and save the snapshot")
+	}
+	if _, ok := config.LoadDiscoverySnapshot(m.config.SearchPaths); !ok {
+		t.Error("the first discovery should save a snapshot")
+	}
+
+	m.snapshotTaken = true
+	if msg := m.discoverInstances()().(instancesDiscoveredMsg); msg.compared || msg.previous != nil {
+		t.Error("later discoveries should leave the snapshot alone")
+	}
+}
+
+// ============================================================================
+// Preset session tests
+// ============================================================================
+
+func TestHandleTmuxSelectKey_CreatesPreset(t *testing.T) {
+	instance := testInstances("/a")[0].ContainerInstance
+	m := Model{
+		state:            StateTmuxSelect,
+		config:           &config.Config{PresetSessions: []string{"main", "logs"}},
+		selectedInstance: &instance,
+		tmuxSessions:     []tmux.Session{{Name: "main"}},
+		textInput:        textinput.New(),
+		cursor:           1,
+	}
+
+	newModel, cmd := m.handleTmuxSelectKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := newModel.(Model).state; got != StateAttaching || cmd == nil {
+		t.Errorf("enter on a preset should create it, got state %v", got)
+	}
+
+	m.cursor = 2
+	newModel, _ = m.handleTmuxSelectKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := newModel.(Model).state; got != StateNewSessionInput {
+		t.Errorf("enter on New Session should prompt for a name, got state %v", got)
+	}
+}
+
+func TestNewSessionPrefill(t *testing.T) {
+	tests := []struct {
+		name     string
+		worktree *devcontainer.WorktreeInfo
+		running  []tmux.Session
+		want     string
+	}{
+		{"main repo keeps the default", &devcontainer.WorktreeInfo{Branch: "main", IsMain: true}, nil, ""},
+		{"not a git repo", nil, nil, ""},
+		{"worktree branch", &devcontainer.WorktreeInfo{Branch: "feature/login"}, nil, "feature/login"},
+		{"dots and colons made safe", &devcontainer.WorktreeInfo{Branch: "release-1.2:hotfix"}, nil, "release-1-2-hotfix"},
+		{"nothing left after sanitizing", &devcontainer.WorktreeInfo{Branch: "..."}, nil, ""},
+		{"control characters keep the default", &devcontainer.WorktreeInfo{Branch: "bad\x07name"}, nil, ""},
+		{"already running", &devcontainer.WorktreeInfo{Branch: "fix-bug"}, []tmux.Session{{Name: "fix-bug"}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := devcontainer.ContainerInstance{Project: devcontainer.Project{Path: "/a"}, Worktree: tt.worktree}
+			m := Model{selectedInstance: &instance, tmuxSessions: tt.running}
+			if got := m.newSessionPrefill(); got != tt.want {
+				t.Errorf("newSessionPrefill() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleTmuxSelectKey_PrefillsBranchSessionName(t *testing.T) {
+	instance := devcontainer.ContainerInstance{
+		Project:  devcontainer.Project{Path: "/a"},
+		Worktree: &devcontainer.WorktreeInfo{Branch: "feature-x"},
+	}
+	m := Model{
+		state:            StateTmuxSelect,
+		config:           &config.Config{DefaultSessionName: "main"},
+		selectedInstance: &instance,
+		textInput:        textinput.New(),
+	}
+
+	newModel, _ := m.handleTmuxSelectKey(tea.KeyMsg{Type: tea.KeyEnter})
+	model := newModel.(Model)
+	if model.state != StateNewSessionInput || model.textInput.Value() != "feature-x" {
+		t.Errorf("state = %v, input = %q, want %v prefilled with the branch", model.state, model.textInput.Value(), StateNewSessionInput)
+	}
+}
+
+// ============================================================================
+// Warning banner tests
+// ============================================================================
+
+func TestHandleDashboardKey_Warning(t *testing.T) {
+	m := Model{state: StateDashboard, warning: "push failed"}
+
+	newModel, _ := m.handleDashboardKey(keyMsg("W"))
+	if got := newModel.(Model); got.state != StateWarningDetail {
+		t.Errorf("W: state = %v, want %v", got.state, StateWarningDetail)
+	}
+
+	newModel, _ = m.handleDashboardKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if got := newModel.(Model); got.warning != "" || got.state != StateDashboard {
+		t.Errorf("esc: warning = %q, state = %v; want cleared on the dashboard", got.warning, got.state)
+	}
+
+	m.warning = ""
+	newModel, _ = m.handleDashboardKey(keyMsg("W"))
+	if got := newModel.(Model); got.state != StateDashboard {
+		t.Errorf("W without a warning: state = %v, want %v", got.state, StateDashboard)
+	}
+}
+
+func TestHandleWarningDetailKey(t *testing.T) {
+	m := Model{state: StateWarningDetail, warning: "a; b", warningScroll: 1}
+
+	newModel, _ := m.handleWarningDetailKey(keyMsg("q"))
+	if got := newModel.(Model); got.state != StateDashboard || got.warning != "a; b" || got.warningScroll != 0 {
+		t.Errorf("q: state = %v, warning = %q, scroll = %d; want dashboard with warning kept", got.state, got.warning, got.warningScroll)
+	}
+
+	newModel, _ = m.handleWarningDetailKey(keyMsg("d"))
+	if got := newModel.(Model); got.state != StateDashboard || got.warning != "" {
+		t.Errorf("d: state = %v, warning = %q; want dashboard with warning cleared", got.state, got.warning)
+	}
+}
+
+// ============================================================================
+// Discard worktree tests
+// ============================================================================
+
+func TestHandleDashboardKey_DiscardWorktreeGuards(t *testing.T) {
+	instances := testInstances("/src/app", "/src/plain")
+	instances[0].Worktree = &devcontainer.WorktreeInfo{Branch: "main", IsMain: true}
+
+	for i, want := range []string{"cannot delete the main worktree", "not a git worktree"} {
+		m := Model{state: StateDashboard, instancesStatus: instances, cursor: i, textInput: textinput.New()}
+		newModel, _ := m.handleDashboardKey(keyMsg("D"))
+		got := newModel.(Model)
+		if got.state != StateError || got.err == nil || !strings.Contains(got.err.Error(), want) {
+			t.Errorf("cursor %d: state = %v, err = %v; want error containing %q", i, got.state, got.err, want)
+		}
+	}
+}
+
+func TestHandleConfirmDiscardWorktreeKey(t *testing.T) {
+	instances := testInstances("/src/app-feature")
+	instances[0].Worktree = &devcontainer.WorktreeInfo{Branch: "feature", MainRepo: "/src/app"}
+	m := Model{state: StateDashboard, instancesStatus: instances, textInput: textinput.New()}
+
+	newModel, _ := m.handleDashboardKey(keyMsg("D"))
+	m = newModel.(Model)
+	if m.state != StateConfirmDiscardWorktree {
+		t.Fatalf("state = %v, want %v", m.state, StateConfirmDiscardWorktree)
+	}
+
+	// A wrong name doesn't confirm
+	m.textInput.SetValue("feat")
+	newModel, cmd := m.handleConfirmDiscardWorktreeKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := newModel.(Model); got.state != StateConfirmDiscardWorktree || cmd != nil {
+		t.Errorf("wrong name: state = %v, want to stay on the confirmation", got.state)
+	}
+
+	m.textInput.SetValue("feature")
+	newModel, cmd = m.handleConfirmDiscardWorktreeKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := newModel.(Model); got.state != StateDiscardingWorktree || cmd == nil {
+		t.Errorf("branch name typed: state = %v, want %v with a command", got.state, StateDiscardingWorktree)
+	}
+
+	newModel, _ = m.handleConfirmDiscardWorktreeKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if got := newModel.(Model); got.state != StateDashboard || got.selectedInstance != nil {
+		t.Errorf("esc: state = %v, want %v with no selection", got.state, StateDashboard)
+	}
+}
+
+// ============================================================================
+// Session count refresh tests
+// ============================================================================
+
+func TestUpdate_TmuxDetachedRefreshesOneInstance(t *testing.T) {
+	instances := testInstances("/a", "/b")
+	m := Model{
+		state:            StateAttaching,
+		instancesStatus:  instances,
+		selectedInstance: &instances[1].ContainerInstance,
+		cursor:           3, // Session list cursor
+	}
+
+	newModel, cmd := m.Update(tmuxDetachedMsg{})
+	got := newModel.(Model)
+	if got.state != StateDashboard {
+		t.Errorf("state = %v, want %v", got.state, StateDashboard)
+	}
+	if got.cursor != 1 {
+		t.Errorf("cursor = %d, want 1 (the detached instance)", got.cursor)
+	}
+	if got.selectedInstance != nil {
+		t.Error("selectedInstance should be cleared")
+	}
+	if cmd == nil {
+		t.Error("should return a command to refresh the instance")
+	}
+
+	// The instance was started for this attach, so its status changes too
+	newModel, _ = got.Update(instanceRefreshedMsg{path: "/b", status: devcontainer.StatusRunning, count: 2})
+	got = newModel.(Model)
+	if got.instancesStatus[1].SessionCount != 2 || got.instancesStatus[0].SessionCount != 0 {
+		t.Errorf("session counts = %d, %d; want 0, 2", got.instancesStatus[0].SessionCount, got.instancesStatus[1].SessionCount)
+	}
+	if got.instancesStatus[1].Status != devcontainer.StatusRunning || got.instancesStatus[0].Status == devcontainer.StatusRunning {
+		t.Errorf("statuses = %v, %v; want only /b running", got.instancesStatus[0].Status, got.instancesStatus[1].Status)
+	}
+}
+
+func TestUpdate_TmuxDetachedRefreshesStatusWhenCountsHidden(t *testing.T) {
+	instances := testInstances("/a", "/b")
+	hide := false
+	m := Model{
+		state:            StateAttaching,
+		config:           &config.Config{ShowSessionCounts: &hide},
+		instancesStatus:  instances,
+		selectedInstance: &instances[1].ContainerInstance,
+	}
+
+	newModel, cmd := m.Update(tmuxDetachedMsg{})
+	if got := newModel.(Model); got.state != StateDashboard || got.cursor != 1 {
+		t.Errorf("state = %v, cursor = %d; want %v, 1", got.state, got.cursor, StateDashboard)
+	}
+	if cmd == nil {
+		t.Error("should still refresh the instance's status when show_session_counts is false")
+	}
+
+	// Uncounted sessions leave the count alone
+	newModel, _ = newModel.Update(instanceRefreshedMsg{path: "/b", status: devcontainer.StatusRunning, count: -1})
+	if got := newModel.(Model).instancesStatus[1]; got.Status != devcontainer.StatusRunning || got.SessionCount != 0 {
+		t.Errorf("instance = %v with %d sessions, want running with the count untouched", got.Status, got.SessionCount)
+	}
+}
+
+func TestUpdate_FocusRefreshesStaleStatus(t *testing.T) {
+	instances := testInstances("/a")
+	newModel := func(state State, seconds int, last time.Time) Model {
+		return Model{
+			state:           state,
+			config:          &config.Config{FocusRefresh: seconds},
+			instances:       []devcontainer.ContainerInstance{instances[0].ContainerInstance},
+			instancesStatus: instances,
+			lastRefresh:     last,
+		}
+	}
+	stale := time.Now().Add(-time.Minute)
+
+	updated, cmd := newModel(StateDashboard, 30, stale).Update(tea.FocusMsg{})
+	if got := updated.(Model); got.state != StateRefreshingStatus || cmd == nil {
+		t.Errorf("stale status on focus: state = %v, want %v with a refresh", got.state, StateRefreshingStatus)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		state   State
+		seconds int
+		last    time.Time
+	}{
+		{"fresh status", StateDashboard, 30, time.Now()},
+		{"option off", StateDashboard, 0, stale},
+		{"confirm dialog open", StateConfirmStop, 30, stale},
+		{"typing a branch name", StateNewWorktreeInput, 30, stale},
+	} {
+		updated, cmd := newModel(tc.state, tc.seconds, tc.last).Update(tea.FocusMsg{})
+		if got := updated.(Model); got.state != tc.state || cmd != nil {
+			t.Errorf("%s: state = %v, want %v and no refresh", tc.name, got.state, tc.state)
+		}
+	}
+}
+
+func TestUpdate_StatusRefreshRecordsTime(t *testing.T) {
+	m := Model{state: StateRefreshingStatus}
+	newModel, _ := m.Update(instanceStatusRefreshedMsg{statuses: testInstances("/a")})
+	if got := newModel.(Model); time.Since(got.lastRefresh) > time.Minute {
+		t.Errorf("lastRefresh = %v, want it set on refresh", got.lastRefresh)
+	}
+}
+
+// ============================================================================
+// Launch command tests
+// ============================================================================
+
+func TestResolveLaunchCommand_PostAttach(t *testing.T) {
+	enabled := true
+	instance := &devcontainer.ContainerInstance{
+		Project:           devcontainer.Project{Name: "app"},
+		PostAttachCommand: "npm run dev",
+	}
+
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		want string
+	}{
+		{"disabled", &config.Config{}, ""},
+		{"enabled", &config.Config{UsePostAttach: &enabled}, "npm run dev"},
+		{"launch_command wins", &config.Config{UsePostAttach: &enabled, LaunchCommand: "claude"}, "claude"},
+		{
+			"project override wins",
+			&config.Config{UsePostAttach: &enabled, Auth: auth.Config{Projects: map[string]auth.ProjectAuth{"app": {LaunchCommand: "make"}}}},
+			"make",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{config: tt.cfg, selectedInstance: instance}
+			if got := m.resolveLaunchCommand(); got != tt.want {
+				t.Errorf("resolveLaunchCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// Move worktree tests
+// ============================================================================
+
+func TestHandleMoveWorktreeInputKey(t *testing.T) {
+	instances := testInstances("/src/app-feature")
+	instances[0].Worktree = &devcontainer.WorktreeInfo{Branch: "feature", MainRepo: "/src/app"}
+	m := Model{state: StateDashboard, instancesStatus: instances, textInput: textinput.New()}
+
+	newModel, _ := m.handleDashboardKey(keyMsg("m"))
+	m = newModel.(Model)
+	if m.state != StateMoveWorktreeInput || m.textInput.Value() != "/src/app-feature" {
+		t.Fatalf("state = %v, input = %q; want move input prefilled with the current path", m.state, m.textInput.Value())
+	}
+
+	// Unchanged path goes back without moving
+	newModel, cmd := m.handleMoveWorktreeInputKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := newModel.(Model); got.state != StateDashboard || cmd != nil {
+		t.Errorf("same path: state = %v, want %v with no command", got.state, StateDashboard)
+	}
+
+	m.textInput.SetValue("/src/worktrees/feature")
+	newModel, cmd = m.handleMoveWorktreeInputKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := newModel.(Model); got.state != StateMovingWorktree || cmd == nil {
+		t.Errorf("new path: state = %v, want %v with a command", got.state, StateMovingWorktree)
+	}
+}
+
+func TestUpdate_WorktreeMovedRestartsRunningContainer(t *testing.T) {
+	for _, restart := range []bool{false, true} {
+		m := Model{state: StateMovingWorktree, config: &config.Config{}}
+		newModel, cmd := m.Update(worktreeMovedMsg{path: "/src/worktrees/feature", restart: restart})
+		got := newModel.(Model)
+		if got.state != StateDiscovering || cmd == nil {
+			t.Errorf("restart=%v: state = %v, want %v with a command", restart, got.state, StateDiscovering)
+		}
+		if got.pendingAutoStart != restart {
+			t.Errorf("restart=%v: pendingAutoStart = %v", restart, got.pendingAutoStart)
+		}
+	}
+}
+
+// ============================================================================
+// Restart confirm tests
+// ============================================================================
+
+func TestHandleDashboardKey_RestartFetchesSessionsWhenRunning(t *testing.T) {
+	instances := testInstances("/a", "/b")
+	instances[1].Status = devcontainer.StatusRunning
+	m := Model{state: StateDashboard, instancesStatus: instances}
+
+	// Stopped container: nothing to interrupt, confirm straight away
+	newModel, cmd := m.handleDashboardKey(keyMsg("r"))
+	if got := newModel.(Model); got.state != StateConfirmRestart || cmd != nil {
+		t.Errorf("stopped: state = %v, want %v with no command", got.state, StateConfirmRestart)
+	}
+
+	// Running container: fetch sessions first
+	m.cursor = 1
+	newModel, cmd = m.handleDashboardKey(keyMsg("r"))
+	got := newModel.(Model)
+	if got.state != StateDashboard || cmd == nil {
+		t.Fatalf("running: state = %v, want %v with a fetch command", got.state, StateDashboard)
+	}
+
+	newModel, _ = got.Update(restartSessionsLoadedMsg{path: "/b", sessions: []string{"main:1", "dev:0"}})
+	got = newModel.(Model)
+	if got.state != StateConfirmRestart {
+		t.Errorf("state = %v, want %v", got.state, StateConfirmRestart)
+	}
+	if len(got.restartSessions) != 2 || got.restartSessions[0].Name != "main" {
+		t.Errorf("restartSessions = %+v, want main and dev", got.restartSessions)
+	}
+}
+
+func TestUpdate_RestartSessionsLoadedIgnoredAfterCancel(t *testing.T) {
+	instances := testInstances("/a", "/b")
+	m := Model{state: StateDashboard, instancesStatus: instances, selectedInstance: &instances[0].ContainerInstance}
+
+	newModel, _ := m.Update(restartSessionsLoadedMsg{path: "/b", sessions: []string{"main:1"}})
+	if got := newModel.(Model); got.state != StateDashboard || got.restartSessions != nil {
+		t.Errorf("stale result for another instance should be ignored, got state %v", got.state)
+	}
+}
+
+// ============================================================================
+// Container start cancellation tests
+// ============================================================================
+
+func TestHandleContainerStartingKey_CancelReturnsToDashboard(t *testing.T) {
+	for _, key := range []tea.KeyMsg{{Type: tea.KeyEsc}, {Type: tea.KeyCtrlC}} {
+		instances := testInstances("/a")
+		m := Model{
+			state:             StateContainerStarting,
+			instancesStatus:   instances,
+			selectedInstance:  &instances[0].ContainerInstance,
+			pendingAutoAttach: true,
+			config:            &config.Config{},
+		}
+		m.beginContainerStart()
+		ctx := m.startCtx
+
+		newModel, _ := m.handleKeyPress(key)
+		got := newModel.(Model)
+		if got.state != StateDashboard {
+			t.Errorf("%s: state = %v, want %v", key, got.state, StateDashboard)
+		}
+		if ctx.Err() == nil {
+			t.Errorf("%s: start context should be cancelled", key)
+		}
+		if got.startCancel != nil || got.pendingAutoAttach || got.selectedInstance != nil {
+			t.Errorf("%s: start state should be cleared", key)
+		}
+
+		// A start that completes after cancelling must not continue to tmux
+		newModel, cmd := got.Update(containerStartedMsg{path: "/a"})
+		if got := newModel.(Model); got.state != StateDashboard || cmd != nil {
+			t.Errorf("%s: late start result should be ignored, state = %v", key, got.state)
+		}
+	}
+}
+
+func TestContainerStarted_IgnoresSupersededStart(t *testing.T) {
+	// /a was cancelled and /b started before /a's result arrived
+	instances := testInstances("/a", "/b")
+	m := Model{state: StateContainerStarting, selectedInstance: &instances[1].ContainerInstance}
+
+	newModel, cmd := m.Update(containerStartedMsg{path: "/a"})
+	if got := newModel.(Model); got.state != StateContainerStarting || cmd != nil {
+		t.Errorf("result for /a should not finish the start of /b, state = %v", got.state)
+	}
+}
+
+// ============================================================================
+// Single-session auto-attach tests
+// ============================================================================
+
+func TestUpdate_AutoAttachSingleSession(t *testing.T) {
+	enabled := true
+	tests := []struct {
+		name       string
+		cfg        *config.Config
+		sessions   []string
+		wantAttach bool
+	}{
+		{"disabled", &config.Config{}, []string{"main:0"}, false},
+		{"one session", &config.Config{AutoAttachSingle: &enabled}, []string{"main:0"}, true},
+		{"several sessions", &config.Config{AutoAttachSingle: &enabled}, []string{"main:0", "dev:1"}, false},
+		{"no sessions", &config.Config{AutoAttachSingle: &enabled}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instances := testInstances("/a")
+			instances[0].Status = devcontainer.StatusRunning
+			m := Model{state: StateDashboard, instancesStatus: instances, config: tt.cfg}
+
+			newModel, _ := m.handleDashboardKey(tea.KeyMsg{Type: tea.KeyEnter})
+			newModel, _ = newModel.(Model).Update(tmuxSessionsLoadedMsg{sessions: tt.sessions})
+			got := newModel.(Model)
+			if attached := got.state == StateAttaching; attached != tt.wantAttach {
+				t.Errorf("state = %v, want attach = %v", got.state, tt.wantAttach)
+			}
+			if got.pendingSingleAttach {
+				t.Error("pendingSingleAttach should be cleared once sessions load")
+			}
+		})
+	}
+}
+
+func TestUpdate_SessionReloadDoesNotAutoAttach(t *testing.T) {
+	enabled := true
+	instances := testInstances("/a")
+	m := Model{
+		state:            StateLoadingTmuxSessions,
+		instancesStatus:  instances,
+		selectedInstance: &instances[0].ContainerInstance,
+		config:           &config.Config{AutoAttachSingle: &enabled},
+	}
+
+	// Reloading after stopping a session should stay on the list
+	newModel, _ := m.Update(tmuxSessionsLoadedMsg{sessions: []string{"main:0"}})
+	if got := newModel.(Model); got.state != StateTmuxSelect {
+		t.Errorf("state = %v, want %v", got.state, StateTmuxSelect)
+	}
+}
+
+// ============================================================================
+// Error retry tests
+// ============================================================================
+
+func TestHandleErrorKey_RetryFailedOperation(t *testing.T) {
+	tests := []struct {
+		name      string
+		failedIn  State
+		wantState State
+	}{
+		{"start", StateContainerStarting, StateContainerStarting},
+		{"stop", StateContainerStopping, StateContainerStopping},
+		{"restart", StateContainerRestarting, StateContainerRestarting},
+		{"load sessions", StateLoadingTmuxSessions, StateLoadingTmuxSessions},
+		{"not retryable", StateCreatingWorktree, StateDashboard},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instances := testInstances("/a")
+			m := Model{
+				state:            tt.failedIn,
+				instancesStatus:  instances,
+				selectedInstance: &instances[0].ContainerInstance,
+				config:           &config.Config{},
+			}
+			newModel, _ := m.Update(containerErrorMsg{err: fmt.Errorf("boom")})
+			m = newModel.(Model)
+			if m.state != StateError {
+				t.Fatalf("state = %v, want %v", m.state, StateError)
+			}
+
+			newModel, _ = m.handleErrorKey(keyMsg("r"))
+			got := newModel.(Model)
+			if got.state != tt.wantState {
+				t.Errorf("state after r = %v, want %v", got.state, tt.wantState)
+			}
+			if got.retry != retryNone || got.err != nil {
+				t.Error("retrying should clear the error")
+			}
+			got.cancelContainerStart()
+		})
+	}
+}
+
+// ============================================================================
+// Theme persistence tests
+// ============================================================================
+
+func TestToggleTheme_PersistsOnceSettled(t *testing.T) {
+	m := Model{state: StateDashboard, darkMode: true, config: &config.Config{}}
+	t.Cleanup(func() { ApplyTheme(true) })
+
+	newModel, cmd := m.handleDashboardKey(keyMsg("t"))
+	m = newModel.(Model)
+	if m.darkMode || m.config.DarkMode == nil || *m.config.DarkMode {
+		t.Fatal("t should switch to light mode and record it in the config")
+	}
+	if cmd == nil {
+		t.Fatal("toggle should schedule a save")
+	}
+
+	// Toggling again supersedes the pending save
+	newModel, _ = m.handleDashboardKey(keyMsg("t"))
+	m = newModel.(Model)
+	if _, cmd := m.Update(themeSaveDueMsg{id: m.themeSaveID - 1}); cmd != nil {
+		t.Error("a superseded toggle should not save")
+	}
+	if _, cmd := m.Update(themeSaveDueMsg{id: m.themeSaveID}); cmd == nil {
+		t.Error("the last toggle should save")
+	}
+}
+
+func TestToggleTheme_OptOut(t *testing.T) {
+	persist := false
+	m := Model{state: StateDashboard, darkMode: true, config: &config.Config{PersistTheme: &persist}}
+	t.Cleanup(func() { ApplyTheme(true) })
+
+	newModel, cmd := m.handleDashboardKey(keyMsg("t"))
+	if got := newModel.(Model); got.darkMode || cmd != nil || got.config.DarkMode != nil {
+		t.Error("with persist_theme_toggle off, t should only switch the theme for this session")
+	}
+}
+
+// ============================================================================
+// Add search path tests
+// ============================================================================
+
+func TestHandleDashboardKey_AddSearchPathOnlyWhenEmpty(t *testing.T) {
+	m := Model{state: StateDashboard, config: &config.Config{}, textInput: textinput.New()}
+	newModel, _ := m.handleDashboardKey(keyMsg("a"))
+	if got := newModel.(Model); got.state != StateAddSearchPathInput {
+		t.Errorf("empty dashboard: state = %v, want %v", got.state, StateAddSearchPathInput)
+	}
+
+	m.instancesStatus = testInstances("/a")
+	newModel, _ = m.handleDashboardKey(keyMsg("a"))
+	if got := newModel.(Model); got.state != StateDashboard {
+		t.Errorf("with projects: state = %v, want %v", got.state, StateDashboard)
+	}
+}
+
+func TestAddSearchPath_RejectsMissingDirectory(t *testing.T) {
+	m := Model{config: &config.Config{SearchPaths: []string{"/src"}}}
+	missing := filepath.Join(t.TempDir(), "nope")
+
+	msg := m.addSearchPath(missing)().(searchPathAddedMsg)
+	if msg.err == nil {
+		t.Fatal("expected an error for a missing directory")
+	}
+
+	newModel, _ := Model{state: StateDiscovering, config: m.config}.Update(msg)
+	got := newModel.(Model)
+	if got.state != StateError {
+		t.Errorf("state = %v, want %v", got.state, StateError)
+	}
+	if len(got.config.SearchPaths) != 1 {
+		t.Errorf("search paths = %v, should be unchanged", got.config.SearchPaths)
+	}
+}
+
+func TestUpdate_SearchPathAddedRediscovers(t *testing.T) {
+	m := Model{state: StateDiscovering, config: &config.Config{SearchPaths: []string{"/src"}}}
+	newModel, cmd := m.Update(searchPathAddedMsg{path: "/code", paths: []string{"/src", "/code"}})
+	got := newModel.(Model)
+	if len(got.config.SearchPaths) != 2 || cmd == nil {
+		t.Errorf("search paths = %v, want the new path and a discovery command", got.config.SearchPaths)
+	}
+}
+
+// ============================================================================
+// devcontainer.json viewer tests
+// ============================================================================
+
+func TestHandleDashboardKey_ShowDevcontainerJSON(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "devcontainer.json")
+	if err := os.WriteFile(configPath, []byte("{\n\t\"name\": \"app\"\n}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	instances := testInstances("/a")
+	instances[0].ConfigPath = configPath
+	m := Model{state: StateDashboard, instancesStatus: instances}
+
+	newModel, cmd := m.handleDashboardKey(keyMsg("J"))
+	m = newModel.(Model)
+	if cmd == nil || m.selectedInstance == nil {
+		t.Fatal("J should select the instance and return a command to read its devcontainer.json")
+	}
+
+	newModel, _ = m.Update(cmd())
+	got := newModel.(Model)
+	if got.state != StateShowDevcontainerJSON {
+		t.Fatalf("state = %v, want %v", got.state, StateShowDevcontainerJSON)
+	}
+	if len(got.jsonLines) != 3 || got.jsonLines[1] != "\t\"name\": \"app\"" {
+		t.Errorf("jsonLines = %q, want the file's three lines", got.jsonLines)
+	}
+
+	newModel, _ = got.handleDevcontainerJSONKey(keyMsg("x"))
+	if got := newModel.(Model); got.state != StateDashboard || got.selectedInstance != nil || got.jsonLines != nil {
+		t.Errorf("any key: state = %v, want %v with the viewer cleared", got.state, StateDashboard)
+	}
+}
+
+func TestUpdate_DevcontainerJSONLoaded(t *testing.T) {
+	instance := devcontainer.ContainerInstance{ConfigPath: "/a/.devcontainer/devcontainer.json"}
+
+	t.Run("read error", func(t *testing.T) {
+		m := Model{state: StateDashboard, selectedInstance: &instance}
+		newModel, _ := m.Update(devcontainerJSONLoadedMsg{path: instance.ConfigPath, err: os.ErrNotExist})
+		if got := newModel.(Model); got.state != StateError || !errors.Is(got.err, os.ErrNotExist) {
+			t.Errorf("state = %v, err = %v; want %v wrapping the read error", got.state, got.err, StateError)
+		}
+	})
+
+	t.Run("stale result", func(t *testing.T) {
+		m := Model{state: StateTmuxSelect, selectedInstance: &instance}
+		newModel, _ := m.Update(devcontainerJSONLoadedMsg{path: instance.ConfigPath, content: "{}"})
+		if got := newModel.(Model); got.state != StateTmuxSelect {
+			t.Errorf("state = %v, want %v (stale read ignored)", got.state, StateTmuxSelect)
+		}
+	})
+}
+
+func TestLoadDevcontainerJSON_TruncatesLargeFiles(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "devcontainer.json")
+	if err := os.WriteFile(configPath, []byte(strings.Repeat("x", constants.MaxConfigViewBytes+10)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := loadDevcontainerJSON(configPath)().(devcontainerJSONLoadedMsg)
+	if msg.err != nil {
+		t.Fatalf("unexpected error: %v", msg.err)
+	}
+	if !msg.truncated || len(msg.content) != constants.MaxConfigViewBytes {
+		t.Errorf("truncated = %v, len = %d; want true, %d", msg.truncated, len(msg.content), constants.MaxConfigViewBytes)
+	}
+}
+
+func TestHandleDevcontainerJSONKey_Scroll(t *testing.T) {
+	m := Model{state: StateShowDevcontainerJSON, jsonLines: make([]string, 100), height: 20}
+
+	newModel, _ := m.handleDevcontainerJSONKey(keyMsg("j"))
+	got := newModel.(Model)
+	if got.state != StateShowDevcontainerJSON || got.jsonScroll != 1 {
+		t.Errorf("j: state = %v, scroll = %d; want %v, 1", got.state, got.jsonScroll, StateShowDevcontainerJSON)
+	}
+
+	newModel, _ = got.handleDevcontainerJSONKey(keyMsg("k"))
+	if got := newModel.(Model); got.jsonScroll != 0 {
+		t.Errorf("k: scroll = %d, want 0", got.jsonScroll)
+	}
+
+	newModel, _ = m.handleDevcontainerJSONKey(keyMsg("k"))
+	if got := newModel.(Model); got.jsonScroll != 0 {
+		t.Errorf("k at top: scroll = %d, want 0", got.jsonScroll)
+	}
+}
+
+// ============================================================================
+// Worktree branch deletion tests
+// ============================================================================
+
+func TestHandleDashboardKey_DeleteWorktreeChecksUnpushedCommits(t *testing.T) {
+	instances := testInstances("/src/app-feature")
+	instances[0].Worktree = &devcontainer.WorktreeInfo{Branch: "feature", MainRepo: "/src/app"}
+	deleteBranch := true
+
+	// Without the option d still checks the container before asking
+	m := Model{state: StateDashboard, config: &config.Config{}, instancesStatus: instances, textInput: textinput.New()}
+	newModel, cmd := m.handleDashboardKey(keyMsg("d"))
+	if got := newModel.(Model); got.state != StateDashboard || cmd == nil {
+		t.Errorf("branch kept: state = %v, want %v with a command to check the container", got.state, StateDashboard)
+	}
+
+	m.config = &config.Config{DeleteBranch: &deleteBranch}
+	for _, tt := range []struct {
+		key  string
+		want State
+	}{
+		{"d", StateConfirmDeleteWorktree},
+		{"D", StateConfirmDiscardWorktree},
+	} {
+		newModel, cmd := m.handleDashboardKey(keyMsg(tt.key))
+		got := newModel.(Model)
+		if got.state != StateDashboard || cmd == nil {
+			t.Fatalf("%s: state = %v, want %v with a command to check the branch", tt.key, got.state, StateDashboard)
+		}
+
+		newModel, _ = got.Update(deleteChecksLoadedMsg{path: "/src/app-feature", unpushed: 3, discard: tt.key == "D"})
+		got = newModel.(Model)
+		if got.state != tt.want || got.unpushedCommits != 3 {
+			t.Errorf("%s: state = %v, unpushed = %d; want %v, 3", tt.key, got.state, got.unpushedCommits, tt.want)
+		}
+	}
+
+	// A result for another worktree is ignored
+	newModel, _ = Model{state: StateDashboard, config: m.config, selectedInstance: &instances[0].ContainerInstance}.
+		Update(deleteChecksLoadedMsg{path: "/src/other", unpushed: 1})
+	if got := newModel.(Model); got.state != StateDashboard {
+		t.Errorf("stale result: state = %v, want %v", got.state, StateDashboard)
+	}
+}
+
+func TestUpdate_DeleteChecksWarnRunningContainer(t *testing.T) {
+	instances := testInstances("/src/app-feature")
+	instances[0].Worktree = &devcontainer.WorktreeInfo{Branch: "feature", MainRepo: "/src/app"}
+	m := Model{state: StateDashboard, config: &config.Config{}, selectedInstance: &instances[0].ContainerInstance}
+
+	newModel, _ := m.Update(deleteChecksLoadedMsg{path: "/src/app-feature", running: true})
+	got := newModel.(Model)
+	if got.state != StateConfirmDeleteWorktree || !got.stopsContainer {
+		t.Fatalf("state = %v, stopsContainer = %v; want %v, true", got.state, got.stopsContainer, StateConfirmDeleteWorktree)
+	}
+	if view := got.View(); !strings.Contains(view, "container is running and will be stopped") {
+		t.Errorf("dialog should say the container will be stopped:\n%s", view)
+	}
+
+	newModel, _ = m.Update(deleteChecksLoadedMsg{path: "/src/app-feature"})
+	if view := newModel.(Model).View(); strings.Contains(view, "will be stopped") {
+		t.Errorf("no stop warning expected for a stopped container:\n%s", view)
+	}
+}
+
+func TestDeletesBranch(t *testing.T) {
+	on, off := true, false
+	tests := []struct {
+		name   string
+		option *bool
+		branch string
+		want   bool
+	}{
+		{"option unset", nil, "feature", false},
+		{"option off", &off, "feature", false},
+		{"option on", &on, "feature", true},
+		{"detached HEAD", &on, "HEAD", false},
+		{"unknown branch", &on, "unknown", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := devcontainer.ContainerInstance{Worktree: &devcontainer.WorktreeInfo{Branch: tt.branch}}
+			m := Model{config: &config.Config{DeleteBranch: tt.option}, selectedInstance: &instance}
+			if got := m.deletesBranch(); got != tt.want {
+				t.Errorf("deletesBranch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderWorktreeDeletionScope(t *testing.T) {
+	tests := []struct {
+		name         string
+		deleteBranch bool
+		unpushed     int
+		want         string
+	}{
+		{"branch kept", false, 5, "The branch is kept"},
+		{"branch deleted", true, 0, "deletes the local branch"},
+		{"unpushed commits", true, 2, "2 unpushed commits will be lost"},
+		{"check failed", true, -1, "Could not check"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderWorktreeDeletionScope(tt.deleteBranch, tt.unpushed); !strings.Contains(got, tt.want) {
+				t.Errorf("renderWorktreeDeletionScope() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+	if got := renderWorktreeDeletionScope(true, 0); strings.Contains(got, "unpushed") {
+		t.Errorf("no unpushed commits: got %q, want no warning", got)
+	}
+}
+
+// ============================================================================
+// Copy exec command tests
+// ============================================================================
+
+func TestHandleDashboardKey_CopyExecCommand(t *testing.T) {
+	m := Model{state: StateDashboard, instancesStatus: testInstances("/src/app")}
+	newModel, cmd := m.handleDashboardKey(keyMsg("Y"))
+	if got := newModel.(Model); got.state != StateDashboard || cmd == nil {
+		t.Errorf("state = %v, want %v with a clipboard copy command", got.state, StateDashboard)
+	}
+
+	m.instancesStatus = nil
+	if _, cmd := m.handleDashboardKey(keyMsg("Y")); cmd != nil {
+		t.Error("Y on an empty dashboard should do nothing")
+	}
+}
+
+// ============================================================================
+// Branch off worktree tests
+// ============================================================================
+
+func TestHandleDashboardKey_BranchOffWorktree(t *testing.T) {
+	instances := testInstances("/src/app", "/src/app-feature")
+	instances[0].Worktree = &devcontainer.WorktreeInfo{Branch: "main", MainRepo: "/src/app", IsMain: true}
+	instances[1].Worktree = &devcontainer.WorktreeInfo{Branch: "feature", MainRepo: "/src/app"}
+	m := Model{state: StateDashboard, instancesStatus: instances, cursor: 1, worktreeInput: textinput.New()}
+
+	newModel, _ := m.handleDashboardKey(keyMsg("b"))
+	got := newModel.(Model)
+	if got.state != StateNewWorktreeInput || got.worktreeBase != "feature" {
+		t.Errorf("b: state = %v, base = %q; want %v, feature", got.state, got.worktreeBase, StateNewWorktreeInput)
+	}
+
+	// n afterwards goes back to branching off the main repo's HEAD
+	newModel, _ = got.handleNewWorktreeInputKey(tea.KeyMsg{Type: tea.KeyEsc})
+	newModel, _ = newModel.(Model).handleDashboardKey(keyMsg("n"))
+	if got := newModel.(Model); got.worktreeBase != "" {
+		t.Errorf("n: base = %q, want none", got.worktreeBase)
+	}
+
+	m.cursor = 0
+	newModel, _ = m.handleDashboardKey(keyMsg("b"))
+	if got := newModel.(Model); got.state != StateError {
+		t.Errorf("b on the main worktree: state = %v, want %v", got.state, StateError)
+	}
+}
+
+// ============================================================================
+// Worktree sub-menu tests
+// ============================================================================
+
+func TestWorktreeList(t *testing.T) {
+	instances := testInstances("/src/app")
+	instances[0].ConfigPath = "/src/app/.devcontainer/devcontainer.json"
+	instances[0].Worktree = &devcontainer.WorktreeInfo{Branch: "main", MainRepo: "/src/app", IsMain: true}
+	m := Model{state: StateDashboard, config: &config.Config{}, instancesStatus: instances, worktreeInput: textinput.New()}
+
+	newModel, cmd := m.handleDashboardKey(keyMsg("v"))
+	m = newModel.(Model)
+	if m.state != StateDashboard || cmd == nil {
+		t.Fatalf("v: state = %v, want %v with a command to list worktrees", m.state, StateDashboard)
+	}
+
+	worktrees := []devcontainer.WorktreeInfo{
+		{Path: "/src/app-one", Branch: "one", MainRepo: "/src/app"},
+		{Path: "/src/app-two", Branch: "two", MainRepo: "/src/app"},
+	}
+	newModel, _ = m.Update(worktreeListLoadedMsg{path: "/src/app", worktrees: worktrees})
+	m = newModel.(Model)
+	if m.state != StateWorktreeList || len(m.worktreeList) != 2 {
+		t.Fatalf("state = %v with %d worktrees, want %v with 2", m.state, len(m.worktreeList), StateWorktreeList)
+	}
+
+	newModel, _ = m.handleWorktreeListKey(keyMsg("j"))
+	newModel, _ = newModel.(Model).handleWorktreeListKey(keyMsg("d"))
+	newModel, _ = newModel.(Model).Update(deleteChecksLoadedMsg{path: "/src/app-two"})
+	got := newModel.(Model)
+	if got.state != StateConfirmDeleteWorktree {
+		t.Fatalf("d: state = %v, want %v", got.state, StateConfirmDeleteWorktree)
+	}
+	if got.selectedInstance.Path != "/src/app-two" || got.selectedInstance.Worktree.Branch != "two" ||
+		got.selectedInstance.ConfigPath != instances[0].ConfigPath {
+		t.Errorf("selected = %+v, want the second worktree sharing the repo's config", got.selectedInstance)
+	}
+	if instances[0].Path != "/src/app" {
+		t.Error("selecting a worktree should not modify the repository's instance")
+	}
+
+	newModel, _ = m.handleWorktreeListKey(keyMsg("n"))
+	if got := newModel.(Model); got.state != StateNewWorktreeInput || got.selectedInstance.Path != "/src/app" {
+		t.Errorf("n: state = %v, want %v for the repository", got.state, StateNewWorktreeInput)
+	}
+
+	newModel, _ = m.handleWorktreeListKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if got := newModel.(Model); got.state != StateDashboard || got.selectedInstance != nil {
+		t.Errorf("esc: state = %v, want %v with no selection", got.state, StateDashboard)
+	}
+}
+
+func TestHandleDashboardKey_WorktreeListNeedsGit(t *testing.T) {
+	m := Model{state: StateDashboard, instancesStatus: testInstances("/src/plain")}
+	newModel, cmd := m.handleDashboardKey(keyMsg("v"))
+	if got := newModel.(Model); got.state != StateError || cmd != nil {
+		t.Errorf("state = %v, want %v", got.state, StateError)
+	}
+}
+
+// ============================================================================
+// Double-press quick stop tests
+// ============================================================================
+
+func TestHandleDashboardKey_DoubleXStopsWithoutConfirm(t *testing.T) {
+	m := Model{state: StateDashboard, instancesStatus: testInstances("/a")}
+
+	newModel, _ := m.handleKeyPress(keyMsg("x"))
+	model := newModel.(Model)
+	if model.state != StateConfirmStop {
+		t.Fatalf("state after first x = %v, want %v", model.state, StateConfirmStop)
+	}
+
+	newModel, cmd := model.handleKeyPress(keyMsg("x"))
+	model = newModel.(Model)
+	if model.state != StateContainerStopping || cmd == nil {
+		t.Errorf("state after second x = %v, want %v with a stop cmd", model.state, StateContainerStopping)
+	}
+}
+
+func TestHandleConfirmKey_SlowSecondXKeepsDialog(t *testing.T) {
+	m := Model{
+		state:            StateConfirmStop,
+		instancesStatus:  testInstances("/a"),
+		selectedInstance: &testInstances("/a")[0].ContainerInstance,
+		stopPressedAt:    time.Now().Add(-time.Second),
+	}
+
+	newModel, cmd := m.handleKeyPress(keyMsg("x"))
+	if got := newModel.(Model); got.state != StateConfirmStop || cmd != nil {
+		t.Errorf("state = %v, want %v", got.state, StateConfirmStop)
+	}
+}
+
+func TestHandleConfirmKey_XDoesNotConfirmRestart(t *testing.T) {
+	m := Model{state: StateConfirmRestart, stopPressedAt: time.Now()}
+
+	newModel, cmd := m.handleKeyPress(keyMsg("x"))
+	if got := newModel.(Model); got.state != StateConfirmRestart || cmd != nil {
+		t.Errorf("state = %v, want %v", got.state, StateConfirmRestart)
+	}
+}
+
+// ============================================================================
+// Saved query picker tests
+// ============================================================================
+
+func TestHandleGitHubIssuesListKey_SavedQueryPicker(t *testing.T) {
+	cfg := &config.Config{GitHub: github.DefaultConfig()}
+	cfg.GitHub.SavedQueries = map[string]string{
+		"my bugs": "label:bug assignee:@me",
+		"stale":   "updated:<2024-01-01",
+	}
+	m := Model{
+		state:        StateGitHubIssuesList,
+		config:       cfg,
+		githubIssues: []github.Issue{{Number: 1}},
+		markedIssues: map[int]bool{1: true},
+		issueQuery:   "stale",
+	}
+
+	newModel, _ := m.handleKeyPress(keyMsg("s"))
+	model := newModel.(Model)
+	if model.state != StateGitHubQuerySelect || model.queryCursor != 2 {
+		t.Fatalf("state = %v, cursor = %d, want %v on the active query", model.state, model.queryCursor, StateGitHubQuerySelect)
+	}
+
+	newModel, _ = model.handleKeyPress(keyMsg("k"))
+	newModel, cmd := newModel.(Model).handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	model = newModel.(Model)
+	if model.issueQuery != "my bugs" || model.issueSearch() != "label:bug assignee:@me" {
+		t.Errorf("issueQuery = %q, search = %q, want my bugs", model.issueQuery, model.issueSearch())
+	}
+	if model.state != StateGitHubIssuesLoading || cmd == nil {
+		t.Errorf("state = %v, want %v with a fetch command", model.state, StateGitHubIssuesLoading)
+	}
+	if len(model.markedIssues) != 0 {
+		t.Error("changing the query should clear marked issues")
+	}
+	model.cancelGitHubRequest()
+}
+
+func TestHandleQuerySelectKey_NoneClearsQuery(t *testing.T) {
+	cfg := &config.Config{GitHub: github.DefaultConfig()}
+	cfg.GitHub.SavedQueries = map[string]string{"my bugs": "label:bug"}
+	m := Model{state: StateGitHubQuerySelect, config: cfg, issueQuery: "my bugs"}
+
+	newModel, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	model := newModel.(Model)
+	if model.issueQuery != "" || model.issueSearch() != "" {
+		t.Errorf("issueQuery = %q, want none", model.issueQuery)
+	}
+	model.cancelGitHubRequest()
+}
+
+func TestHandleGitHubIssuesListKey_NoSavedQueries(t *testing.T) {
+	m := Model{state: StateGitHubIssuesList, config: &config.Config{GitHub: github.DefaultConfig()}}
+
+	newModel, _ := m.handleKeyPress(keyMsg("s"))
+	if got := newModel.(Model); got.state != StateGitHubIssuesList || !strings.Contains(got.flash, "saved_queries") {
+		t.Errorf("state = %v, flash = %q, want a hint to configure saved_queries", got.state, got.flash)
+	}
+}
+
+// ============================================================================
+// Container adoption tests
+// ============================================================================
+
+func TestMarkUnmanaged(t *testing.T) {
+	managed := t.TempDir()
+	if err := auth.WriteCredentialFile(managed, map[string]string{"TOKEN": "x"}); err != nil {
+		t.Fatal(err)
+	}
+	unmanaged := t.TempDir()
+
+	statuses := testInstances(managed, unmanaged, unmanaged, unmanaged)
+	statuses[0].Status = devcontainer.StatusRunning
+	statuses[1].Status = devcontainer.StatusRunning
+	statuses[2].Status = devcontainer.StatusRunning
+	statuses[2].SessionCount = 1
+	statuses[3].Status = devcontainer.StatusStopped
+
+	markUnmanaged(statuses)
+	for i, want := range []bool{false, true, false, false} {
+		if statuses[i].Unmanaged != want {
+			t.Errorf("statuses[%d].Unmanaged = %v, want %v", i, statuses[i].Unmanaged, want)
+		}
+	}
+}
+
+func TestHandleDashboardKey_AdoptOnlyUnmanaged(t *testing.T) {
+	m := Model{state: StateDashboard, config: &config.Config{}, instancesStatus: testInstances("/a")}
+
+	newModel, cmd := m.handleDashboardKey(keyMsg("A"))
+	if got := newModel.(Model); got.state != StateDashboard || cmd != nil {
+		t.Errorf("A on a managed instance: state = %v, want %v", got.state, StateDashboard)
+	}
+
+	m.instancesStatus[0].Unmanaged = true
+	newModel, cmd = m.handleDashboardKey(keyMsg("A"))
+	if got := newModel.(Model); got.state != StateAdoptingContainer || cmd == nil {
+		t.Errorf("A on an unmanaged instance: state = %v, want %v", got.state, StateAdoptingContainer)
+	}
+}
+
+func TestContainerAdoptedMsg_OpensDefaultSession(t *testing.T) {
+	instances := testInstances("/a")
+	instances[0].Unmanaged = true
+	m := Model{
+		state:            StateAdoptingContainer,
+		config:           &config.Config{},
+		instancesStatus:  instances,
+		selectedInstance: &instances[0].ContainerInstance,
+	}
+
+	newModel, cmd := m.Update(containerAdoptedMsg{path: "/b"})
+	if got := newModel.(Model); got.state != StateAdoptingContainer || cmd != nil {
+		t.Fatalf("a result for another instance should be ignored, state = %v", got.state)
+	}
+
+	newModel, cmd = m.Update(containerAdoptedMsg{path: "/a"})
+	model := newModel.(Model)
+	if model.state != StateLoadingTmuxSessions || cmd == nil || !model.pendingAutoAttach {
+		t.Errorf("state = %v, pendingAutoAttach = %v, want %v and auto-attach", model.state, model.pendingAutoAttach, StateLoadingTmuxSessions)
+	}
+	if model.instancesStatus[0].Unmanaged {
+		t.Error("an adopted instance should no longer be flagged")
+	}
+}
+
+// ============================================================================
+// Profile switcher tests
+// ============================================================================
+
+func TestHandleDashboardKey_SwitchProfile(t *testing.T) {
+	cfg := &config.Config{
+		SearchPaths: []string{"/base"},
+		Profiles: map[string]config.Profile{
+			"personal": {SearchPaths: []string{"/personal"}},
+			"work":     {SearchPaths: []string{"/work"}},
+		},
+	}
+	m := Model{state: StateDashboard, config: cfg}
+
+	newModel, _ := m.handleKeyPress(keyMsg("P"))
+	model := newModel.(Model)
+	if model.state != StateProfileSelect || model.profileCursor != 0 {
+		t.Fatalf("state = %v, cursor = %d, want %v on the base paths", model.state, model.profileCursor, StateProfileSelect)
+	}
+
+	newModel, _ = model.handleKeyPress(keyMsg("j"))
+	newModel, _ = newModel.(Model).handleKeyPress(keyMsg("j"))
+	newModel, cmd := newModel.(Model).handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	model = newModel.(Model)
+	if model.state != StateDiscovering || cmd == nil {
+		t.Errorf("state = %v, want %v with a discovery cmd", model.state, StateDiscovering)
+	}
+	if model.config.ActiveProfile() != "work" || len(model.config.SearchPaths) != 1 || model.config.SearchPaths[0] != "/work" {
+		t.Errorf("profile = %q, paths = %q, want work's paths", model.config.ActiveProfile(), model.config.SearchPaths)
+	}
+	if cfg.ActiveProfile() != "" {
+		t.Error("switching should not modify the previous config")
+	}
+}
+
+func TestHandleDashboardKey_NoProfiles(t *testing.T) {
+	m := Model{state: StateDashboard, config: &config.Config{}}
+
+	newModel, _ := m.handleKeyPress(keyMsg("P"))
+	if got := newModel.(Model); got.state != StateDashboard || !strings.Contains(got.flash, "No profiles") {
+		t.Errorf("state = %v, flash = %q, want a hint that no profiles are configured", got.state, got.flash)
+	}
+}
+
+// ============================================================================
+// Tmux server restart tests
+// ============================================================================
+
+func TestHandleTmuxSelectKey_RestartTmuxServer(t *testing.T) {
+	instance := devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app", Path: "/app"}}
+	m := Model{state: StateTmuxSelect, config: &config.Config{}, selectedInstance: &instance}
+
+	// Available even when no sessions are listed
+	newModel, _ := m.handleKeyPress(keyMsg("R"))
+	model := newModel.(Model)
+	if model.state != StateConfirmTmuxServerRestart {
+		t.Fatalf("state = %v, want %v", model.state, StateConfirmTmuxServerRestart)
+	}
+
+	newModel, _ = model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if got := newModel.(Model); got.state != StateTmuxSelect {
+		t.Errorf("esc: state = %v, want %v", got.state, StateTmuxSelect)
+	}
+
+	newModel, cmd := model.handleKeyPress(keyMsg("y"))
+	model = newModel.(Model)
+	if model.state != StateTmuxServerRestarting || cmd == nil {
+		t.Fatalf("y: state = %v, want %v with a restart cmd", model.state, StateTmuxServerRestarting)
+	}
+
+	newModel, cmd = model.Update(tmuxSessionStoppedMsg{})
+	if got := newModel.(Model); got.state != StateLoadingTmuxSessions || cmd == nil {
+		t.Errorf("after restart: state = %v, want %v", got.state, StateLoadingTmuxSessions)
+	}
+}
+
+// ============================================================================
+// Session pipe tests
+// ============================================================================
+
+func TestHandleTmuxSelectKey_PipeSession(t *testing.T) {
+	instance := testInstances("/a")[0].ContainerInstance
+	m := Model{
+		state:            StateTmuxSelect,
+		config:           &config.Config{},
+		selectedInstance: &instance,
+		tmuxSessions:     []tmux.Session{{Name: "main"}},
+		textInput:        textinput.New(),
+	}
+
+	newModel, _ := m.handleKeyPress(keyMsg("p"))
+	model := newModel.(Model)
+	if model.state != StatePipeSessionInput || model.textInput.Placeholder != "main.log" {
+		t.Fatalf("state = %v, placeholder = %q, want %v suggesting main.log", model.state, model.textInput.Placeholder, StatePipeSessionInput)
+	}
+
+	newModel, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := newModel.(Model); got.state != StateTmuxSelect || cmd == nil {
+		t.Errorf("enter: state = %v, want %v with a pipe cmd", got.state, StateTmuxSelect)
+	}
+
+	// New Session row has nothing to pipe
+	m.cursor = 1
+	newModel, _ = m.handleKeyPress(keyMsg("p"))
+	if got := newModel.(Model); got.state != StateTmuxSelect {
+		t.Errorf("p on New Session: state = %v, want %v", got.state, StateTmuxSelect)
+	}
+}
+
+func TestSessionPipedMsg(t *testing.T) {
+	m := Model{state: StateTmuxSelect}
+
+	newModel, _ := m.Update(sessionPipedMsg{session: "main", outPath: "main.log"})
+	if got := newModel.(Model); !strings.Contains(got.flash, "main.log") {
+		t.Errorf("flash = %q, want it to name the output file", got.flash)
+	}
+
+	newModel, _ = m.Update(sessionPipedMsg{err: errors.New("failed to pipe tmux session")})
+	if got := newModel.(Model); got.state != StateError {
+		t.Errorf("state = %v, want %v", got.state, StateError)
+	}
+}
+
+// ============================================================================
+// New issue tests
+// ============================================================================
+
+func TestHandleNewIssueInputKey_TitleThenBody(t *testing.T) {
+	m := Model{
+		state:        StateGitHubIssuesList,
+		config:       &config.Config{GitHub: github.DefaultConfig()},
+		githubIssues: []github.Issue{{Number: 1}},
+		textInput:    textinput.New(),
+	}
+
+	newModel, _ := m.handleKeyPress(keyMsg("c"))
+	model := newModel.(Model)
+	if model.state != StateGitHubIssueTitleInput {
+		t.Fatalf("state = %v, want %v", model.state, StateGitHubIssueTitleInput)
+	}
+
+	// An empty title is not accepted
+	newModel, _ = model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := newModel.(Model); got.state != StateGitHubIssueTitleInput {
+		t.Errorf("empty title: state = %v, want to stay on the title", got.state)
+	}
+
+	model.textInput.SetValue("  Crash on start  ")
+	newModel, _ = model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	model = newModel.(Model)
+	if model.state != StateGitHubIssueBodyInput || model.newIssueTitle != "Crash on start" || model.textInput.Value() != "" {
+		t.Fatalf("state = %v, title = %q, want the body input after the trimmed title", model.state, model.newIssueTitle)
+	}
+
+	// The body is optional
+	newModel, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := newModel.(Model); got.state != StateGitHubIssueCreating || cmd == nil {
+		t.Errorf("state = %v, want %v with a create command", got.state, StateGitHubIssueCreating)
+	}
+}
+
+func TestHandleNewIssueInputKey_EscCancels(t *testing.T) {
+	m := Model{state: StateGitHubIssueBodyInput, newIssueTitle: "Crash", textInput: textinput.New()}
+
+	newModel, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	got := newModel.(Model)
+	if got.state != StateGitHubIssuesList || got.newIssueTitle != "" || cmd != nil {
+		t.Errorf("state = %v, title = %q, want back on the list", got.state, got.newIssueTitle)
+	}
+}
+
+func TestGitHubIssueCreated_SelectsNewIssue(t *testing.T) {
+	instance := testInstances("/a")[0].ContainerInstance
+	m := Model{
+		state:            StateGitHubIssueCreating,
+		config:           &config.Config{GitHub: github.DefaultConfig()},
+		selectedInstance: &instance,
+	}
+
+	newModel, cmd := m.Update(githubIssueCreatedMsg{number: 9})
+	model := newModel.(Model)
+	if model.state != StateGitHubIssuesLoading || cmd == nil || model.createdIssue != 9 {
+		t.Fatalf("state = %v, createdIssue = %d, want a reload remembering #9", model.state, model.createdIssue)
+	}
+	model.cancelGitHubRequest()
+
+	newModel, _ = model.Update(githubIssuesLoadedMsg{issues: []github.Issue{{Number: 10}, {Number: 9}}, request: model.githubRequest})
+	model = newModel.(Model)
+	if model.cursor != 1 || model.createdIssue != 0 {
+		t.Errorf("cursor = %d, createdIssue = %d, want the new issue selected", model.cursor, model.createdIssue)
+	}
+	if !strings.Contains(model.flash, "#9") || !strings.Contains(model.flash, "worktree") {
+		t.Errorf("flash = %q, want it to offer a worktree for #9", model.flash)
+	}
+}
+
+func TestGitHubIssueCreateError(t *testing.T) {
+	m := Model{state: StateGitHubIssueCreating}
+
+	newModel, _ := m.Update(githubIssuesErrorMsg{err: &github.ScopeError{Missing: []string{"repo"}}})
+	got := newModel.(Model)
+	if got.state != StateError || !strings.Contains(got.err.Error(), "gh auth refresh") {
+		t.Errorf("state = %v, err = %v, want the missing scope reported", got.state, got.err)
+	}
+}
@@ -1,10 +1,18 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/christophergyman/claude-quick/internal/config"
+	"github.com/christophergyman/claude-quick/internal/constants"
 	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/github"
 	"github.com/christophergyman/claude-quick/internal/tmux"
 )
 
@@ -37,7 +45,7 @@ func TestTotalTmuxOptions(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := TotalTmuxOptions(tt.sessions)
+			result := TotalTmuxOptions(tt.sessions, nil)
 			if result != tt.expected {
 				t.Errorf("TotalTmuxOptions() = %d, want %d", result, tt.expected)
 			}
@@ -94,7 +102,7 @@ func TestIsNewSessionSelected(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := IsNewSessionSelected(tt.sessions, tt.cursor)
+			result := IsNewSessionSelected(tt.sessions, nil, tt.cursor)
 			if result != tt.expected {
 				t.Errorf("IsNewSessionSelected() = %v, want %v", result, tt.expected)
 			}
@@ -102,6 +110,83 @@ func TestIsNewSessionSelected(t *testing.T) {
 	}
 }
 
+func TestPresetSessionOptions(t *testing.T) {
+	sessions := []tmux.Session{{Name: "main"}, {Name: "dev"}}
+	presets := missingPresets(sessions, []string{"main", "logs", "build"})
+
+	if strings.Join(presets, ",") != "logs,build" {
+		t.Fatalf("missingPresets() = %v, want running presets skipped", presets)
+	}
+	if got := TotalTmuxOptions(sessions, presets); got != 5 {
+		t.Errorf("TotalTmuxOptions() = %d, want 5", got)
+	}
+	if name, ok := SelectedPreset(sessions, presets, 3); !ok || name != "build" {
+		t.Errorf("SelectedPreset(cursor 3) = %q, %v, want build", name, ok)
+	}
+	if _, ok := SelectedPreset(sessions, presets, 1); ok {
+		t.Error("cursor on an existing session should not select a preset")
+	}
+	if IsNewSessionSelected(sessions, presets, 2) || !IsNewSessionSelected(sessions, presets, 4) {
+		t.Error("New Session should follow the presets")
+	}
+
+	// Presets become usable tmux names, matched against running sessions as such
+	if got := missingPresets([]tmux.Session{{Name: "api-v2"}}, []string{"api.v2", "db:shell", "db.shell", "..."}); strings.Join(got, ",") != "db-shell" {
+		t.Errorf("missingPresets() = %v, want the sanitized db-shell only", got)
+	}
+
+	result := RenderTmuxSelect("proj", sessions, "", presets, 0, "", "")
+	if !strings.Contains(result, "[+ logs]") || !strings.Contains(result, "preset") {
+		t.Error("should render presets as quick-create options")
+	}
+}
+
+func TestRenderTmuxSelect_DefaultSession(t *testing.T) {
+	sessions := tmux.DefaultFirst([]tmux.Session{{Name: "logs"}, {Name: "main"}, {Name: "build"}}, "main")
+	if sessions[0].Name != "main" || sessions[1].Name != "logs" || sessions[2].Name != "build" {
+		t.Errorf("DefaultFirst() = %v, want main first and the rest in order", sessions)
+	}
+	if got := tmux.DefaultFirst([]tmux.Session{{Name: "logs"}}, "main"); got[0].Name != "logs" {
+		t.Errorf("DefaultFirst() without the default = %v, want it unchanged", got)
+	}
+
+	result := RenderTmuxSelect("proj", sessions, "main", nil, 0, "", "")
+	if !strings.Contains(result, "main") || strings.Count(result, "(default)") != 1 {
+		t.Error("should label only the default session")
+	}
+}
+
+func TestScrollKey(t *testing.T) {
+	// 20 lines, 5 visible: the first visible line runs from 0 to 15
+	tests := []struct {
+		key     string
+		scroll  int
+		want    int
+		handled bool
+	}{
+		{"down", 0, 1, true},
+		{"j", 15, 15, true},
+		{"up", 0, 0, true},
+		{"k", 3, 2, true},
+		{"pgdown", 0, 5, true},
+		{"pgdown", 13, 15, true},
+		{"pgup", 3, 0, true},
+		{"down", 40, 15, true},
+		{"x", 3, 3, false},
+	}
+	for _, tt := range tests {
+		got, handled := scrollKey(tt.key, tt.scroll, 20, 5)
+		if got != tt.want || handled != tt.handled {
+			t.Errorf("scrollKey(%q, %d) = %d, %v; want %d, %v", tt.key, tt.scroll, got, handled, tt.want, tt.handled)
+		}
+	}
+
+	// A view shorter than a page never scrolls
+	if got := clampScroll(2, 3, 5); got != 0 {
+		t.Errorf("clampScroll() = %d for a short view, want 0", got)
+	}
+}
+
 func TestTruncatePath(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -145,6 +230,18 @@ func TestTruncatePath(t *testing.T) {
 			maxLen:   -5,
 			expected: "/short", // Will use default (40) so won't truncate
 		},
+		{
+			name:     "multibyte runes are kept whole",
+			path:     "/home/użytkownik/projekty",
+			maxLen:   12,
+			expected: ".../projekty",
+		},
+		{
+			name:     "maxLen too small for the ellipsis",
+			path:     "/home/user",
+			maxLen:   2,
+			expected: "er",
+		},
 	}
 
 	for _, tt := range tests {
@@ -498,6 +595,76 @@ func TestGetStatusIndicator(t *testing.T) {
 	}
 }
 
+func TestParseColorFgBg(t *testing.T) {
+	tests := []struct {
+		value    string
+		wantDark bool
+		wantOK   bool
+	}{
+		{"15;0", true, true},
+		{"0;15", false, true},
+		{"12;8", true, true},
+		{"0;7", false, true},
+		{"15;default;0", true, true},
+		{"0;default", false, false},
+		{"", false, false},
+		{"7", false, false},
+		{"0;255", false, false},
+	}
+
+	for _, tt := range tests {
+		dark, ok := parseColorFgBg(tt.value)
+		if dark != tt.wantDark || ok != tt.wantOK {
+			t.Errorf("parseColorFgBg(%q) = %v, %v; want %v, %v", tt.value, dark, ok, tt.wantDark, tt.wantOK)
+		}
+	}
+}
+
+func TestResolveDarkMode(t *testing.T) {
+	light, dark := false, true
+
+	// An explicit setting wins over the terminal
+	t.Setenv("COLORFGBG", "15;0")
+	if resolveDarkMode(&config.Config{DarkMode: &light}) {
+		t.Error("dark_mode: false should give the light theme on a dark terminal")
+	}
+	t.Setenv("COLORFGBG", "0;15")
+	if !resolveDarkMode(&config.Config{DarkMode: &dark}) {
+		t.Error("dark_mode: true should give the dark theme on a light terminal")
+	}
+
+	// Unset follows the terminal background
+	if resolveDarkMode(&config.Config{}) {
+		t.Error("unset dark_mode should follow a light COLORFGBG background")
+	}
+	t.Setenv("COLORFGBG", "15;0")
+	if !resolveDarkMode(&config.Config{}) {
+		t.Error("unset dark_mode should follow a dark COLORFGBG background")
+	}
+}
+
+func TestMergePalette(t *testing.T) {
+	overrides := config.ThemeConfig{
+		Orange:  "#123456",
+		Success: "#ABCDEF",
+	}
+
+	merged := mergePalette(darkPalette, overrides)
+
+	if merged.orange != lipgloss.Color("#123456") {
+		t.Errorf("orange = %q, want override #123456", merged.orange)
+	}
+	if merged.success != lipgloss.Color("#ABCDEF") {
+		t.Errorf("success = %q, want override #ABCDEF", merged.success)
+	}
+	if merged.warning != darkPalette.warning {
+		t.Errorf("warning = %q, want base %q", merged.warning, darkPalette.warning)
+	}
+	if darkPalette.orange == lipgloss.Color("#123456") {
+		t.Error("mergePalette should not modify the base palette")
+	}
+}
+
 // ============================================================================
 // container.go tests
 // ============================================================================
@@ -604,6 +771,32 @@ func TestRenderConfirmDialog(t *testing.T) {
 	}
 }
 
+func TestRenderConfirmDialog_ListsInterruptedSessions(t *testing.T) {
+	few := []tmux.Session{{Name: "main", Attached: 1}, {Name: "dev"}}
+	result := RenderConfirmDialog("restart", "myapp", few)
+	for _, expected := range []string{"interrupted", "main", "dev", "(attached)"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("dialog should contain %q", expected)
+		}
+	}
+
+	var many []tmux.Session
+	for i := 0; i <= constants.MaxListedSessions; i++ {
+		many = append(many, tmux.Session{Name: fmt.Sprintf("s%d", i)})
+	}
+	result = RenderConfirmDialog("restart", "myapp", many)
+	if want := fmt.Sprintf("%d sessions will be interrupted", len(many)); !strings.Contains(result, want) {
+		t.Errorf("dialog should summarize as %q", want)
+	}
+	if strings.Contains(result, "s0") {
+		t.Error("summarized dialog should not list session names")
+	}
+
+	if result := RenderConfirmDialog("restart", "myapp", nil); strings.Contains(result, "interrupted") {
+		t.Error("dialog without sessions should not mention interruptions")
+	}
+}
+
 func TestRenderOperation(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -649,6 +842,71 @@ func TestRenderOperation(t *testing.T) {
 	}
 }
 
+func TestScrollViewRows(t *testing.T) {
+	if got := scrollViewRows(0); got != constants.MinScrollViewRows {
+		t.Errorf("scrollViewRows(0) = %d, want %d", got, constants.MinScrollViewRows)
+	}
+	if got := scrollViewRows(40); got != 40-constants.ScrollViewChrome {
+		t.Errorf("scrollViewRows(40) = %d, want %d", got, 40-constants.ScrollViewChrome)
+	}
+}
+
+func TestRenderWorktreeCommits(t *testing.T) {
+	t.Run("no commits", func(t *testing.T) {
+		result := RenderWorktreeCommits("proj [feature]", "main", "", []string{}, 0, 30, 80)
+		if !strings.Contains(result, "No commits since main") {
+			t.Error("should show empty message with base name")
+		}
+		if !strings.Contains(result, "calculating") {
+			t.Error("should show a placeholder while the size is measured")
+		}
+	})
+
+	t.Run("scrolled list", func(t *testing.T) {
+		var commits []string
+		for i := 0; i < 20; i++ {
+			commits = append(commits, "abc123"+string(rune('a'+i))+" subject "+string(rune('a'+i)))
+		}
+		result := RenderWorktreeCommits("proj [feature]", "main", "1.5 MB", commits, 3, 0, 80)
+		if strings.Contains(result, "subject a") {
+			t.Error("commits before the scroll offset should be hidden")
+		}
+		if !strings.Contains(result, "subject d") {
+			t.Error("commit at the scroll offset should be visible")
+		}
+		if !strings.Contains(result, "of 20 commits") {
+			t.Error("should show position indicator when list overflows")
+		}
+		if !strings.Contains(result, "1.5 MB") {
+			t.Error("should show the worktree size")
+		}
+	})
+}
+
+func TestFormatDiskUsage(t *testing.T) {
+	partial := fmt.Errorf("%w (2 skipped)", devcontainer.ErrPartialDiskUsage)
+	tests := []struct {
+		name string
+		size int64
+		err  error
+		want string
+	}{
+		{"bytes", 512, nil, "512 B"},
+		{"kilobytes", 1536, nil, "1.5 KB"},
+		{"megabytes", 5 * 1024 * 1024, nil, "5.0 MB"},
+		{"gigabytes", 3 * 1024 * 1024 * 1024, nil, "3.0 GB"},
+		{"partial", 2048, partial, "at least 2.0 KB (some files unreadable)"},
+		{"failed", 0, fmt.Errorf("failed to list worktree files"), "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatDiskUsage(tt.size, tt.err); got != tt.want {
+				t.Errorf("formatDiskUsage(%d, %v) = %q, want %q", tt.size, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
 // ============================================================================
 // model.go tests
 // ============================================================================
@@ -772,3 +1030,689 @@ func TestModel_GetWorktreeBranch(t *testing.T) {
 		})
 	}
 }
+
+func TestModel_WithWarning(t *testing.T) {
+	m := Model{}
+	updated := m.WithWarning("Docker daemon unreachable: connection refused")
+
+	if updated.warning != "Docker daemon unreachable: connection refused" {
+		t.Errorf("warning = %q, want the given warning", updated.warning)
+	}
+	if m.warning != "" {
+		t.Error("WithWarning should not modify the original model")
+	}
+}
+
+// ============================================================================
+// events.go tests
+// ============================================================================
+
+func TestLogEvent_DropsOldest(t *testing.T) {
+	m := Model{}
+	for i := 0; i < constants.MaxEventLogEntries+5; i++ {
+		m.logEvent("event %d", i)
+	}
+
+	if len(m.events) != constants.MaxEventLogEntries {
+		t.Fatalf("len(events) = %d, want %d", len(m.events), constants.MaxEventLogEntries)
+	}
+	if m.events[0].text != "event 5" {
+		t.Errorf("oldest event = %q, want %q", m.events[0].text, "event 5")
+	}
+	if last := m.events[len(m.events)-1].text; last != fmt.Sprintf("event %d", constants.MaxEventLogEntries+4) {
+		t.Errorf("newest event = %q", last)
+	}
+}
+
+func TestRenderEventLog(t *testing.T) {
+	if !strings.Contains(RenderEventLog(nil, 0, 30, 80), "Nothing has happened yet") {
+		t.Error("empty log should show a placeholder")
+	}
+
+	m := Model{}
+	m.logEvent("Started container alpha")
+	m.logEvent("Stopped container alpha")
+	result := RenderEventLog(m.events, 0, 30, 80)
+
+	// Newest entry is listed first
+	started := strings.Index(result, "Started container alpha")
+	stopped := strings.Index(result, "Stopped container alpha")
+	if started < 0 || stopped < 0 {
+		t.Fatal("should contain both events")
+	}
+	if stopped > started {
+		t.Error("newest event should be listed first")
+	}
+}
+
+func TestUpdate_LogsContainerStopped(t *testing.T) {
+	m := Model{
+		state:            StateContainerStopping,
+		selectedInstance: &devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "alpha"}},
+		config:           &config.Config{},
+	}
+
+	newModel, _ := m.Update(containerStoppedMsg{})
+	got := newModel.(Model)
+	if len(got.events) != 1 || !strings.Contains(got.events[0].text, "Stopped container alpha") {
+		t.Errorf("events = %+v, want a stop event", got.events)
+	}
+}
+
+func TestNewWithProject(t *testing.T) {
+	instance := devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "alpha", Path: "/src/alpha"}}
+	m := NewWithProject(instance, config.DefaultConfig())
+
+	if m.state != StateContainerStarting {
+		t.Errorf("state = %v, want %v", m.state, StateContainerStarting)
+	}
+	if m.selectedInstance == nil || m.selectedInstance.Path != "/src/alpha" {
+		t.Errorf("selectedInstance = %+v, want the given project", m.selectedInstance)
+	}
+	if len(m.instances) != 1 || len(m.instancesStatus) != 1 {
+		t.Error("the project should be the only instance")
+	}
+	if m.Init() == nil {
+		t.Error("Init() should start the container")
+	}
+}
+
+func TestRenderDashboard_ImageStatus(t *testing.T) {
+	instances := []devcontainer.ContainerInstanceWithStatus{
+		{ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "alpha", Path: "/src/alpha"}}, Status: devcontainer.StatusRunning},
+		{ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "beta", Path: "/src/beta"}}, Status: devcontainer.StatusRunning},
+	}
+
+	result := RenderDashboard(instances, nil, nil, 0, DashboardOptions{}, 80, "", "")
+	if strings.Contains(result, "update available") {
+		t.Error("should not show image hints before a check")
+	}
+
+	status := map[string]devcontainer.ImageStatus{"/src/alpha": devcontainer.ImageOutdated}
+	result = RenderDashboard(instances, nil, status, 0, DashboardOptions{}, 80, "", "")
+	if strings.Count(result, "update available") != 1 {
+		t.Error("should show the update hint for the checked instance only")
+	}
+}
+
+func TestRenderDashboard_EmptyState(t *testing.T) {
+	result := RenderDashboard(nil, nil, nil, 0, DashboardOptions{}, 80, "", "")
+	for _, want := range []string{"No devcontainer projects found", "setup wizard", "search again", "w: Wizard", "R: Search again"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("empty dashboard should mention %q:\n%s", want, result)
+		}
+	}
+}
+
+func TestRenderDashboard_CompactLayout(t *testing.T) {
+	instances := []devcontainer.ContainerInstanceWithStatus{
+		{ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "alpha", Path: "/src/alpha"}}, Status: devcontainer.StatusRunning},
+		{
+			ContainerInstance: devcontainer.ContainerInstance{
+				Project:  devcontainer.Project{Name: "beta", Path: "/src/beta-feature"},
+				Worktree: &devcontainer.WorktreeInfo{Branch: "feature", IsMain: false},
+			},
+			Status: devcontainer.StatusStopped,
+		},
+	}
+
+	for _, width := range []int{50, 80, 120} {
+		result := RenderDashboard(instances, nil, nil, 0, DashboardOptions{Compact: true}, width, "", "")
+		lines := strings.Split(result, "\n")
+
+		// Each instance renders on exactly one line, with no blank rows between them
+		var rows []string
+		for i, line := range lines {
+			if strings.Contains(line, "alpha") || strings.Contains(line, "beta") {
+				rows = append(rows, line)
+				if i > 0 && strings.TrimSpace(lines[i-1]) == "" {
+					t.Errorf("width %d: unexpected blank line before %q", width, line)
+				}
+			}
+		}
+		if len(rows) != 2 {
+			t.Fatalf("width %d: got %d instance lines, want 2:\n%s", width, len(rows), result)
+		}
+
+		for _, row := range rows {
+			if got := lipgloss.Width(row); got != width-2 {
+				t.Errorf("width %d: row %q is %d columns wide, want %d", width, row, got, width-2)
+			}
+		}
+		if !strings.Contains(rows[1], "[feature]") || !strings.Contains(rows[1], "stopped") {
+			t.Errorf("width %d: compact row should include branch and status: %q", width, rows[1])
+		}
+
+		// Paths start in the same column on every row
+		if width >= 80 {
+			col0 := lipgloss.Width(rows[0][:strings.Index(rows[0], "/src/alpha")])
+			col1 := lipgloss.Width(rows[1][:strings.Index(rows[1], "/src/beta")])
+			if col0 != col1 {
+				t.Errorf("width %d: paths are not aligned:\n%s\n%s", width, rows[0], rows[1])
+			}
+		}
+	}
+}
+
+func TestSessionLaunchCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		firstOnly bool
+		existing  int
+		want      string
+	}{
+		{"every session, none running", false, 0, "claude"},
+		{"every session, some running", false, 2, "claude"},
+		{"first only, none running", true, 0, "claude"},
+		{"first only, some running", true, 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sessionLaunchCommand("claude", tt.firstOnly, tt.existing); got != tt.want {
+				t.Errorf("sessionLaunchCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth int
+		want     string
+	}{
+		{"fits", "short line", 20, "short line"},
+		{"wraps at words", "one two three four", 9, "one two\nthree\nfour"},
+		{"keeps paragraphs", "a b\n\nc d", 10, "a b\n\nc d"},
+		{"breaks long words", "abcdefghij", 4, "abcd\nefgh\nij"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapText(tt.text, tt.maxWidth); got != tt.want {
+				t.Errorf("wrapText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapText_StyledWidth(t *testing.T) {
+	styled := ErrorStyle.Render("Error:") + " container failed to start"
+	for _, line := range strings.Split(wrapText(styled, 20), "\n") {
+		if w := lipgloss.Width(line); w > 20 {
+			t.Errorf("line %q is %d columns wide, want <= 20", line, w)
+		}
+	}
+}
+
+func TestRenderError_Wraps(t *testing.T) {
+	err := fmt.Errorf("container failed to start: %s", strings.Repeat("stderr output ", 20))
+	result := RenderError(err, "", false, 0, 60, 0)
+	for _, line := range strings.Split(result, "\n") {
+		if strings.Contains(line, "stderr") && lipgloss.Width(line) > 56 {
+			t.Errorf("error line is %d columns wide, want <= 56: %q", lipgloss.Width(line), line)
+		}
+	}
+	if strings.Contains(result, "Lines ") {
+		t.Error("should not scroll when no height is known")
+	}
+}
+
+func TestRenderError_Scrolls(t *testing.T) {
+	err := fmt.Errorf("%slast", strings.Repeat("line\n", 30))
+	result := RenderError(err, "", false, 0, 60, 20)
+	if !strings.Contains(result, "Lines 1-8 of 31") {
+		t.Errorf("should show the visible range:\n%s", result)
+	}
+	if strings.Contains(result, "last") {
+		t.Error("the last line should be scrolled out of view")
+	}
+
+	result = RenderError(err, "", false, 100, 60, 20)
+	if !strings.Contains(result, "last") || !strings.Contains(result, "Lines 24-31 of 31") {
+		t.Errorf("scroll should clamp to the end:\n%s", result)
+	}
+}
+
+func TestRenderError_TailoredHints(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"daemon down", fmt.Errorf("failed to start container: %w: Cannot connect", devcontainer.ErrDaemonUnreachable), "Start Docker"},
+		{"pull failed", fmt.Errorf("failed to start container: %w: pull access denied", devcontainer.ErrImagePullFailed), "docker login"},
+		{"no container", fmt.Errorf("%w running for project", devcontainer.ErrNoContainer), "may have been removed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := RenderError(tt.err, "", false, 0, 120, 0); !strings.Contains(result, tt.want) {
+				t.Errorf("RenderError should contain hint %q:\n%s", tt.want, result)
+			}
+		})
+	}
+
+	if hint := errorHint(fmt.Errorf("something else")); hint != "" {
+		t.Errorf("errorHint() = %q for an unclassified error, want none", hint)
+	}
+}
+
+func TestRenderDashboard_GroupHeaders(t *testing.T) {
+	instances := []devcontainer.ContainerInstanceWithStatus{
+		{ContainerInstance: devcontainer.ContainerInstance{
+			Project:  devcontainer.Project{Name: "app", Path: "/src/app"},
+			Worktree: &devcontainer.WorktreeInfo{MainRepo: "/src/app", IsMain: true},
+		}},
+		{ContainerInstance: devcontainer.ContainerInstance{
+			Project:  devcontainer.Project{Name: "app", Path: "/src/app-feature"},
+			Worktree: &devcontainer.WorktreeInfo{MainRepo: "/src/app", Branch: "feature"},
+		}},
+		{ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "solo", Path: "/src/solo"}}},
+	}
+
+	for _, compact := range []bool{false, true} {
+		result := RenderDashboard(instances, nil, nil, 0, DashboardOptions{Compact: compact, GroupWorktrees: true}, 80, "", "")
+		var headers []string
+		for _, line := range strings.Split(result, "\n") {
+			if strings.TrimSpace(line) == "app" {
+				headers = append(headers, line)
+			}
+			if strings.TrimSpace(line) == "solo" {
+				t.Errorf("compact=%v: single-instance group should not get a header", compact)
+			}
+		}
+		if len(headers) != 1 {
+			t.Errorf("compact=%v: got %d group headers, want 1:\n%s", compact, len(headers), result)
+		}
+
+		plain := RenderDashboard(instances, nil, nil, 0, DashboardOptions{Compact: compact}, 80, "", "")
+		for _, line := range strings.Split(plain, "\n") {
+			if strings.TrimSpace(line) == "app" {
+				t.Errorf("compact=%v: headers should only show when grouping is enabled", compact)
+			}
+		}
+	}
+}
+
+func TestRenderDashboard_FullPaths(t *testing.T) {
+	long := "/home/user/" + strings.Repeat("nested/", 20) + "project"
+	instances := []devcontainer.ContainerInstanceWithStatus{
+		{ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "project", Path: long}}},
+	}
+
+	for _, compact := range []bool{false, true} {
+		truncated := RenderDashboard(instances, nil, nil, 0, DashboardOptions{Compact: compact}, 60, "", "")
+		if strings.Contains(truncated, long) {
+			t.Errorf("compact=%v: paths should be truncated to the width by default", compact)
+		}
+
+		full := RenderDashboard(instances, nil, nil, 0, DashboardOptions{Compact: compact, FullPaths: true}, 60, "", "")
+		if !strings.Contains(full, long) {
+			t.Errorf("compact=%v: full_paths should show the whole path:\n%s", compact, full)
+		}
+		if !strings.Contains(full, "unknown") {
+			t.Errorf("compact=%v: the status should still follow an overflowing path:\n%s", compact, full)
+		}
+	}
+}
+
+func TestRenderDiscovering_Estimate(t *testing.T) {
+	if view := RenderDiscovering("*", 50, time.Second, devcontainer.DiscoveryStats{}); strings.Contains(view, "%") {
+		t.Errorf("no estimate expected without a previous run:\n%s", view)
+	}
+
+	last := devcontainer.DiscoveryStats{Walked: 200, Duration: 10 * time.Second}
+	view := RenderDiscovering("*", 50, 4*time.Second, last)
+	for _, want := range []string{"~25%", "about 6s left", "50 of ~200 entries"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+
+	// A run walking more than the last one never claims to be done
+	if view := RenderDiscovering("*", 300, 12*time.Second, last); !strings.Contains(view, "~99%") || strings.Contains(view, "left") {
+		t.Errorf("overrunning the last run should show 99%% and no time left:\n%s", view)
+	}
+}
+
+func TestRenderDashboard_InstanceLimitNotice(t *testing.T) {
+	instances := []devcontainer.ContainerInstanceWithStatus{
+		{ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app", Path: "/src/app"}}},
+	}
+
+	if view := RenderDashboard(instances, nil, nil, 0, DashboardOptions{}, 80, "", ""); strings.Contains(view, "max_instances") {
+		t.Errorf("no notice expected when discovery found everything:\n%s", view)
+	}
+	view := RenderDashboard(instances, nil, nil, 0, DashboardOptions{InstanceLimit: 1}, 80, "", "")
+	if !strings.Contains(view, "Showing the first 1 of many projects") {
+		t.Errorf("expected a notice that the list was cut short:\n%s", view)
+	}
+}
+
+func TestRenderDashboard_LastCommit(t *testing.T) {
+	instances := []devcontainer.ContainerInstanceWithStatus{
+		{ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "app", Path: "/src/app"}},
+			LastCommit: time.Now().Add(-50 * time.Hour)},
+		{ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "tmpl", Path: "/src/tmpl"}}},
+	}
+
+	for _, compact := range []bool{false, true} {
+		view := RenderDashboard(instances, nil, nil, 0, DashboardOptions{Compact: compact}, 100, "", "")
+		if strings.Count(view, " ago") != 1 || !strings.Contains(view, "2d ago") {
+			t.Errorf("compact=%v: expected only the first instance to show its last commit age:\n%s", compact, view)
+		}
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{30 * time.Second, "just now"},
+		{5 * time.Minute, "5m ago"},
+		{3 * time.Hour, "3h ago"},
+		{49 * time.Hour, "2d ago"},
+		{90 * 24 * time.Hour, "3mo ago"},
+		{800 * 24 * time.Hour, "2y ago"},
+	}
+	for _, tt := range tests {
+		if got := formatAge(tt.age); got != tt.want {
+			t.Errorf("formatAge(%v) = %q, want %q", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestRenderDashboard_CollapsedGroup(t *testing.T) {
+	instances := []devcontainer.ContainerInstanceWithStatus{
+		{ContainerInstance: devcontainer.ContainerInstance{
+			Project:  devcontainer.Project{Name: "app", Path: "/src/app"},
+			Worktree: &devcontainer.WorktreeInfo{MainRepo: "/src/app", IsMain: true},
+		}, Status: devcontainer.StatusRunning},
+		{ContainerInstance: devcontainer.ContainerInstance{
+			Project:  devcontainer.Project{Name: "app", Path: "/src/app-feature"},
+			Worktree: &devcontainer.WorktreeInfo{MainRepo: "/src/app", Branch: "feature"},
+		}},
+		{ContainerInstance: devcontainer.ContainerInstance{Project: devcontainer.Project{Name: "solo", Path: "/src/solo"}}},
+	}
+	collapsed := map[string]bool{"/src/app": true}
+
+	for _, compact := range []bool{false, true} {
+		result := RenderDashboard(instances, nil, nil, 1, DashboardOptions{Compact: compact, GroupWorktrees: true, Collapsed: collapsed}, 80, "", "")
+		if !strings.Contains(result, "▸ app (2 worktrees)") || !strings.Contains(result, "1 running") {
+			t.Errorf("compact=%v: folded group should show its count and running containers:\n%s", compact, result)
+		}
+		if strings.Contains(result, "/src/app-feature") {
+			t.Errorf("compact=%v: folded group members should be hidden:\n%s", compact, result)
+		}
+		if !strings.Contains(result, "solo") {
+			t.Errorf("compact=%v: other projects should still show:\n%s", compact, result)
+		}
+
+		plain := RenderDashboard(instances, nil, nil, 0, DashboardOptions{Compact: compact, Collapsed: collapsed}, 80, "", "")
+		if strings.Contains(plain, "worktrees)") {
+			t.Errorf("compact=%v: groups should only fold when grouping is enabled", compact)
+		}
+	}
+}
+
+func TestRenderDashboard_WarningBanner(t *testing.T) {
+	instances := testInstances("/src/alpha")
+
+	short := RenderDashboard(instances, nil, nil, 0, DashboardOptions{}, 80, "push failed", "")
+	if !strings.Contains(short, "Warning: push failed") || !strings.Contains(short, "esc dismiss") {
+		t.Errorf("short warning should be shown in full with a dismiss hint:\n%s", short)
+	}
+	if strings.Contains(short, "W view full warning") {
+		t.Error("short warning should not offer the full view")
+	}
+
+	long := strings.Repeat("credential lookup failed; ", 10)
+	result := RenderDashboard(instances, nil, nil, 0, DashboardOptions{}, 80, long, "")
+	if !strings.Contains(result, "W view full warning") {
+		t.Errorf("truncated warning should offer the full view:\n%s", result)
+	}
+	for _, line := range strings.Split(result, "\n") {
+		if strings.Contains(line, "Warning:") && lipgloss.Width(line) > 76 {
+			t.Errorf("warning banner is %d columns wide, want at most 76", lipgloss.Width(line))
+		}
+	}
+}
+
+func TestRenderWarningDetail(t *testing.T) {
+	result := RenderWarningDetail("first problem; second problem", 0, 40, 80)
+	lines := strings.Split(result, "\n")
+	var first, second int
+	for i, line := range lines {
+		if strings.Contains(line, "first problem") {
+			first = i
+		}
+		if strings.Contains(line, "second problem") {
+			second = i
+		}
+	}
+	if first == 0 || second != first+1 {
+		t.Errorf("joined warnings should render one per line:\n%s", result)
+	}
+}
+
+func TestSetNoColor(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	if !strings.Contains(TitleStyle.Render("x"), "\x1b[") {
+		t.Fatal("expected colored output before SetNoColor")
+	}
+
+	SetNoColor()
+	ApplyTheme(true)
+	result := RenderDashboard(testInstances("/src/alpha"), nil, nil, 0, DashboardOptions{}, 80, "push failed", "")
+	if strings.Contains(result, "\x1b[") {
+		t.Errorf("no-color output should not contain escape sequences: %q", result)
+	}
+}
+
+func TestRenderDashboard_FooterCapabilities(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	t.Cleanup(func() {
+		lipgloss.SetColorProfile(profile)
+		ApplyTheme(true)
+	})
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	ApplyTheme(true)
+
+	instances := testInstances("/src/plain", "/src/app", "/src/app-feature")
+	instances[1].Worktree = &devcontainer.WorktreeInfo{Branch: "main", IsMain: true}
+	instances[2].Worktree = &devcontainer.WorktreeInfo{Branch: "feature"}
+
+	disabled := func(key, desc string) string { return DisabledStyle.Render(key + " " + desc) }
+	tests := []struct {
+		cursor       int
+		wantDisabled []string
+		wantEnabled  []string
+	}{
+		{0, []string{disabled("n", "new"), disabled("d", "delete"), disabled("c", "commits"), disabled("g", "issues")}, nil},
+		{1, []string{disabled("d", "delete")}, []string{RenderKeyBinding("n", "new"), RenderKeyBinding("g", "issues")}},
+		{2, nil, []string{RenderKeyBinding("n", "new"), RenderKeyBinding("d", "delete"), RenderKeyBinding("c", "commits"), RenderKeyBinding("g", "issues")}},
+	}
+
+	for _, tt := range tests {
+		result := RenderDashboard(instances, nil, nil, tt.cursor, DashboardOptions{}, 100, "", "")
+		for _, want := range tt.wantDisabled {
+			if !strings.Contains(result, want) {
+				t.Errorf("cursor %d: footer should gray out %q", tt.cursor, want)
+			}
+		}
+		for _, want := range tt.wantEnabled {
+			if !strings.Contains(result, want) {
+				t.Errorf("cursor %d: footer should show %q as available", tt.cursor, want)
+			}
+		}
+	}
+}
+
+func TestRenderGitHubIssuesList_StateFilter(t *testing.T) {
+	result := RenderGitHubIssuesList(nil, nil, 0, "acme", "app", github.IssueStateClosed, "", "", 0, 100)
+	for _, want := range []string{"acme/app (closed)", "No closed issues found", "o to change"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("issues list should contain %q", want)
+		}
+	}
+}
+
+func TestRenderGitHubIssuesList_SavedQuery(t *testing.T) {
+	result := RenderGitHubIssuesList(nil, nil, 0, "acme", "app", github.IssueStateOpen, "my bugs", "", 0, 100)
+	for _, want := range []string{"acme/app (open, my bugs)", `match "my bugs"`, "s to change the query"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("issues list should contain %q", want)
+		}
+	}
+}
+
+func TestRenderIssueRow_TitleWidth(t *testing.T) {
+	title := strings.Repeat("word ", 19) + "end" // 98 cells
+	tests := []struct {
+		name     string
+		titleMax int
+		width    int
+		wantFull bool
+	}{
+		{"wide terminal fits the whole title", 0, 130, true},
+		{"title just fits", 0, 98 + 4 + 6 + 2 + 4 + 1, true},
+		{"one cell short", 0, 98 + 4 + 6 + 2 + 4, false},
+		{"narrow terminal", 0, 40, false},
+		{"cap on a wide terminal", 30, 200, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			renderIssueRow(&b, github.Issue{Number: 1, Title: title, State: github.IssueStateOpen}, false, false, tt.titleMax, tt.width)
+			line := strings.TrimSuffix(b.String(), "\n")
+			if got := strings.Contains(line, title); got != tt.wantFull {
+				t.Errorf("full title shown = %v, want %v: %q", got, tt.wantFull, line)
+			}
+			if !tt.wantFull && !strings.Contains(line, "...") {
+				t.Errorf("cut title should end with ...: %q", line)
+			}
+			if w := lipgloss.Width(line); w > tt.width {
+				t.Errorf("row is %d cells wide, want at most %d", w, tt.width)
+			}
+			if tt.titleMax > 0 && strings.Contains(line, title[:tt.titleMax]) {
+				t.Errorf("title should be cut to %d cells: %q", tt.titleMax, line)
+			}
+		})
+	}
+}
+
+func TestTruncateToWidth_WideRunes(t *testing.T) {
+	got := truncateToWidth("日本語のタイトル", 9)
+	if w := lipgloss.Width(got); w > 9 || !strings.HasSuffix(got, "...") {
+		t.Errorf("truncateToWidth() = %q (%d cells), want at most 9 cells ending in ...", got, w)
+	}
+	if got := truncateToWidth("short", 9); got != "short" {
+		t.Errorf("truncateToWidth() = %q, want the text unchanged", got)
+	}
+}
+
+func TestRenderSavedQueryPicker(t *testing.T) {
+	queries := map[string]string{"my bugs": "label:bug assignee:@me"}
+	result := RenderSavedQueryPicker(queries, "my bugs", 1, 100)
+	for _, want := range []string{"(none)", "my bugs ✓", "label:bug assignee:@me"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("query picker should contain %q", want)
+		}
+	}
+}
+
+func TestRenderError_RetryHint(t *testing.T) {
+	err := fmt.Errorf("failed to start container")
+	if result := RenderError(err, "", true, 0, 80, 0); !strings.Contains(result, "r to retry") {
+		t.Error("retryable errors should advertise r")
+	}
+	if result := RenderError(err, "", false, 0, 80, 0); strings.Contains(result, "retry") {
+		t.Error("other errors should not advertise r")
+	}
+}
+
+func TestRenderDashboard_Unmanaged(t *testing.T) {
+	instances := testInstances("/src/app")
+	instances[0].Status = devcontainer.StatusRunning
+	instances[0].Unmanaged = true
+	for _, opts := range []DashboardOptions{{}, {Compact: true}} {
+		result := RenderDashboard(instances, nil, nil, 0, opts, 120, "", "")
+		if !strings.Contains(result, "A to adopt") {
+			t.Errorf("compact=%v: dashboard should offer to adopt an unmanaged container", opts.Compact)
+		}
+	}
+}
+
+func TestRenderDashboard_Profile(t *testing.T) {
+	result := RenderDashboard(testInstances("/src/app"), nil, nil, 0, DashboardOptions{Profile: "work"}, 100, "", "")
+	if !strings.Contains(result, "profile: work") {
+		t.Error("dashboard header should show the active profile")
+	}
+}
+
+func TestRenderDashboard_Flash(t *testing.T) {
+	result := RenderDashboard(testInstances("/src/app"), nil, nil, 0, DashboardOptions{}, 80, "", "Copied exec command")
+	if !strings.Contains(result, "Copied exec command") {
+		t.Error("dashboard should show the flash message")
+	}
+}
+
+func TestRenderDashboard_NewInstance(t *testing.T) {
+	instances := testInstances("/src/app", "/src/api")
+	opts := DashboardOptions{New: map[string]bool{"/src/api": true}}
+	for _, compact := range []bool{false, true} {
+		opts.Compact = compact
+		result := RenderDashboard(instances, nil, nil, 0, opts, 120, "", "")
+		if strings.Count(result, "NEW") != 1 {
+			t.Errorf("compact=%v: want one NEW tag:\n%s", compact, result)
+		}
+	}
+}
+
+func TestRenderConfigDisplay_Sources(t *testing.T) {
+	result := RenderConfigDisplay(config.DefaultConfig())
+	if !strings.Contains(result, "Max Depth: 3 (default)") {
+		t.Error("config view should mark default values")
+	}
+	if !strings.Contains(result, "all other settings are defaults") {
+		t.Error("config view should say nothing else was set in the file")
+	}
+}
+
+func TestRenderGitHubIssuesList_ErrorFlash(t *testing.T) {
+	m := Model{state: StateGitHubIssuesList}
+	newModel, _ := m.Update(clipboardCopiedMsg{err: fmt.Errorf("no clipboard utility found")})
+	m = newModel.(Model)
+	if !m.flashError || m.styledFlash() != ErrorStyle.Render("no clipboard utility found") {
+		t.Errorf("copy failure flash = %q, want it styled as an error", m.styledFlash())
+	}
+
+	newModel, _ = m.Update(clipboardCopiedMsg{label: "issue URL"})
+	if m = newModel.(Model); m.flashError {
+		t.Error("a successful copy should not be styled as an error")
+	}
+}
+
+func TestJoinNotice(t *testing.T) {
+	tests := []struct {
+		notice, extra, want string
+	}{
+		{"", "", ""},
+		{"push failed", "", "push failed"},
+		{"", "post-create command failed", "post-create command failed"},
+		{"push failed", "post-create command failed", "push failed; post-create command failed"},
+	}
+
+	for _, tt := range tests {
+		if got := joinNotice(tt.notice, tt.extra); got != tt.want {
+			t.Errorf("joinNotice(%q, %q) = %q, want %q", tt.notice, tt.extra, got, tt.want)
+		}
+	}
+}
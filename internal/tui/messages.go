@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"github.com/christophergyman/claude-quick/internal/auth"
 	"github.com/christophergyman/claude-quick/internal/devcontainer"
 	"github.com/christophergyman/claude-quick/internal/github"
 )
@@ -11,6 +12,9 @@ import (
 // instancesDiscoveredMsg is sent when project discovery completes
 type instancesDiscoveredMsg struct {
 	instances []devcontainer.ContainerInstance
+	truncated bool            // More projects were found than max_instances allows
+	previous  map[string]bool // Instance paths found by the previous run (nil if none, or truncated)
+	compared  bool            // previous was loaded and this run saved in its place
 }
 
 // instanceStatusRefreshedMsg is sent when container status refresh completes
@@ -18,10 +22,35 @@ type instanceStatusRefreshedMsg struct {
 	statuses []devcontainer.ContainerInstanceWithStatus
 }
 
+// sessionPipedMsg is sent after a session's output is piped to a file
+type sessionPipedMsg struct {
+	session string
+	outPath string
+	err     error
+}
+
+// containerAdoptedMsg is sent after credentials are written for a container
+// started outside claude-quick
+type containerAdoptedMsg struct {
+	path         string
+	authWarning  string
+	authFailures []auth.CredentialError
+}
+
+// containerStartCancelledMsg is sent when the user cancels a container start
+type containerStartCancelledMsg struct{}
+
 // containerStartedMsg is sent when a container finishes starting
 type containerStartedMsg struct {
-	// authWarning contains any auth credential resolution warnings (empty if none)
+	// path is the instance that was started
+	path string
+	// authWarning describes a failure to write resolved credentials (empty if none)
 	authWarning string
+	// workspaceWarning describes a workspace folder not backed by the
+	// instance path (empty if it matches)
+	workspaceWarning string
+	// authFailures lists credentials that could not be resolved
+	authFailures []auth.CredentialError
 }
 
 // containerErrorMsg is sent when any container operation fails
@@ -31,7 +60,7 @@ type containerErrorMsg struct{ err error }
 type tmuxSessionsLoadedMsg struct{ sessions []string }
 
 // tmuxSessionCreatedMsg is sent when a new tmux session is created
-type tmuxSessionCreatedMsg struct{}
+type tmuxSessionCreatedMsg struct{ name string }
 
 // containerStoppedMsg is sent when a container is stopped
 type containerStoppedMsg struct{}
@@ -48,40 +77,178 @@ type tmuxSessionRestartedMsg struct{}
 // tmuxDetachedMsg is sent when user detaches from tmux
 type tmuxDetachedMsg struct{}
 
+// worktreeMovedMsg is sent when a worktree has been moved to path.
+// restart is set if its container was running and should start at the new path.
+type worktreeMovedMsg struct {
+	path    string
+	restart bool
+}
+
+// instanceRefreshedMsg is sent with the container status and tmux session count of one instance
+type instanceRefreshedMsg struct {
+	path   string
+	status devcontainer.ContainerStatus
+	count  int // -1 if sessions weren't counted
+}
+
+// restartSessionsLoadedMsg is sent with the tmux sessions a restart would interrupt
+type restartSessionsLoadedMsg struct {
+	path     string
+	sessions []string
+}
+
+// deleteChecksLoadedMsg is sent with what deleting a worktree would lose or
+// interrupt: its branch's unpushed commits and a running container
+type deleteChecksLoadedMsg struct {
+	path     string
+	unpushed int  // -1 if the branch could not be checked; 0 if it is kept
+	running  bool // The container is running and a plain delete (d) will stop it
+	discard  bool // Confirm stopping the container too (D) rather than a plain delete (d)
+}
+
+// worktreeListLoadedMsg is sent with the linked worktrees of the selected repository
+type worktreeListLoadedMsg struct {
+	path      string
+	worktrees []devcontainer.WorktreeInfo
+	err       error
+}
+
 // worktreeCreatedMsg is sent when a new git worktree is created
 type worktreeCreatedMsg struct {
 	worktreePath string
-	pushWarning  string
+	notice       string
 }
 
 // worktreeDeletedMsg is sent when a git worktree is deleted
-type worktreeDeletedMsg struct{}
+type worktreeDeletedMsg struct {
+	branch string // Local branch deleted along with it ("" if kept)
+}
+
+// worktreeCommitsLoadedMsg is sent when the commits since the base branch are loaded
+type worktreeCommitsLoadedMsg struct {
+	base    string
+	commits []string
+}
+
+// worktreeDiskUsageMsg is sent when a worktree's disk usage has been measured
+type worktreeDiskUsageMsg struct {
+	path string
+	size int64
+	err  error
+}
+
+// containerLogsLoadedMsg is sent when a container's logs have been read
+type containerLogsLoadedMsg struct {
+	logs  string
+	pager []string // Pager command and arguments (nil to use the in-TUI viewer)
+}
+
+// shellExitedMsg is sent when a shell opened with openShell exits
+type shellExitedMsg struct {
+	err      error
+	returnTo State // Screen the shell was opened from
+}
+
+// pagerClosedMsg is sent when the external pager exits
+type pagerClosedMsg struct{ err error }
 
 // githubIssuesLoadedMsg is sent when GitHub issues are successfully fetched
 type githubIssuesLoadedMsg struct {
-	issues []github.Issue
-	owner  string
-	repo   string
+	issues  []github.Issue
+	owner   string
+	repo    string
+	request int // The gh request this answers
+}
+
+// githubIssueCreatedMsg is sent when a new issue is created with gh
+type githubIssueCreatedMsg struct {
+	number  int
+	request int // The gh request this answers
 }
 
 // githubIssuesErrorMsg is sent when fetching GitHub issues fails
 type githubIssuesErrorMsg struct {
-	err error
+	err     error
+	request int // The gh request this answers
 }
 
 // githubIssueDetailLoadedMsg is sent when a single issue's details are loaded
 type githubIssueDetailLoadedMsg struct {
-	body string
+	body    string
+	request int // The gh request this answers
 }
 
 // githubWorktreeCreatedMsg is sent when worktree creation from issue succeeds
 type githubWorktreeCreatedMsg struct {
 	worktreePath string
 	branchName   string
-	pushWarning  string
+	notice       string
 	labelWarning string // Warning if label addition failed
 }
 
+// issueWorktreeResult holds the outcome of creating a worktree for one issue
+type issueWorktreeResult struct {
+	issueNumber  int
+	worktreePath string
+	branchName   string
+	notice       string
+	labelWarning string
+	err          error
+}
+
+// githubBatchWorktreesCreatedMsg is sent when worktree creation for multiple issues finishes
+type githubBatchWorktreesCreatedMsg struct {
+	results []issueWorktreeResult
+}
+
+// imageCheckedMsg is sent when a container's image has been compared against its latest local tag
+type imageCheckedMsg struct {
+	path   string
+	status devcontainer.ImageStatus
+}
+
+// cloneProgressMsg carries the latest progress line from a running git clone
+type cloneProgressMsg struct{ line string }
+
+// repoClonedMsg is sent when a repository has been cloned into a search path
+type repoClonedMsg struct{ path string }
+
+// favoritesSavedMsg is sent after favorites are written to the config file
+type favoritesSavedMsg struct{ err error }
+
+// searchPathAddedMsg is sent after a search path added from the empty
+// dashboard is validated and saved to the config file
+type searchPathAddedMsg struct {
+	path  string
+	paths []string // Search paths including the new one
+	err   error
+}
+
+// devcontainerJSONLoadedMsg is sent when an instance's devcontainer.json has been read
+type devcontainerJSONLoadedMsg struct {
+	path      string
+	content   string
+	truncated bool // The file exceeded MaxConfigViewBytes
+	err       error
+}
+
+// themeSaveDueMsg fires after a theme toggle settles; stale ids are ignored
+type themeSaveDueMsg struct{ id int }
+
+// themeSavedMsg is sent after the toggled theme is written to the config file
+type themeSavedMsg struct{ err error }
+
+// clipboardCopiedMsg is sent when a clipboard copy finishes
+type clipboardCopiedMsg struct {
+	label string // What was copied (e.g., "issue URL")
+	err   error
+}
+
+// flashExpiredMsg is sent when a transient status message should be cleared
+type flashExpiredMsg struct {
+	id int // Matches Model.flashID so newer messages aren't cleared early
+}
+
 // tmuxNotFoundError indicates tmux is not available in the container
 type tmuxNotFoundError struct{}
 
@@ -104,3 +271,9 @@ type wizardPathValidatedMsg struct {
 	path   string
 	exists bool
 }
+
+// wizardCredentialValidatedMsg is sent when a credential source has been validated
+type wizardCredentialValidatedMsg struct {
+	key     string // credentialKey of the validated credential
+	warning string // Empty when the source looks usable
+}
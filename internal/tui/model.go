@@ -1,9 +1,16 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os/exec"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -24,29 +31,115 @@ type Model struct {
 	instancesStatus  []devcontainer.ContainerInstanceWithStatus
 	selectedInstance *devcontainer.ContainerInstance
 	tmuxSessions     []tmux.Session
+	restartSessions  []tmux.Session // Sessions the pending restart would interrupt
+	unpushedCommits  int            // Commits only on the branch a worktree delete would remove; -1 if unknown
+	stopsContainer   bool           // The worktree being deleted has a running container that will be stopped
 	selectedSession  *tmux.Session
 	cursor           int
 	spinner          spinner.Model
 	textInput        textinput.Model
 	worktreeInput    textinput.Model
+	worktreeBase     string // Branch the new worktree starts from ("" for the main repo's HEAD)
 	err              error
 	errHint          string
+	errScroll        int     // Index of the first visible line of a long error
+	retry            retryOp // Failed operation r re-runs from the error screen
 	width            int
 	height           int
 	config           *config.Config
 	previousState    State
-	warning          string // Warning message (auth, push failures, etc.)
-	darkMode         bool   // Current theme mode (true = dark, false = light)
+	warning          string    // Warning message (auth, push failures, etc.)
+	flash            string    // Transient status message (e.g., "Copied issue URL")
+	flashID          int       // Incremented per flash so stale expiry ticks are ignored
+	flashError       bool      // The flash reports a failure
+	darkMode         bool      // Current theme mode (true = dark, false = light)
+	themeSaveID      int       // Incremented per theme toggle so only the last one is saved
+	stopPressedAt    time.Time // When x last opened the stop dialog, for the double-press quick stop
+	lastRefresh      time.Time // When container status was last refreshed, for refresh_on_focus_seconds
 
 	// GitHub Issues state
-	githubIssues    []github.Issue  // Cached list of issues
-	selectedIssue   *github.Issue   // Currently selected issue
-	githubRepoOwner string          // Detected owner (e.g., "christophergyman")
-	githubRepoName  string          // Detected repo name (e.g., "claude-quick")
+	githubIssues    []github.Issue     // Cached list of issues
+	selectedIssue   *github.Issue      // Currently selected issue
+	markedIssues    map[int]bool       // Issue numbers marked for batch worktree creation
+	githubRepoOwner string             // Detected owner (e.g., "christophergyman")
+	githubRepoName  string             // Detected repo name (e.g., "claude-quick")
+	githubCancel    context.CancelFunc // Cancels the in-flight gh request (nil if none)
+	githubRequest   int                // Incremented per gh request so late results of earlier ones are dropped
+	issueState      github.IssueState  // State filter chosen with o ("" uses github.default_state)
+	issueQuery      string             // Saved query chosen with s ("" for none)
+	queryCursor     int                // Selected row in the saved query picker
+	profileCursor   int                // Selected row in the profile switcher
+	newIssueTitle   string             // Title of the issue being created with c
+	createdIssue    int                // Issue created with c, selected once the list reloads
+
+	// Container start state
+	startCtx    context.Context    // Bounds the in-flight devcontainer up (nil if none)
+	startCancel context.CancelFunc // Cancels startCtx, killing devcontainer up
+
+	// Worktree commits panel state
+	worktreeCommits []string // Commits on the selected branch since its base
+	commitsBase     string   // Base ref the commits are compared against
+	commitsScroll   int      // Index of the first visible commit
+	worktreeSize    string   // Rendered disk usage ("" while it's being measured)
+
+	// Worktree sub-menu state
+	worktreeList   []devcontainer.WorktreeInfo // Linked worktrees of the selected repository
+	worktreeCursor int                         // Selected row in worktreeList
+
+	// Container logs viewer state (used when no pager is available)
+	logLines  []string // Log output split into lines, oldest first
+	logScroll int      // Index of the first visible log line
+
+	// devcontainer.json viewer state
+	jsonLines     []string // File contents split into lines
+	jsonTruncated bool     // Only the first MaxConfigViewBytes are shown
+	jsonScroll    int      // Index of the first visible line
+
+	// Warning detail panel
+	warningScroll int // Index of the first visible warning line
+
+	// Image update state (computed on demand, keyed by instance path)
+	imageStatus map[string]devcontainer.ImageStatus
+
+	// Worktree groups folded to one dashboard row, keyed by main-repo path
+	collapsedGroups map[string]bool
+
+	// Set when discovery stopped at max_instances with more projects left
+	instancesTruncated bool
+
+	// Set after f on the dashboard: the next letter jumps to a project
+	jumpPending bool
+
+	// Which instances the dashboard lists, cycled with F
+	visibility instanceVisibility
+
+	// Instance paths the previous discovery run didn't find, tagged NEW
+	newInstances map[string]bool
+	// Set once a discovery has been compared with the last run's snapshot and
+	// saved it; later discoveries in this process leave both alone
+	snapshotTaken bool
+
+	// Progress of the running discovery, shared with its goroutine
+	discovery *discoveryProgress
+
+	// Clone state
+	cloneInput    textinput.Model // Repository URL input
+	cloneDest     string          // Directory the repository is being cloned into
+	cloneProgress string          // Latest progress line from git clone
+	cloneUpdates  <-chan tea.Msg  // Progress and completion messages from the running clone
+
+	// Credentials that failed to resolve on the last container start
+	authFailures []auth.CredentialError
+
+	// Activity log state
+	events      []eventEntry // Session activity log, oldest first
+	eventScroll int          // Index of the first visible event (newest first)
 
 	// Auto-start state (for GitHub issue worktree creation)
 	pendingAutoStart      bool   // Whether to auto-start after discovery
+	pendingAutoAttach     bool   // Whether to attach to the default session once the auto-started container is up
 	autoStartWorktreePath string // Path of newly created worktree to auto-start
+	pendingSingleAttach   bool   // Whether to skip the session list if the running container has exactly one session
 
 	// Wizard state for guided configuration setup
 	wizardSearchPaths   []string          // Editable search paths list
@@ -62,6 +155,7 @@ type Model struct {
 	wizardCursor        int               // Cursor for list navigation in wizard
 	wizardEditMode      bool              // Whether currently editing a field
 	wizardPathWarnings  map[string]bool   // Map of path -> exists (false means warning)
+	wizardCredWarnings  map[string]string // Map of credentialKey -> warning (empty means ok)
 	wizardFromDashboard bool              // Whether wizard was launched from dashboard
 }
 
@@ -81,6 +175,37 @@ func (m Model) getSessionName() string {
 	return m.selectedSession.Name
 }
 
+// defaultSessionName returns the name for a new session when none is given.
+// With session_name_from_branch, worktrees use their branch name.
+func (m Model) defaultSessionName() string {
+	if m.config.IsSessionNameFromBranch() {
+		if name := devcontainer.SanitizeSessionName(m.getWorktreeBranch()); name != "" {
+			return name
+		}
+	}
+	return m.config.DefaultSessionName
+}
+
+// newSessionPrefill returns the name the new-session input starts with: a
+// linked worktree's branch made tmux-safe. Returns "" (leaving the default as
+// the placeholder) for main repos, for branches that don't survive
+// sanitizing, and when a session of that name is already running.
+func (m Model) newSessionPrefill() string {
+	if m.selectedInstance == nil || m.selectedInstance.Worktree == nil || m.selectedInstance.Worktree.IsMain {
+		return ""
+	}
+	name := devcontainer.SanitizeSessionName(m.selectedInstance.Worktree.Branch)
+	if name == "" || strings.ContainsFunc(name, unicode.IsControl) {
+		return ""
+	}
+	for _, s := range m.tmuxSessions {
+		if s.Name == name {
+			return ""
+		}
+	}
+	return name
+}
+
 // getWorktreeBranch safely returns the selected worktree's branch name
 func (m Model) getWorktreeBranch() string {
 	if m.selectedInstance == nil || m.selectedInstance.Worktree == nil {
@@ -89,6 +214,417 @@ func (m Model) getWorktreeBranch() string {
 	return m.selectedInstance.Worktree.Branch
 }
 
+// getMarkedIssues returns the issues marked for batch creation, in list order
+func (m Model) getMarkedIssues() []github.Issue {
+	var marked []github.Issue
+	for _, issue := range m.githubIssues {
+		if m.markedIssues[issue.Number] {
+			marked = append(marked, issue)
+		}
+	}
+	return marked
+}
+
+// issueStateFilter returns the issue state to list: the one chosen in the
+// issues list, or the configured default
+func (m Model) issueStateFilter() github.IssueState {
+	if m.issueState != "" {
+		return m.issueState
+	}
+	return m.config.GitHub.DefaultState
+}
+
+// issueSearch returns the gh search string of the active saved query,
+// or "" when none is chosen
+func (m Model) issueSearch() string {
+	if m.issueQuery == "" {
+		return ""
+	}
+	return m.config.GitHub.SavedQueries[m.issueQuery]
+}
+
+// deletesBranch reports whether deleting the selected worktree also deletes
+// its local branch: delete_branch_with_worktree is on and it is on a branch
+func (m Model) deletesBranch() bool {
+	if m.config == nil || !m.config.IsDeleteBranchWithWorktree() {
+		return false
+	}
+	if m.selectedInstance == nil || m.selectedInstance.Worktree == nil {
+		return false
+	}
+	branch := m.selectedInstance.Worktree.Branch
+	return branch != "" && branch != "HEAD" && branch != constants.DefaultBranchUnknown
+}
+
+// worktreeInstance builds the instance for a linked worktree of the selected
+// repository, which shares the repository's devcontainer config
+func (m Model) worktreeInstance(wt devcontainer.WorktreeInfo) *devcontainer.ContainerInstance {
+	instance := *m.selectedInstance
+	instance.Path = wt.Path
+	instance.Worktree = &wt
+	return &instance
+}
+
+// showSessionCounts reports whether status refreshes should count tmux sessions
+func (m Model) showSessionCounts() bool {
+	return m.config == nil || m.config.IsShowSessionCounts()
+}
+
+// showLastCommit reports whether status refreshes read each git instance's last commit time
+func (m Model) showLastCommit() bool {
+	return m.config == nil || m.config.IsShowLastCommit()
+}
+
+// startGitHubRequest cancels any in-flight gh request and returns a context
+// bounded by the configured fetch timeout. Commands built afterwards tag their
+// results with the new request number.
+func (m *Model) startGitHubRequest() context.Context {
+	m.cancelGitHubRequest()
+	m.githubRequest++
+	timeout := time.Duration(m.config.GitHub.FetchTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = constants.DefaultGitHubTimeout * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	m.githubCancel = cancel
+	return ctx
+}
+
+// cancelGitHubRequest aborts the in-flight gh request, if any
+func (m *Model) cancelGitHubRequest() {
+	if m.githubCancel != nil {
+		m.githubCancel()
+		m.githubCancel = nil
+	}
+}
+
+// beginContainerStart cancels any in-flight container start and prepares a
+// context bounded by the configured container timeout for the next one.
+// startContainer runs under this context, so call it first.
+func (m *Model) beginContainerStart() {
+	m.cancelContainerStart()
+	timeout := constants.DefaultContainerTimeout * time.Second
+	if m.config != nil && m.config.ContainerTimeout > 0 {
+		timeout = time.Duration(m.config.ContainerTimeout) * time.Second
+	}
+	m.startCtx, m.startCancel = context.WithTimeout(context.Background(), timeout)
+}
+
+// cancelContainerStart aborts the in-flight container start, if any
+func (m *Model) cancelContainerStart() {
+	if m.startCancel != nil {
+		m.startCancel()
+		m.startCancel = nil
+		m.startCtx = nil
+	}
+}
+
+// dashboardOptions returns the dashboard layout settings from config
+func (m Model) dashboardOptions() DashboardOptions {
+	if m.config == nil {
+		return DashboardOptions{}
+	}
+	return DashboardOptions{
+		Compact:        m.config.IsCompactDashboard(),
+		GroupWorktrees: m.config.IsGroupWorktrees(),
+		Profile:        m.config.ActiveProfile(),
+		Collapsed:      m.dashboardCollapsed(),
+		FullPaths:      m.config.IsFullPaths(),
+		InstanceLimit:  m.instanceLimit(),
+		Visibility:     m.visibility,
+		New:            m.newInstances,
+	}
+}
+
+// diffDiscovery compares discovered instances with the previous run's paths,
+// returning the new instances' paths and the sorted paths no longer found.
+// Without a previous run nothing counts as new.
+func diffDiscovery(previous map[string]bool, instances []devcontainer.ContainerInstance) (added map[string]bool, removed []string) {
+	if previous == nil {
+		return nil, nil
+	}
+	found := make(map[string]bool, len(instances))
+	for _, instance := range instances {
+		found[instance.Path] = true
+		if !previous[instance.Path] {
+			if added == nil {
+				added = make(map[string]bool)
+			}
+			added[instance.Path] = true
+		}
+	}
+	for path := range previous {
+		if !found[path] {
+			removed = append(removed, path)
+		}
+	}
+	slices.Sort(removed)
+	return added, removed
+}
+
+// instanceLimit returns max_instances if discovery was cut short by it, or 0
+func (m Model) instanceLimit() int {
+	if !m.instancesTruncated || m.config == nil {
+		return 0
+	}
+	return m.config.MaxInstances
+}
+
+// presetSessions returns the configured preset sessions that aren't running yet
+func (m Model) presetSessions() []string {
+	if m.config == nil {
+		return nil
+	}
+	return missingPresets(m.tmuxSessions, m.config.PresetSessions)
+}
+
+// favoriteSet returns the favorited project paths as a set
+func (m Model) favoriteSet() map[string]bool {
+	if m.config == nil || len(m.config.Favorites) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(m.config.Favorites))
+	for _, path := range m.config.Favorites {
+		set[path] = true
+	}
+	return set
+}
+
+// sortFavoritesFirst moves favorited instances to the top, preserving relative order
+func sortFavoritesFirst(instances []devcontainer.ContainerInstanceWithStatus, favorites map[string]bool) {
+	sort.SliceStable(instances, func(i, j int) bool {
+		return favorites[instances[i].Path] && !favorites[instances[j].Path]
+	})
+}
+
+// worktreeGroupKey identifies the repository an instance belongs to.
+// Instances outside a git repository form their own group.
+func worktreeGroupKey(instance devcontainer.ContainerInstanceWithStatus) string {
+	if instance.Worktree != nil && instance.Worktree.MainRepo != "" {
+		return instance.Worktree.MainRepo
+	}
+	return instance.Path
+}
+
+// groupWorktrees makes instances of the same repository contiguous, with the
+// main worktree first. Groups keep the position of their first member.
+func groupWorktrees(instances []devcontainer.ContainerInstanceWithStatus) {
+	firstSeen := make(map[string]int, len(instances))
+	for i, inst := range instances {
+		if _, ok := firstSeen[worktreeGroupKey(inst)]; !ok {
+			firstSeen[worktreeGroupKey(inst)] = i
+		}
+	}
+	isMain := func(inst devcontainer.ContainerInstanceWithStatus) bool {
+		return inst.Worktree == nil || inst.Worktree.IsMain
+	}
+	sort.SliceStable(instances, func(i, j int) bool {
+		gi, gj := firstSeen[worktreeGroupKey(instances[i])], firstSeen[worktreeGroupKey(instances[j])]
+		if gi != gj {
+			return gi < gj
+		}
+		return isMain(instances[i]) && !isMain(instances[j])
+	})
+}
+
+// sortInstances orders the dashboard: favorites first, then (if enabled)
+// worktrees grouped by repository
+func (m Model) sortInstances() {
+	sortFavoritesFirst(m.instancesStatus, m.favoriteSet())
+	if m.config != nil && m.config.IsGroupWorktrees() {
+		groupWorktrees(m.instancesStatus)
+	}
+}
+
+// worktreeGroupBounds returns the half-open range of the contiguous group
+// containing instance i
+func worktreeGroupBounds(instances []devcontainer.ContainerInstanceWithStatus, i int) (start, end int) {
+	key := worktreeGroupKey(instances[i])
+	start, end = i, i+1
+	for start > 0 && worktreeGroupKey(instances[start-1]) == key {
+		start--
+	}
+	for end < len(instances) && worktreeGroupKey(instances[end]) == key {
+		end++
+	}
+	return start, end
+}
+
+// inCollapsedGroup reports whether instance i belongs to a folded group of
+// several worktrees, which the dashboard shows as a single row
+func inCollapsedGroup(instances []devcontainer.ContainerInstanceWithStatus, collapsed map[string]bool, i int) bool {
+	if !collapsed[worktreeGroupKey(instances[i])] {
+		return false
+	}
+	start, end := worktreeGroupBounds(instances, i)
+	return end-start > 1
+}
+
+// instanceVisibility selects which instances the dashboard lists by status
+type instanceVisibility int
+
+const (
+	showAllInstances     instanceVisibility = iota // Every discovered instance
+	showStartedInstances                           // Running and stopped, hiding never-started (unknown) ones
+	showRunningInstances                           // Running only
+)
+
+// next returns the visibility F cycles to
+func (v instanceVisibility) next() instanceVisibility {
+	return (v + 1) % 3
+}
+
+// String returns the footer label for the visibility
+func (v instanceVisibility) String() string {
+	switch v {
+	case showStartedInstances:
+		return "running+stopped"
+	case showRunningInstances:
+		return "running only"
+	}
+	return "all"
+}
+
+// shows reports whether an instance with the given status is listed
+func (v instanceVisibility) shows(status devcontainer.ContainerStatus) bool {
+	switch v {
+	case showStartedInstances:
+		return status != devcontainer.StatusUnknown
+	case showRunningInstances:
+		return status == devcontainer.StatusRunning
+	}
+	return true
+}
+
+// visibleRows returns the indexes of the instances the dashboard shows:
+// those visibility lists, with a folded group represented by its first listed member
+func visibleRows(instances []devcontainer.ContainerInstanceWithStatus, collapsed map[string]bool, visibility instanceVisibility) []int {
+	rows := make([]int, 0, len(instances))
+	for i := range instances {
+		if !visibility.shows(instances[i].Status) {
+			continue
+		}
+		// Folded groups are contiguous, so their row is taken if the previous
+		// row isn't already one of its members
+		if inCollapsedGroup(instances, collapsed, i) && len(rows) > 0 &&
+			worktreeGroupKey(instances[rows[len(rows)-1]]) == worktreeGroupKey(instances[i]) {
+			continue
+		}
+		rows = append(rows, i)
+	}
+	return rows
+}
+
+// dashboardCollapsed returns the folded worktree groups, or nil when grouping is off
+func (m Model) dashboardCollapsed() map[string]bool {
+	if m.config == nil || !m.config.IsGroupWorktrees() {
+		return nil
+	}
+	return m.collapsedGroups
+}
+
+// dashboardRows returns the dashboard's visible rows (see visibleRows)
+func (m Model) dashboardRows() []int {
+	return visibleRows(m.instancesStatus, m.dashboardCollapsed(), m.visibility)
+}
+
+// moveDashboardCursor moves the cursor delta visible rows, skipping the
+// hidden members of folded groups and instances the visibility filter hides
+func (m Model) moveDashboardCursor(delta int) Model {
+	rows := m.dashboardRows()
+	if next := cursorRow(rows, m.cursor) + delta; next >= 0 && next < len(rows) {
+		m.cursor = rows[next]
+	}
+	return m
+}
+
+// snapDashboardCursor moves a cursor left on an instance the visibility
+// filter hides to the nearest listed one before it (or the first)
+func (m Model) snapDashboardCursor() Model {
+	if m.visibility == showAllInstances || m.cursor >= len(m.instancesStatus) {
+		return m
+	}
+	if m.visibility.shows(m.instancesStatus[m.cursor].Status) {
+		return m
+	}
+	if rows := m.dashboardRows(); len(rows) > 0 {
+		m.cursor = rows[cursorRow(rows, m.cursor)]
+	}
+	return m
+}
+
+// allInstancesHidden reports whether the visibility filter hides every instance
+func (m Model) allInstancesHidden() bool {
+	return len(m.instancesStatus) > 0 && len(m.dashboardRows()) == 0
+}
+
+// focusRefreshDue reports whether refresh_on_focus_seconds is set and the
+// status shown is at least that old
+func (m Model) focusRefreshDue() bool {
+	if m.config == nil || m.config.FocusRefresh <= 0 || len(m.instances) == 0 {
+		return false
+	}
+	return time.Since(m.lastRefresh) >= time.Duration(m.config.FocusRefresh)*time.Second
+}
+
+// cursorRow returns the position in rows of the row holding cursor. The cursor
+// may sit on a hidden member (e.g. after detaching); it belongs to its group's row.
+func cursorRow(rows []int, cursor int) int {
+	current := 0
+	for n, i := range rows {
+		if i <= cursor {
+			current = n
+		}
+	}
+	return current
+}
+
+// showFlash displays a transient status message and schedules its removal
+func (m Model) showFlash(text string) (Model, tea.Cmd) {
+	m.flashID++
+	m.flash = text
+	m.flashError = false
+	id := m.flashID
+	return m, tea.Tick(constants.FlashMessageSeconds*time.Second, func(time.Time) tea.Msg {
+		return flashExpiredMsg{id: id}
+	})
+}
+
+// showErrorFlash shows a failure as a flash message, styled as an error
+func (m Model) showErrorFlash(text string) (Model, tea.Cmd) {
+	m, cmd := m.showFlash(text)
+	m.flashError = true
+	return m, cmd
+}
+
+// styledFlash returns the flash message styled for display, or "" if none
+func (m Model) styledFlash() string {
+	if m.flash == "" {
+		return ""
+	}
+	if m.flashError {
+		return ErrorStyle.Render(m.flash)
+	}
+	return SuccessStyle.Render(m.flash)
+}
+
+// toggleTheme switches between the dark and light themes. Unless
+// persist_theme_toggle is off, the choice is saved once toggling settles.
+func (m Model) toggleTheme() (tea.Model, tea.Cmd) {
+	m.darkMode = !m.darkMode
+	ApplyTheme(m.darkMode)
+	if m.config == nil || !m.config.IsPersistThemeToggle() {
+		return m, nil
+	}
+	dark := m.darkMode
+	m.config.DarkMode = &dark
+	m.themeSaveID++
+	id := m.themeSaveID
+	return m, tea.Tick(constants.ThemeSaveDelayMs*time.Millisecond, func(time.Time) tea.Msg {
+		return themeSaveDueMsg{id: id}
+	})
+}
+
 // newTextInput creates a configured text input with the given placeholder
 func newTextInput(placeholder string) textinput.Model {
 	ti := textinput.New()
@@ -101,7 +637,8 @@ func newTextInput(placeholder string) textinput.Model {
 // New creates a new Model with discovered instances
 func New(instances []devcontainer.ContainerInstance, cfg *config.Config) Model {
 	// Initialize theme from config
-	darkMode := cfg.IsDarkMode()
+	SetThemeOverrides(cfg.Theme)
+	darkMode := resolveDarkMode(cfg)
 	ApplyTheme(darkMode)
 
 	s := spinner.New()
@@ -116,13 +653,15 @@ func New(instances []devcontainer.ContainerInstance, cfg *config.Config) Model {
 		worktreeInput: newTextInput(constants.DefaultWorktreePlaceholder),
 		config:        cfg,
 		darkMode:      darkMode,
+		discovery:     &discoveryProgress{},
 	}
 }
 
 // NewWithDiscovery creates a Model that will discover instances asynchronously
 func NewWithDiscovery(cfg *config.Config) Model {
 	// Initialize theme from config
-	darkMode := cfg.IsDarkMode()
+	SetThemeOverrides(cfg.Theme)
+	darkMode := resolveDarkMode(cfg)
 	ApplyTheme(darkMode)
 
 	s := spinner.New()
@@ -137,13 +676,35 @@ func NewWithDiscovery(cfg *config.Config) Model {
 		worktreeInput: newTextInput(constants.DefaultWorktreePlaceholder),
 		config:        cfg,
 		darkMode:      darkMode,
+		discovery:     &discoveryProgress{},
 	}
 }
 
+// NewWithProject creates a Model that skips discovery and immediately starts
+// the given instance, continuing to its tmux sessions once the container is up
+func NewWithProject(instance devcontainer.ContainerInstance, cfg *config.Config) Model {
+	m := New([]devcontainer.ContainerInstance{instance}, cfg)
+	m.instancesStatus = []devcontainer.ContainerInstanceWithStatus{
+		{ContainerInstance: instance, Status: devcontainer.StatusUnknown},
+	}
+	m.selectedInstance = &m.instancesStatus[0].ContainerInstance
+	m.beginContainerStart()
+	m.state = StateContainerStarting
+	return m
+}
+
+// WithWarning returns a copy of the model with a warning shown on the dashboard
+func (m Model) WithWarning(warning string) Model {
+	m.warning = warning
+	m.logEvent("Warning: %s", warning)
+	return m
+}
+
 // NewWithWizard creates a Model that starts with the configuration wizard
 func NewWithWizard(cfg *config.Config) Model {
 	// Initialize theme from config
-	darkMode := cfg.IsDarkMode()
+	SetThemeOverrides(cfg.Theme)
+	darkMode := resolveDarkMode(cfg)
 	ApplyTheme(darkMode)
 
 	s := spinner.New()
@@ -158,11 +719,17 @@ func NewWithWizard(cfg *config.Config) Model {
 		worktreeInput: newTextInput(constants.DefaultWorktreePlaceholder),
 		config:        cfg,
 		darkMode:      darkMode,
+		discovery:     &discoveryProgress{},
 	}
 
 	// Initialize wizard state
 	m.initWizardState(cfg)
 
+	// First run: suggest existing code directories instead of scanning all of home
+	if roots := config.DetectLikelyProjectRoots(); len(roots) > 0 {
+		m.wizardSearchPaths = roots
+	}
+
 	return m
 }
 
@@ -177,14 +744,15 @@ func (m *Model) initWizardState(cfg *config.Config) {
 	m.wizardMaxDepthInput = newTextInput("3")
 
 	// Initialize wizard state from config
-	m.wizardSearchPaths = make([]string, len(cfg.SearchPaths))
-	copy(m.wizardSearchPaths, cfg.SearchPaths)
+	// The wizard edits the top-level search paths, not an active profile's
+	m.wizardSearchPaths = slices.Clone(cfg.BaseSearchPaths())
 
 	m.wizardCredentials = make([]auth.Credential, len(cfg.Auth.Credentials))
 	copy(m.wizardCredentials, cfg.Auth.Credentials)
 
-	m.wizardDarkMode = cfg.IsDarkMode()
+	m.wizardDarkMode = resolveDarkMode(cfg)
 	m.wizardPathWarnings = make(map[string]bool)
+	m.wizardCredWarnings = make(map[string]string)
 
 	// Set input values from config
 	m.wizardSessionInput.SetValue(cfg.DefaultSessionName)
@@ -200,6 +768,12 @@ func (m Model) Init() tea.Cmd {
 	if m.state == StateDiscovering {
 		return tea.Batch(m.spinner.Tick, m.discoverInstances())
 	}
+	if m.state == StateWizardWelcome {
+		return m.validateAllWizardPaths()
+	}
+	if m.state == StateContainerStarting {
+		return tea.Batch(m.spinner.Tick, m.startContainer())
+	}
 	return nil
 }
 
@@ -214,6 +788,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case tea.FocusMsg:
+		// Back in the terminal after a while: refresh stale status. Only on the
+		// dashboard, so inputs, confirmations and other screens aren't disturbed.
+		if m.state == StateDashboard && m.focusRefreshDue() {
+			m.state = StateRefreshingStatus
+			return m, tea.Batch(m.spinner.Tick, m.refreshInstanceStatus())
+		}
+		return m, nil
+
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
@@ -221,12 +804,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case instancesDiscoveredMsg:
 		m.instances = msg.instances
+		m.instancesTruncated = msg.truncated
+		if msg.compared {
+			m.snapshotTaken = true
+			var removed []string
+			m.newInstances, removed = diffDiscovery(msg.previous, msg.instances)
+			if len(removed) > 0 {
+				m.warning = joinNotice(m.warning, "Gone since last run: "+strings.Join(removed, ", "))
+			}
+		}
 		m.state = StateRefreshingStatus
 		m.cursor = 0
 		return m, tea.Batch(m.spinner.Tick, m.refreshInstanceStatus())
 
 	case instanceStatusRefreshedMsg:
 		m.instancesStatus = msg.statuses
+		m.lastRefresh = time.Now()
+		m.sortInstances()
 
 		// Check if we need to auto-start a newly created worktree
 		if m.pendingAutoStart && m.autoStartWorktreePath != "" {
@@ -237,7 +831,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.selectedInstance = &m.instancesStatus[i].ContainerInstance
 					m.cursor = i
 					m.autoStartWorktreePath = ""
+					m.pendingAutoAttach = m.config.IsAutoAttachAfterCreate()
 					// Start the container
+					m.beginContainerStart()
 					m.state = StateContainerStarting
 					return m, tea.Batch(m.spinner.Tick, m.startContainer())
 				}
@@ -246,44 +842,202 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.autoStartWorktreePath = ""
 		}
 
+		m = m.snapDashboardCursor()
 		m.state = StateDashboard
 		return m, nil
 
 	case tmuxDetachedMsg:
-		// User detached from tmux, return to dashboard with status refresh
-		m.state = StateRefreshingStatus
-		m.selectedInstance = nil
+		// User detached from tmux. Only the attached instance's status and session
+		// count can have changed (it may have been started for this attach), so
+		// refresh that instead of every container's status.
+		m.state = StateDashboard
 		m.cursor = 0
-		return m, tea.Batch(m.spinner.Tick, m.refreshInstanceStatus())
+		if m.selectedInstance == nil {
+			return m, nil
+		}
+		path := m.selectedInstance.Path
+		for i := range m.instancesStatus {
+			if m.instancesStatus[i].Path == path {
+				m.cursor = i
+				break
+			}
+		}
+		m.selectedInstance = nil
+		return m, refreshInstance(path, m.showSessionCounts())
+
+	case instanceRefreshedMsg:
+		for i := range m.instancesStatus {
+			instance := &m.instancesStatus[i]
+			if instance.Path != msg.path {
+				continue
+			}
+			instance.Status = msg.status
+			if msg.status != devcontainer.StatusRunning {
+				instance.SessionCount = 0
+			} else if msg.count >= 0 {
+				instance.SessionCount = msg.count
+				instance.Unmanaged = instance.Unmanaged && msg.count == 0
+			}
+			break
+		}
+		return m, nil
 
 	case containerStartedMsg:
+		// Ignore a start that finished just as the user cancelled it, including
+		// one cancelled in favour of starting another instance
+		if m.state != StateContainerStarting || m.selectedInstance == nil || m.selectedInstance.Path != msg.path {
+			return m, nil
+		}
+		m.cancelContainerStart()
+		var warnings []string
+		if msg.authWarning != "" {
+			warnings = append(warnings, msg.authWarning)
+		}
+		if msg.workspaceWarning != "" {
+			warnings = append(warnings, msg.workspaceWarning)
+		}
+		m.warning = strings.Join(warnings, "; ")
+		m.logEvent("Started container %s", m.getInstanceName())
+		if m.warning != "" {
+			m.logEvent("Warning: %s", m.warning)
+		}
+		for _, f := range msg.authFailures {
+			m.logEvent("Warning: credential %s (%s) failed: %v", f.Credential.Name, f.Credential.Source, f.Err)
+		}
+		// Stop to show credential failures before continuing to tmux sessions
+		if len(msg.authFailures) > 0 {
+			m.authFailures = msg.authFailures
+			m.state = StateAuthWarning
+			return m, nil
+		}
+		return m.handleContainerStarted()
+
+	case sessionPipedMsg:
+		if msg.err != nil {
+			m.logEvent("Error: %v", msg.err)
+			// Don't pull the user off whatever screen they moved on to
+			if m.state != StateTmuxSelect {
+				return m.showErrorFlash(fmt.Sprintf("Failed to pipe %s: %v", msg.session, msg.err))
+			}
+			m.state = StateError
+			m.err = msg.err
+			m.errHint = "Press any key to go back"
+			return m, nil
+		}
+		m.logEvent("Piping tmux session %s in %s to %s", msg.session, m.getInstanceName(), msg.outPath)
+		return m.showFlash(fmt.Sprintf("Piping %s to %s", msg.session, msg.outPath))
+
+	case containerAdoptedMsg:
+		if m.state != StateAdoptingContainer || m.selectedInstance == nil || m.selectedInstance.Path != msg.path {
+			return m, nil
+		}
+		for i := range m.instancesStatus {
+			if m.instancesStatus[i].Path == msg.path {
+				m.instancesStatus[i].Unmanaged = false
+			}
+		}
 		m.warning = msg.authWarning
+		m.logEvent("Adopted container %s", m.getInstanceName())
+		if m.warning != "" {
+			m.logEvent("Warning: %s", m.warning)
+		}
+		for _, f := range msg.authFailures {
+			m.logEvent("Warning: credential %s (%s) failed: %v", f.Credential.Name, f.Credential.Source, f.Err)
+		}
+		// Continue into the default session, created if it doesn't exist
+		m.pendingAutoAttach = true
+		if len(msg.authFailures) > 0 {
+			m.authFailures = msg.authFailures
+			m.state = StateAuthWarning
+			return m, nil
+		}
 		return m.handleContainerStarted()
 
+	case containerStartCancelledMsg:
+		// The UI already returned to the dashboard when the user cancelled
+		return m, nil
+
 	case containerErrorMsg:
+		m.cancelContainerStart()
+		m.pendingAutoAttach = false
+		m.pendingSingleAttach = false
+		m.logEvent("Error: %v", msg.err)
+		m.retry = retryOpFor(m.state)
 		m.state = StateError
 		m.err = msg.err
 		m.errHint = "Press any key to go back"
+		if errors.Is(msg.err, context.DeadlineExceeded) {
+			m.errHint = "The container took too long to start; raise container_timeout_seconds for slow image builds"
+		}
 		return m, nil
 
 	case tmuxSessionsLoadedMsg:
-		m.tmuxSessions = tmux.ParseSessions(msg.sessions)
+		// The session a plain enter would create, if running, leads the list
+		m.tmuxSessions = tmux.DefaultFirst(tmux.ParseSessions(msg.sessions), m.defaultSessionName())
+		if m.pendingAutoAttach {
+			return m.autoAttachDefaultSession()
+		}
+		// Only a fresh enter from the dashboard skips the list; reloads after
+		// stopping a session stay on it
+		singleAttach := m.pendingSingleAttach
+		m.pendingSingleAttach = false
+		if singleAttach && len(m.tmuxSessions) == 1 {
+			return m.attachToSession(m.tmuxSessions[0].Name)
+		}
 		m.state = StateTmuxSelect
 		m.cursor = 0
 		return m, nil
 
+	case restartSessionsLoadedMsg:
+		// Ignore late results if the user moved on before the fetch finished
+		if m.state != StateDashboard || m.selectedInstance == nil || m.selectedInstance.Path != msg.path {
+			return m, nil
+		}
+		m.restartSessions = tmux.ParseSessions(msg.sessions)
+		m.state = StateConfirmRestart
+		return m, nil
+
+	case deleteChecksLoadedMsg:
+		// Ignore late results if the user moved on before the check finished
+		if (m.state != StateDashboard && m.state != StateWorktreeList) || m.selectedInstance == nil || m.selectedInstance.Path != msg.path {
+			return m, nil
+		}
+		m.unpushedCommits = msg.unpushed
+		m.stopsContainer = msg.running
+		if msg.discard {
+			return m.openDiscardConfirm()
+		}
+		m.state = StateConfirmDeleteWorktree
+		return m, nil
+
 	case tmuxSessionCreatedMsg:
 		// Session created, now attach
-		sessionName := m.textInput.Value()
+		sessionName := msg.name
+		m.logEvent("Created tmux session %s in %s", sessionName, m.getInstanceName())
 		return m.attachToSession(sessionName)
 
 	case containerStoppedMsg, containerRestartedMsg:
+		if _, ok := msg.(containerStoppedMsg); ok {
+			m.logEvent("Stopped container %s", m.getInstanceName())
+		} else {
+			m.logEvent("Restarted container %s", m.getInstanceName())
+		}
 		// Refresh status after container operation
 		m.state = StateRefreshingStatus
 		m.selectedInstance = nil
 		return m, tea.Batch(m.spinner.Tick, m.refreshInstanceStatus())
 
 	case tmuxSessionStoppedMsg, tmuxSessionRestartedMsg:
+		switch {
+		case m.state == StateTmuxKillingAll:
+			m.logEvent("Killed all tmux sessions in %s", m.getInstanceName())
+		case m.state == StateTmuxServerRestarting:
+			m.logEvent("Restarted the tmux server in %s", m.getInstanceName())
+		case m.state == StateTmuxRestarting:
+			m.logEvent("Restarted tmux session %s in %s", m.getSessionName(), m.getInstanceName())
+		default:
+			m.logEvent("Stopped tmux session %s in %s", m.getSessionName(), m.getInstanceName())
+		}
 		// Reload tmux sessions after stop/restart with loading animation
 		m.selectedSession = nil
 		m.cursor = 0
@@ -291,33 +1045,245 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(m.spinner.Tick, m.loadTmuxSessions())
 
 	case worktreeCreatedMsg:
-		// Store push warning for display (clear any previous warning)
-		m.warning = msg.pushWarning
+		// Store push warning or remote tracking note for display (clear any previous warning)
+		m.warning = msg.notice
+		m.logEvent("Created worktree %s", msg.worktreePath)
+		if m.warning != "" {
+			m.logEvent("Warning: %s", m.warning)
+		}
 		// Worktree created, refresh instances
 		m.state = StateDiscovering
 		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
 
+	case worktreeMovedMsg:
+		m.logEvent("Moved worktree %s to %s", m.getWorktreeBranch(), msg.path)
+		m.selectedInstance = nil
+		if msg.restart {
+			// Recreate the container at the new workspace folder after discovery
+			m.pendingAutoStart = true
+			m.autoStartWorktreePath = msg.path
+		}
+		m.state = StateDiscovering
+		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
+
 	case worktreeDeletedMsg:
+		if msg.branch != "" {
+			m.logEvent("Deleted worktree and branch %s", msg.branch)
+		} else {
+			m.logEvent("Deleted worktree %s", m.getWorktreeBranch())
+		}
 		// Worktree deleted, refresh instances
 		m.state = StateDiscovering
 		m.selectedInstance = nil
 		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
 
+	case imageCheckedMsg:
+		if m.imageStatus == nil {
+			m.imageStatus = make(map[string]devcontainer.ImageStatus)
+		}
+		m.imageStatus[msg.path] = msg.status
+		m.selectedInstance = nil
+		m.state = StateDashboard
+		return m, nil
+
+	case searchPathAddedMsg:
+		if msg.err != nil {
+			m.logEvent("Error: %v", msg.err)
+			m.state = StateError
+			m.err = msg.err
+			m.errHint = "Press any key to go back"
+			return m, nil
+		}
+		m.logEvent("Added search path %s", msg.path)
+		m.config.SearchPaths = msg.paths
+		return m, m.discoverInstances()
+
+	case worktreeListLoadedMsg:
+		if m.state != StateDashboard || m.selectedInstance == nil || m.selectedInstance.Path != msg.path {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.state = StateError
+			m.err = msg.err
+			m.errHint = "Press any key to go back"
+			return m, nil
+		}
+		m.worktreeList = msg.worktrees
+		m.worktreeCursor = 0
+		m.state = StateWorktreeList
+		return m, nil
+
+	case devcontainerJSONLoadedMsg:
+		// Ignore a read that finishes after the user moved on
+		if m.state != StateDashboard || m.selectedInstance == nil || m.selectedInstance.ConfigPath != msg.path {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.state = StateError
+			m.err = fmt.Errorf("failed to read devcontainer.json: %w", msg.err)
+			m.errHint = "Press any key to go back"
+			return m, nil
+		}
+		m.jsonLines = strings.Split(strings.TrimRight(msg.content, "\n"), "\n")
+		m.jsonTruncated = msg.truncated
+		m.jsonScroll = 0
+		m.state = StateShowDevcontainerJSON
+		return m, nil
+
+	case themeSaveDueMsg:
+		if msg.id != m.themeSaveID {
+			return m, nil
+		}
+		return m, m.saveTheme()
+
+	case themeSavedMsg:
+		if msg.err != nil {
+			m.warning = fmt.Sprintf("failed to save theme: %v", msg.err)
+			m.logEvent("Warning: %s", m.warning)
+		}
+		return m, nil
+
+	case favoritesSavedMsg:
+		if msg.err != nil {
+			m.warning = fmt.Sprintf("failed to save favorites: %v", msg.err)
+			m.logEvent("Warning: %s", m.warning)
+		}
+		return m, nil
+
+	case cloneProgressMsg:
+		m.cloneProgress = msg.line
+		return m, waitForClone(m.cloneUpdates)
+
+	case repoClonedMsg:
+		// Repository cloned, rediscover so it shows up on the dashboard
+		m.logEvent("Cloned repository into %s", msg.path)
+		m.cloneUpdates = nil
+		m.cloneProgress = ""
+		m.state = StateDiscovering
+		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
+
+	case worktreeCommitsLoadedMsg:
+		m.worktreeCommits = msg.commits
+		m.commitsBase = msg.base
+		m.commitsScroll = 0
+		m.worktreeSize = ""
+		m.state = StateWorktreeCommits
+		return m, m.loadWorktreeDiskUsage()
+
+	case worktreeDiskUsageMsg:
+		// Ignore a result for a panel the user already closed
+		if m.state != StateWorktreeCommits || m.selectedInstance == nil || m.selectedInstance.Path != msg.path {
+			return m, nil
+		}
+		m.worktreeSize = formatDiskUsage(msg.size, msg.err)
+		return m, nil
+
+	case containerLogsLoadedMsg:
+		if msg.pager != nil {
+			return m.openInPager(msg.pager, msg.logs)
+		}
+		m.logLines = strings.Split(strings.TrimRight(msg.logs, "\n"), "\n")
+		// Start at the end, where the most recent output is
+		m.logScroll = len(m.logLines) - scrollViewRows(m.height)
+		if m.logScroll < 0 {
+			m.logScroll = 0
+		}
+		m.state = StateContainerLogs
+		return m, nil
+
+	case pagerClosedMsg:
+		if msg.err != nil {
+			m.state = StateError
+			m.err = fmt.Errorf("pager failed: %w", msg.err)
+			m.errHint = "Set $PAGER to a working pager, or unset it to use less"
+			return m, nil
+		}
+		m.state = StateDashboard
+		m.selectedInstance = nil
+		return m, nil
+
+	case shellExitedMsg:
+		// A non-zero exit is usually just the last command's status, except
+		// 126/127, which mean not even the fallback shell could be run
+		var exitErr *exec.ExitError
+		if errors.As(msg.err, &exitErr) {
+			if code := exitErr.ExitCode(); code == 126 || code == 127 {
+				m.state = StateError
+				m.err = fmt.Errorf("no usable shell in the container (exit status %d)", code)
+				m.errHint = "Set container_shell to a shell the image provides"
+				return m, nil
+			}
+		} else if msg.err != nil {
+			m.state = StateError
+			m.err = fmt.Errorf("failed to open a shell: %w", msg.err)
+			m.errHint = "Press any key to go back"
+			return m, nil
+		}
+		m.state = msg.returnTo
+		if m.state == StateDashboard {
+			m.selectedInstance = nil
+		}
+		return m, nil
+
 	case githubIssuesLoadedMsg:
+		// Ignore results from a request the user cancelled or replaced
+		if m.state != StateGitHubIssuesLoading || msg.request != m.githubRequest {
+			return m, nil
+		}
+		m.cancelGitHubRequest()
 		m.githubIssues = msg.issues
 		m.githubRepoOwner = msg.owner
 		m.githubRepoName = msg.repo
 		m.state = StateGitHubIssuesList
 		m.cursor = 0
+		if created := m.createdIssue; created != 0 {
+			// Land on the new issue so enter creates its worktree
+			m.createdIssue = 0
+			for i, issue := range m.githubIssues {
+				if issue.Number == created {
+					m.cursor = i
+					return m.showFlash(fmt.Sprintf("Created issue #%d (enter to create a worktree)", created))
+				}
+			}
+			return m.showFlash(fmt.Sprintf("Created issue #%d", created))
+		}
 		return m, nil
 
+	case githubIssueCreatedMsg:
+		if m.state != StateGitHubIssueCreating || msg.request != m.githubRequest {
+			return m, nil
+		}
+		m.logEvent("Created issue #%d in %s/%s", msg.number, m.githubRepoOwner, m.githubRepoName)
+		m.createdIssue = msg.number
+		m.state = StateGitHubIssuesLoading
+		ctx := m.startGitHubRequest()
+		return m, tea.Batch(m.spinner.Tick, m.loadGitHubIssues(ctx))
+
 	case githubIssuesErrorMsg:
+		if m.state != StateGitHubIssuesLoading && m.state != StateGitHubIssueDetailLoading && m.state != StateGitHubIssueCreating {
+			return m, nil
+		}
+		if msg.request != m.githubRequest {
+			return m, nil
+		}
+		m.cancelGitHubRequest()
+		m.logEvent("Error: %v", msg.err)
 		m.state = StateError
 		m.err = msg.err
 		m.errHint = "Press any key to go back"
+		switch {
+		case errors.Is(msg.err, context.DeadlineExceeded):
+			m.errHint = "gh may be waiting on the network or authentication; try 'gh auth status' or raise github.fetch_timeout_seconds"
+		case errors.Is(msg.err, github.ErrNotAuthenticated):
+			m.errHint = "Run 'gh auth login' in a terminal, then press g again"
+		}
 		return m, nil
 
 	case githubIssueDetailLoadedMsg:
+		if m.state != StateGitHubIssueDetailLoading || msg.request != m.githubRequest {
+			return m, nil
+		}
+		m.cancelGitHubRequest()
 		// Update selected issue with body
 		if m.selectedIssue != nil {
 			m.selectedIssue.Body = msg.body
@@ -332,14 +1298,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Combine warnings for display
 		var warnings []string
-		if msg.pushWarning != "" {
-			warnings = append(warnings, msg.pushWarning)
+		if msg.notice != "" {
+			warnings = append(warnings, msg.notice)
 		}
 		if msg.labelWarning != "" {
 			warnings = append(warnings, msg.labelWarning)
 		}
+		m.logEvent("Created worktree %s from issue", msg.worktreePath)
 		if len(warnings) > 0 {
 			m.warning = strings.Join(warnings, "; ")
+			m.logEvent("Warning: %s", m.warning)
 		}
 
 		// Set up auto-start for after discovery completes
@@ -348,6 +1316,56 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state = StateDiscovering
 		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
 
+	case githubBatchWorktreesCreatedMsg:
+		// Worktrees created from several issues, refresh and auto-start the first success
+		m.githubIssues = nil
+		m.selectedIssue = nil
+		m.markedIssues = nil
+
+		var warnings []string
+		autoStartPath := ""
+		for _, r := range msg.results {
+			if r.err != nil {
+				warnings = append(warnings, fmt.Sprintf("#%d: %v", r.issueNumber, r.err))
+				continue
+			}
+			m.logEvent("Created worktree %s from issue #%d", r.worktreePath, r.issueNumber)
+			if autoStartPath == "" {
+				autoStartPath = r.worktreePath
+			}
+			if r.notice != "" {
+				warnings = append(warnings, fmt.Sprintf("#%d: %s", r.issueNumber, r.notice))
+			}
+			if r.labelWarning != "" {
+				warnings = append(warnings, fmt.Sprintf("#%d: %s", r.issueNumber, r.labelWarning))
+			}
+		}
+		if len(warnings) > 0 {
+			m.warning = strings.Join(warnings, "; ")
+			m.logEvent("Warning: %s", m.warning)
+		}
+
+		// Only the first created worktree is started; the rest are left stopped
+		if autoStartPath != "" {
+			m.pendingAutoStart = true
+			m.autoStartWorktreePath = autoStartPath
+		}
+		m.state = StateDiscovering
+		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
+
+	case clipboardCopiedMsg:
+		if msg.err != nil {
+			return m.showErrorFlash(msg.err.Error())
+		}
+		return m.showFlash("Copied " + msg.label)
+
+	case flashExpiredMsg:
+		if msg.id == m.flashID {
+			m.flash = ""
+			m.jumpPending = false // The jump prompt expires with its flash
+		}
+		return m, nil
+
 	case wizardPathValidatedMsg:
 		// Update path validation warnings
 		if m.wizardPathWarnings == nil {
@@ -356,9 +1374,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.wizardPathWarnings[msg.path] = msg.exists
 		return m, nil
 
+	case wizardCredentialValidatedMsg:
+		// Update credential validation warnings
+		if m.wizardCredWarnings == nil {
+			m.wizardCredWarnings = make(map[string]string)
+		}
+		m.wizardCredWarnings[msg.key] = msg.warning
+		return m, nil
+
 	case wizardConfigSavedMsg:
 		// Config saved successfully, reload and go to dashboard
-		newCfg, err := config.Load()
+		// Keep the profile that was active when the wizard opened
+		profile := ""
+		if m.config != nil {
+			profile = m.config.ActiveProfile()
+		}
+		newCfg, err := config.Load(profile)
 		if err != nil {
 			m.state = StateError
 			m.err = err
@@ -366,10 +1397,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.config = newCfg
+		ApplyRuntimeConfig(newCfg)
+		m.logEvent("Saved configuration")
 		m.state = StateDiscovering
 		return m, tea.Batch(m.spinner.Tick, m.discoverInstances())
 
 	case wizardConfigErrorMsg:
+		m.logEvent("Error: %v", msg.err)
 		m.state = StateError
 		m.err = msg.err
 		m.errHint = "Press any key to go back"
@@ -390,6 +1424,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	// Update clone URL input if in clone input state
+	if m.state == StateCloneInput {
+		var cmd tea.Cmd
+		m.cloneInput, cmd = m.cloneInput.Update(msg)
+		return m, cmd
+	}
+
 	return m, nil
 }
 
@@ -397,22 +1438,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) View() string {
 	switch m.state {
 	case StateDiscovering:
-		return RenderDiscovering(m.spinner.View())
+		walked, elapsed, last := m.discovery.snapshot()
+		return RenderDiscovering(m.spinner.View(), walked, elapsed, last)
 
 	case StateRefreshingStatus:
 		return RenderRefreshingStatus(m.spinner.View())
 
 	case StateDashboard:
-		return RenderDashboard(m.instancesStatus, m.cursor, m.width, m.warning)
+		return RenderDashboard(m.instancesStatus, m.favoriteSet(), m.imageStatus, m.cursor, m.dashboardOptions(), m.width, m.warning, m.styledFlash())
 
 	case StateContainerStarting:
 		return RenderContainerStarting(m.getInstanceName(), m.spinner.View())
 
 	case StateConfirmStop:
-		return RenderConfirmDialog("stop", m.getInstanceName())
+		return RenderConfirmDialog("stop", m.getInstanceName(), nil)
 
 	case StateConfirmRestart:
-		return RenderConfirmDialog("restart", m.getInstanceName())
+		return RenderConfirmDialog("restart", m.getInstanceName(), m.restartSessions)
 
 	case StateContainerStopping:
 		return RenderContainerOperation("Stopping", m.getInstanceName(), m.spinner.View())
@@ -426,6 +1468,18 @@ func (m Model) View() string {
 	case StateConfirmTmuxRestart:
 		return RenderTmuxConfirmDialog("restart", m.getSessionName())
 
+	case StateConfirmTmuxKillAll:
+		return RenderTmuxKillAllConfirmDialog(m.getInstanceName(), len(m.tmuxSessions))
+
+	case StateTmuxKillingAll:
+		return renderOperation("Killing", "all sessions in", m.getInstanceName(), m.spinner.View())
+
+	case StateConfirmTmuxServerRestart:
+		return RenderTmuxServerRestartConfirmDialog(m.getInstanceName(), len(m.tmuxSessions))
+
+	case StateTmuxServerRestarting:
+		return renderOperation("Restarting", "the tmux server in", m.getInstanceName(), m.spinner.View())
+
 	case StateTmuxStopping:
 		return RenderTmuxOperation("Stopping", m.getSessionName(), m.spinner.View())
 
@@ -436,11 +1490,14 @@ func (m Model) View() string {
 		return RenderLoadingTmuxSessions(m.getInstanceName(), m.spinner.View())
 
 	case StateTmuxSelect:
-		return RenderTmuxSelect(m.getInstanceName(), m.tmuxSessions, m.cursor, m.warning)
+		return RenderTmuxSelect(m.getInstanceName(), m.tmuxSessions, m.defaultSessionName(), m.presetSessions(), m.cursor, m.warning, m.styledFlash())
 
 	case StateNewSessionInput:
 		return RenderNewSessionInput(m.getInstanceName(), m.textInput)
 
+	case StatePipeSessionInput:
+		return RenderPipeSessionInput(m.getSessionName(), m.textInput)
+
 	case StateAttaching:
 		sessionName := m.textInput.Value()
 		if m.cursor < len(m.tmuxSessions) {
@@ -451,21 +1508,60 @@ func (m Model) View() string {
 	case StateNewWorktreeInput:
 		projectName := ""
 		if m.selectedInstance != nil {
-			projectName = m.selectedInstance.Name
+			projectName = m.selectedInstance.ProjectName()
 		}
-		return RenderNewWorktreeInput(projectName, m.worktreeInput)
+		return RenderNewWorktreeInput(projectName, m.worktreeBase, m.worktreeInput)
 
 	case StateCreatingWorktree:
 		return RenderCreatingWorktree(m.worktreeInput.Value(), m.spinner.View())
 
+	case StateCloneInput:
+		return RenderCloneInput(m.cloneParentDir(), m.cloneInput)
+
+	case StateCloning:
+		return RenderCloning(m.cloneDest, m.cloneProgress, m.spinner.View())
+
+	case StateAuthWarning:
+		return RenderAuthWarning(m.getInstanceName(), m.authFailures)
+
 	case StateConfirmDeleteWorktree:
-		return RenderConfirmDeleteWorktree(m.getWorktreeBranch())
+		return RenderConfirmDeleteWorktree(m.getWorktreeBranch(), m.deletesBranch(), m.unpushedCommits, m.stopsContainer)
 
 	case StateDeletingWorktree:
 		return RenderDeletingWorktree(m.getWorktreeBranch(), m.spinner.View())
 
+	case StateMoveWorktreeInput:
+		return RenderMoveWorktreeInput(m.getWorktreeBranch(), m.textInput)
+
+	case StateAddSearchPathInput:
+		return RenderAddSearchPathInput(config.ConfigPath(), m.textInput)
+
+	case StateAdoptingContainer:
+		return RenderAdoptingContainer(m.getInstanceName(), m.spinner.View())
+
+	case StateProfileSelect:
+		return RenderProfilePicker(m.config.Profiles, m.config.ActiveProfile(), m.profileCursor, m.width)
+
+	case StateGitHubQuerySelect:
+		return RenderSavedQueryPicker(m.config.GitHub.SavedQueries, m.issueQuery, m.queryCursor, m.width)
+
+	case StateWorktreeList:
+		return RenderWorktreeList(m.getInstanceName(), m.worktreeList, m.worktreeCursor, m.width)
+
+	case StateShowDevcontainerJSON:
+		return RenderDevcontainerJSON(m.selectedInstance.ConfigPath, m.jsonLines, m.jsonTruncated, m.jsonScroll, m.height, m.width)
+
+	case StateMovingWorktree:
+		return RenderMovingWorktree(m.getWorktreeBranch(), m.spinner.View())
+
+	case StateConfirmDiscardWorktree:
+		return RenderConfirmDiscardWorktree(m.getInstanceName(), m.getWorktreeBranch(), m.deletesBranch(), m.unpushedCommits, m.textInput)
+
+	case StateDiscardingWorktree:
+		return RenderDiscardingWorktree(m.getWorktreeBranch(), m.spinner.View())
+
 	case StateError:
-		return RenderError(m.err, m.errHint)
+		return RenderError(m.err, m.errHint, m.retry != retryNone, m.errScroll, m.width, m.height)
 
 	case StateShowConfig:
 		return RenderConfigDisplay(m.config)
@@ -474,7 +1570,13 @@ func (m Model) View() string {
 		return RenderGitHubIssuesLoading(m.spinner.View())
 
 	case StateGitHubIssuesList:
-		return RenderGitHubIssuesList(m.githubIssues, m.cursor, m.githubRepoOwner, m.githubRepoName, m.width)
+		return RenderGitHubIssuesList(m.githubIssues, m.markedIssues, m.cursor, m.githubRepoOwner, m.githubRepoName, m.issueStateFilter(), m.issueQuery, m.styledFlash(), m.config.GitHub.IssueTitleMax, m.width)
+
+	case StateGitHubIssueTitleInput, StateGitHubIssueBodyInput:
+		return RenderNewIssueInput(m.githubRepoOwner, m.githubRepoName, m.newIssueTitle, m.textInput)
+
+	case StateGitHubIssueCreating:
+		return RenderGitHubIssueCreating(m.newIssueTitle, m.spinner.View())
 
 	case StateGitHubIssueDetailLoading:
 		issueNum := 0
@@ -488,15 +1590,39 @@ func (m Model) View() string {
 		if m.selectedIssue != nil {
 			body = m.selectedIssue.Body
 		}
-		return RenderGitHubIssueDetail(m.selectedIssue, body, m.width)
+		return RenderGitHubIssueDetail(m.selectedIssue, body, m.styledFlash(), m.width)
 
 	case StateGitHubWorktreeCreating:
+		if len(m.markedIssues) > 0 {
+			return RenderGitHubBatchWorktreeCreating(len(m.markedIssues), m.spinner.View())
+		}
 		issueNum := 0
 		if m.selectedIssue != nil {
 			issueNum = m.selectedIssue.Number
 		}
 		return RenderGitHubWorktreeCreating(issueNum, m.spinner.View())
 
+	case StateCheckingImage:
+		return RenderCheckingImage(m.getInstanceName(), m.spinner.View())
+
+	case StateWorktreeCommitsLoading:
+		return RenderWorktreeCommitsLoading(m.getWorktreeBranch(), m.spinner.View())
+
+	case StateWorktreeCommits:
+		return RenderWorktreeCommits(m.getInstanceName(), m.commitsBase, m.worktreeSize, m.worktreeCommits, m.commitsScroll, m.height, m.width)
+
+	case StateContainerLogsLoading:
+		return RenderContainerLogsLoading(m.getInstanceName(), m.spinner.View())
+
+	case StateContainerLogs:
+		return RenderContainerLogs(m.getInstanceName(), m.logLines, m.logScroll, m.height, m.width)
+
+	case StateEventLog:
+		return RenderEventLog(m.events, m.eventScroll, m.height, m.width)
+
+	case StateWarningDetail:
+		return RenderWarningDetail(m.warning, m.warningScroll, m.height, m.width)
+
 	case StateWizardWelcome:
 		return RenderWizardWelcome(m.width)
 
@@ -504,7 +1630,7 @@ func (m Model) View() string {
 		return RenderWizardSearchPaths(m.wizardSearchPaths, m.wizardPathWarnings, m.wizardCursor, m.wizardPathInput, m.wizardEditMode, m.width)
 
 	case StateWizardCredentials:
-		return RenderWizardCredentials(m.wizardCredentials, m.wizardCredSource, m.wizardCredValue, m.wizardCursor, m.wizardEditMode, m.width)
+		return RenderWizardCredentials(m.wizardCredentials, m.wizardCredWarnings, m.wizardCredSource, m.wizardCredValue, m.wizardCursor, m.wizardEditMode, m.width)
 
 	case StateWizardSettings:
 		var activeInput textinput.Model
@@ -36,6 +36,10 @@ const (
 	StateTmuxStopping
 	// StateTmuxRestarting is shown while a tmux session is being restarted
 	StateTmuxRestarting
+	// StateConfirmTmuxKillAll prompts user to confirm killing every tmux session in a container
+	StateConfirmTmuxKillAll
+	// StateTmuxKillingAll is shown while all tmux sessions are being killed
+	StateTmuxKillingAll
 	// StateLoadingTmuxSessions is shown while loading tmux sessions from a container
 	StateLoadingTmuxSessions
 	// StateError displays an error message
@@ -60,6 +64,60 @@ const (
 	StateGitHubIssueDetail
 	// StateGitHubWorktreeCreating is shown while creating worktree from issue
 	StateGitHubWorktreeCreating
+	// StateWorktreeCommitsLoading is shown while listing commits since the base branch
+	StateWorktreeCommitsLoading
+	// StateWorktreeCommits displays the commits on a worktree branch since its base
+	StateWorktreeCommits
+	// StateEventLog displays the session activity log
+	StateEventLog
+	// StateCheckingImage is shown while comparing a container's image against its latest local tag
+	StateCheckingImage
+	// StateCloneInput shows text input for a repository URL to clone
+	StateCloneInput
+	// StateCloning is shown while git clone runs, with its latest progress line
+	StateCloning
+	// StateContainerLogsLoading is shown while reading a container's logs
+	StateContainerLogsLoading
+	// StateContainerLogs displays container logs when no pager is available
+	StateContainerLogs
+	// StateAuthWarning lists credentials that failed to resolve after a container starts
+	StateAuthWarning
+	// StateWarningDetail shows the full dashboard warning in a scrollable panel
+	StateWarningDetail
+	// StateConfirmDiscardWorktree asks the user to type the branch name before
+	// stopping a worktree's container and removing the worktree
+	StateConfirmDiscardWorktree
+	// StateDiscardingWorktree is shown while stopping the container and removing the worktree
+	StateDiscardingWorktree
+	// StateMoveWorktreeInput shows text input for a worktree's new location
+	StateMoveWorktreeInput
+	// StateMovingWorktree is shown while moving a worktree and recreating its container
+	StateMovingWorktree
+	// StateAddSearchPathInput shows text input for a search path to add from the empty dashboard
+	StateAddSearchPathInput
+	// StateShowDevcontainerJSON displays the selected instance's devcontainer.json
+	StateShowDevcontainerJSON
+	// StateWorktreeList lists a repository's linked worktrees, for reaching them
+	// when show_worktrees hides them from the dashboard
+	StateWorktreeList
+	// StateGitHubQuerySelect lists the saved gh search queries to filter issues by
+	StateGitHubQuerySelect
+	// StateAdoptingContainer is shown while taking over a container started outside claude-quick
+	StateAdoptingContainer
+	// StateProfileSelect lists the config profiles to switch search paths to
+	StateProfileSelect
+	// StateConfirmTmuxServerRestart prompts user to confirm restarting the tmux server in a container
+	StateConfirmTmuxServerRestart
+	// StateTmuxServerRestarting is shown while the tmux server is being restarted
+	StateTmuxServerRestarting
+	// StatePipeSessionInput shows text input for the file a session's output is piped to
+	StatePipeSessionInput
+	// StateGitHubIssueTitleInput shows text input for the title of a new issue
+	StateGitHubIssueTitleInput
+	// StateGitHubIssueBodyInput shows text input for the optional body of a new issue
+	StateGitHubIssueBodyInput
+	// StateGitHubIssueCreating is shown while the new issue is created with gh
+	StateGitHubIssueCreating
 
 	// Wizard states for guided configuration setup
 	// StateWizardWelcome is the introduction screen for the setup wizard
@@ -75,3 +133,31 @@ const (
 	// StateWizardSaving is shown while saving the configuration
 	StateWizardSaving
 )
+
+// retryOp identifies a failed operation that r on the error screen re-runs
+// against the still-selected instance
+type retryOp int
+
+const (
+	retryNone retryOp = iota
+	retryStart
+	retryStop
+	retryRestart
+	retryLoadSessions
+)
+
+// retryOpFor returns the operation in progress in state, or retryNone if
+// an error in that state can't simply be re-run
+func retryOpFor(state State) retryOp {
+	switch state {
+	case StateContainerStarting:
+		return retryStart
+	case StateContainerStopping:
+		return retryStop
+	case StateContainerRestarting:
+		return retryRestart
+	case StateLoadingTmuxSessions:
+		return retryLoadSessions
+	}
+	return retryNone
+}
@@ -1,6 +1,15 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/christophergyman/claude-quick/internal/config"
+)
 
 // colorPalette holds all colors for a theme
 type colorPalette struct {
@@ -41,6 +50,38 @@ var currentPalette = darkPalette
 // IsDarkMode tracks the current theme state
 var IsDarkMode = true
 
+// themeOverrides holds user-configured colors merged onto the base palette
+var themeOverrides config.ThemeConfig
+
+// SetThemeOverrides sets the user color overrides used by subsequent ApplyTheme calls
+func SetThemeOverrides(theme config.ThemeConfig) {
+	themeOverrides = theme
+}
+
+// SetNoColor switches rendering to plain text (no colors or text attributes)
+// for dumb terminals and captured output. It persists across ApplyTheme calls.
+func SetNoColor() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// mergePalette returns base with any non-empty overrides applied.
+// Overrides are expected to be validated already (see config.ThemeConfig.Sanitize).
+func mergePalette(base colorPalette, overrides config.ThemeConfig) colorPalette {
+	apply := func(dst *lipgloss.Color, value string) {
+		if value != "" {
+			*dst = lipgloss.Color(value)
+		}
+	}
+	apply(&base.orange, overrides.Orange)
+	apply(&base.primary, overrides.Primary)
+	apply(&base.dim, overrides.Dim)
+	apply(&base.success, overrides.Success)
+	apply(&base.warning, overrides.Warning)
+	apply(&base.errorCol, overrides.Error)
+	apply(&base.separator, overrides.Separator)
+	return base
+}
+
 // Styles - initialized with dark mode colors
 var (
 	// Header title style (Anthropic orange)
@@ -108,6 +149,10 @@ var (
 	ColumnHeaderStyle = lipgloss.NewStyle().
 				Foreground(currentPalette.dim).
 				Bold(true)
+
+	// Disabled key binding style (actions unavailable for the selection)
+	DisabledStyle = lipgloss.NewStyle().
+			Foreground(currentPalette.separator)
 )
 
 // Status text styles with labels
@@ -118,13 +163,40 @@ var (
 	StatusInProgress = lipgloss.NewStyle().Foreground(currentPalette.orange)
 )
 
-// ApplyTheme updates all styles based on the dark mode setting
+// resolveDarkMode returns the configured dark_mode or, when it is unset,
+// whether the terminal background is dark. The t toggle overrides either.
+func resolveDarkMode(cfg *config.Config) bool {
+	if cfg.DarkMode != nil {
+		return *cfg.DarkMode
+	}
+	if dark, ok := parseColorFgBg(os.Getenv("COLORFGBG")); ok {
+		return dark
+	}
+	// Queries the terminal with OSC 11; dark if it doesn't answer
+	return lipgloss.HasDarkBackground()
+}
+
+// parseColorFgBg reports whether the background in a COLORFGBG value
+// ("fg;bg" or "fg;default;bg", set by rxvt, Konsole and others) is dark.
+// ok is false if the value doesn't name a background color.
+func parseColorFgBg(value string) (dark, ok bool) {
+	fields := strings.Split(value, ";")
+	bg, err := strconv.Atoi(fields[len(fields)-1])
+	if len(fields) < 2 || err != nil || bg < 0 || bg > 15 {
+		return false, false
+	}
+	// ANSI 7 (white) and the bright colors other than 8 (bright black) are light
+	return bg <= 6 || bg == 8, true
+}
+
+// ApplyTheme updates all styles based on the dark mode setting,
+// merging any configured color overrides onto the base palette
 func ApplyTheme(darkMode bool) {
 	IsDarkMode = darkMode
 	if darkMode {
-		currentPalette = darkPalette
+		currentPalette = mergePalette(darkPalette, themeOverrides)
 	} else {
-		currentPalette = lightPalette
+		currentPalette = mergePalette(lightPalette, themeOverrides)
 	}
 
 	// Rebuild all styles with the new palette
@@ -179,6 +251,9 @@ func ApplyTheme(darkMode bool) {
 		Foreground(currentPalette.dim).
 		Bold(true)
 
+	DisabledStyle = lipgloss.NewStyle().
+		Foreground(currentPalette.separator)
+
 	// Status styles
 	StatusRunning = lipgloss.NewStyle().Foreground(currentPalette.success)
 	StatusStopped = lipgloss.NewStyle().Foreground(currentPalette.warning)
@@ -216,6 +291,14 @@ func RenderKeyBinding(key, description string) string {
 	return KeyStyle.Render(key) + " " + DimmedStyle.Render(description)
 }
 
+// renderKeyBindingIf formats a key binding, grayed out when the action is unavailable
+func renderKeyBindingIf(key, description string, enabled bool) string {
+	if !enabled {
+		return DisabledStyle.Render(key + " " + description)
+	}
+	return RenderKeyBinding(key, description)
+}
+
 // RenderBorderedHeader creates a bordered header box
 func RenderBorderedHeader(title, subtitle string, width int) string {
 	if width <= 0 {
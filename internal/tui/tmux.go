@@ -6,13 +6,16 @@ import (
 
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/christophergyman/claude-quick/internal/devcontainer"
 	"github.com/christophergyman/claude-quick/internal/tmux"
 )
 
 const newSessionOption = "[+ New Session]"
 
 // RenderTmuxSelect renders the tmux session selection view
-func RenderTmuxSelect(projectName string, sessions []tmux.Session, cursor int, warning string) string {
+// presets are configured session names not yet running, offered as quick-create options.
+// The session named defaultName, which a plain new session would use, is labeled.
+func RenderTmuxSelect(projectName string, sessions []tmux.Session, defaultName string, presets []string, cursor int, warning, flash string) string {
 	width := defaultWidth
 
 	var b strings.Builder
@@ -45,12 +48,27 @@ func RenderTmuxSelect(projectName string, sessions []tmux.Session, cursor int, w
 		} else {
 			line = NoCursor() + ItemStyle.Render(display)
 		}
+		if session.Name == defaultName {
+			line += " " + DimmedStyle.Render("(default)")
+		}
 		b.WriteString(line)
 		b.WriteString("\n")
 	}
 
+	// Render preset quick-create options
+	for i, name := range presets {
+		display := "[+ " + name + "]"
+		if len(sessions)+i == cursor {
+			b.WriteString(Cursor() + SelectedStyle.Render(display))
+		} else {
+			b.WriteString(NoCursor() + ItemStyle.Render(display))
+		}
+		b.WriteString(" " + DimmedStyle.Render("preset"))
+		b.WriteString("\n")
+	}
+
 	// Render "New Session" option
-	newSessionIdx := len(sessions)
+	newSessionIdx := len(sessions) + len(presets)
 	var newSessionLine string
 	if cursor == newSessionIdx {
 		newSessionLine = Cursor() + SelectedStyle.Render(newSessionOption)
@@ -60,23 +78,34 @@ func RenderTmuxSelect(projectName string, sessions []tmux.Session, cursor int, w
 	b.WriteString(newSessionLine)
 	b.WriteString("\n")
 
+	// Transient status message
+	if flash != "" {
+		b.WriteString("\n")
+		b.WriteString("  " + flash)
+		b.WriteString("\n")
+	}
+
 	// Footer section
 	b.WriteString("\n")
 	b.WriteString("  " + RenderSeparator(width-4))
 	b.WriteString("\n")
 
 	// Key bindings - first row
-	keybindings1 := fmt.Sprintf("  %s  %s  %s  %s",
+	keybindings1 := fmt.Sprintf("  %s  %s  %s  %s  %s  %s",
 		RenderKeyBinding("↑↓", "navigate"),
 		RenderKeyBinding("enter", "select"),
 		RenderKeyBinding("x", "stop"),
+		RenderKeyBinding("X", "kill all"),
 		RenderKeyBinding("r", "restart"),
+		RenderKeyBinding("R", "restart tmux"),
 	)
 	b.WriteString(keybindings1)
 	b.WriteString("\n")
 
 	// Key bindings - second row with right-aligned detach hint
-	leftKeys := fmt.Sprintf("  %s  %s  %s",
+	leftKeys := fmt.Sprintf("  %s  %s  %s  %s  %s",
+		RenderKeyBinding("s", "shell"),
+		RenderKeyBinding("p", "pipe to file"),
 		RenderKeyBinding("t", "theme"),
 		RenderKeyBinding("?", "config"),
 		RenderKeyBinding("q", "back"),
@@ -114,6 +143,27 @@ func RenderNewSessionInput(projectName string, ti textinput.Model) string {
 	return b.String()
 }
 
+// RenderPipeSessionInput renders the input for the file a session's output is piped to
+func RenderPipeSessionInput(sessionName string, ti textinput.Model) string {
+	b := renderWithHeader("Pipe Session: " + sessionName)
+	b.WriteString("Append the session's output to (path inside the container):")
+	b.WriteString("\n\n")
+	b.WriteString(ti.View())
+	b.WriteString("\n\n")
+	b.WriteString(DimmedStyle.Render("Relative paths are in the workspace folder, so the file shows up in the project on the host"))
+	b.WriteString("\n\n")
+
+	// Footer
+	b.WriteString(RenderSeparator(defaultWidth - 4))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s  %s",
+		RenderKeyBinding("enter", "pipe"),
+		RenderKeyBinding("esc", "cancel"),
+	))
+
+	return b.String()
+}
+
 // RenderAttaching renders the view while attaching to a tmux session
 func RenderAttaching(projectName, sessionName, spinnerView string) string {
 	return renderSpinnerAction(spinnerView, "Attaching to", sessionName)
@@ -127,14 +177,41 @@ func RenderLoadingTmuxSessions(projectName, spinnerView string) string {
 	return b.String()
 }
 
-// TotalTmuxOptions returns the total number of selectable options (sessions + new session)
-func TotalTmuxOptions(sessions []tmux.Session) int {
-	return len(sessions) + 1
+// TotalTmuxOptions returns the total number of selectable options (sessions + presets + new session)
+func TotalTmuxOptions(sessions []tmux.Session, presets []string) int {
+	return len(sessions) + len(presets) + 1
 }
 
 // IsNewSessionSelected returns true if the cursor is on the "New Session" option
-func IsNewSessionSelected(sessions []tmux.Session, cursor int) bool {
-	return cursor == len(sessions)
+func IsNewSessionSelected(sessions []tmux.Session, presets []string, cursor int) bool {
+	return cursor == len(sessions)+len(presets)
+}
+
+// SelectedPreset returns the preset session name under the cursor, if any
+func SelectedPreset(sessions []tmux.Session, presets []string, cursor int) (string, bool) {
+	i := cursor - len(sessions)
+	if i < 0 || i >= len(presets) {
+		return "", false
+	}
+	return presets[i], true
+}
+
+// missingPresets returns the preset session names that aren't already running, in config order.
+// Names are sanitized first, as tmux can't target sessions named with "." or ":".
+func missingPresets(sessions []tmux.Session, presets []string) []string {
+	seen := make(map[string]bool, len(sessions))
+	for _, s := range sessions {
+		seen[s.Name] = true
+	}
+	var missing []string
+	for _, name := range presets {
+		name = devcontainer.SanitizeSessionName(name)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			missing = append(missing, name)
+		}
+	}
+	return missing
 }
 
 // RenderTmuxConfirmDialog renders a confirmation dialog for tmux stop/restart operations
@@ -142,6 +219,45 @@ func RenderTmuxConfirmDialog(operation, sessionName string) string {
 	return renderConfirmDialog(operation, "tmux session", "Session", sessionName)
 }
 
+// RenderTmuxKillAllConfirmDialog renders a confirmation dialog for killing every session in a container
+func RenderTmuxKillAllConfirmDialog(projectName string, sessionCount int) string {
+	b := renderWithHeader("")
+	noun := "sessions"
+	if sessionCount == 1 {
+		noun = "session"
+	}
+	b.WriteString(ErrorStyle.Render(fmt.Sprintf("Kill ALL %d tmux %s?", sessionCount, noun)))
+	b.WriteString("\n\n")
+	b.WriteString("Project: ")
+	b.WriteString(SuccessStyle.Render(projectName))
+	b.WriteString("\n\n")
+	b.WriteString(DimmedStyle.Render("This stops the tmux server in the container (tmux kill-server)"))
+	b.WriteString("\n\n")
+	b.WriteString(HelpStyle.Render("y: Confirm  n/Esc: Cancel"))
+	return b.String()
+}
+
+// RenderTmuxServerRestartConfirmDialog renders a confirmation dialog for
+// restarting the tmux server in a container, which kills every session
+func RenderTmuxServerRestartConfirmDialog(projectName string, sessionCount int) string {
+	b := renderWithHeader("")
+	b.WriteString(ErrorStyle.Render("Restart the tmux server?"))
+	b.WriteString("\n\n")
+	b.WriteString("Project: ")
+	b.WriteString(SuccessStyle.Render(projectName))
+	b.WriteString("\n\n")
+	noun := "sessions"
+	if sessionCount == 1 {
+		noun = "session"
+	}
+	b.WriteString(WarningStyle.Render(fmt.Sprintf("All %d %s will be killed (tmux kill-server)", sessionCount, noun)))
+	b.WriteString("\n")
+	b.WriteString(DimmedStyle.Render("Use this to recover when tmux in the container stops responding"))
+	b.WriteString("\n\n")
+	b.WriteString(HelpStyle.Render("y: Confirm  n/Esc: Cancel"))
+	return b.String()
+}
+
 // RenderTmuxOperation renders progress during tmux stop/restart operations
 func RenderTmuxOperation(operation, sessionName, spinnerView string) string {
 	return renderOperation(operation, "session", sessionName, spinnerView)
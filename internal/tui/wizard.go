@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -186,7 +187,7 @@ func RenderWizardSearchPaths(paths []string, warnings map[string]bool, cursor in
 }
 
 // RenderWizardCredentials renders the credentials setup screen
-func RenderWizardCredentials(credentials []auth.Credential, sourceType auth.SourceType, valueInput interface{ View() string }, cursor int, editMode bool, width int) string {
+func RenderWizardCredentials(credentials []auth.Credential, warnings map[string]string, sourceType auth.SourceType, valueInput interface{ View() string }, cursor int, editMode bool, width int) string {
 	if width <= 0 {
 		width = defaultWidth
 	}
@@ -256,7 +257,7 @@ func RenderWizardCredentials(credentials []auth.Credential, sourceType auth.Sour
 		case auth.SourceEnv:
 			b.WriteString(DimmedStyle.Render("Enter environment variable name"))
 		case auth.SourceCommand:
-			b.WriteString(DimmedStyle.Render("Enter command to execute"))
+			b.WriteString(DimmedStyle.Render("Enter command to execute (runs each time a container starts)"))
 		}
 		b.WriteString("\n\n")
 
@@ -277,6 +278,11 @@ func RenderWizardCredentials(credentials []auth.Credential, sourceType auth.Sour
 				}
 				b.WriteString(" ")
 				b.WriteString(DimmedStyle.Render(fmt.Sprintf("(%s: %s)", cred.Source, cred.Value)))
+				if cred.Source == auth.SourceCommand {
+					b.WriteString(DimmedStyle.Render(" - runs on each container start"))
+				} else if warning := warnings[credentialKey(cred)]; warning != "" {
+					b.WriteString(WarningStyle.Render(" (" + warning + ")"))
+				}
 				b.WriteString("\n")
 			}
 		}
@@ -433,7 +439,10 @@ func RenderWizardSummary(paths []string, credentials []auth.Credential, sessionN
 		b.WriteString(DimmedStyle.Render("(none)"))
 		b.WriteString("\n")
 	} else {
-		for _, cred := range credentials {
+		// Sorted by name, the order the credential file is written in
+		sorted := slices.Clone(credentials)
+		slices.SortStableFunc(sorted, func(a, b auth.Credential) int { return strings.Compare(a.Name, b.Name) })
+		for _, cred := range sorted {
 			b.WriteString("  ")
 			b.WriteString(ItemStyle.Render(cred.Name))
 			b.WriteString(" ")
@@ -491,3 +500,8 @@ func RenderWizardSaving(spinnerView string) string {
 	b.WriteString(" Saving configuration...")
 	return b.String()
 }
+
+// credentialKey identifies a credential for validation warnings
+func credentialKey(cred auth.Credential) string {
+	return string(cred.Source) + ":" + cred.Value
+}
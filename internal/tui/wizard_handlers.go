@@ -160,6 +160,7 @@ func (m Model) handleWizardCredentialsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 		case "enter":
 			// Add the credential
+			var cmd tea.Cmd
 			value := m.wizardCredValue.Value()
 			if value != "" {
 				// Check if GITHUB_TOKEN already exists
@@ -177,11 +178,12 @@ func (m Model) handleWizardCredentialsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 						Value:  value,
 					}
 					m.wizardCredentials = append(m.wizardCredentials, cred)
+					cmd = m.validateWizardCredential(cred)
 				}
 			}
 			m.wizardEditMode = false
 			m.wizardCredValue.Reset()
-			return m, nil
+			return m, cmd
 
 		case "esc":
 			m.wizardEditMode = false
@@ -3,6 +3,7 @@ package tui
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -12,6 +13,8 @@ import (
 	"github.com/christophergyman/claude-quick/internal/auth"
 	"github.com/christophergyman/claude-quick/internal/config"
 	"github.com/christophergyman/claude-quick/internal/constants"
+	"github.com/christophergyman/claude-quick/internal/github"
+	"github.com/christophergyman/claude-quick/internal/util"
 )
 
 // ============================================================================
@@ -138,6 +141,7 @@ func TestRenderWizardCredentials(t *testing.T) {
 	tests := []struct {
 		name        string
 		credentials []auth.Credential
+		warnings    map[string]string
 		sourceType  auth.SourceType
 		cursor      int
 		editMode    bool
@@ -179,12 +183,29 @@ func TestRenderWizardCredentials(t *testing.T) {
 			editMode:   true,
 			contains:   []string{"already configured", "esc to cancel"},
 		},
+		{
+			name: "missing file warning",
+			credentials: []auth.Credential{
+				{Name: "GITHUB_TOKEN", Source: auth.SourceFile, Value: "~/.missing"},
+			},
+			warnings:   map[string]string{"file:~/.missing": "file not found"},
+			sourceType: auth.SourceFile,
+			contains:   []string{"~/.missing", "file not found"},
+		},
+		{
+			name: "command reminder",
+			credentials: []auth.Credential{
+				{Name: "GITHUB_TOKEN", Source: auth.SourceCommand, Value: "gh auth token"},
+			},
+			sourceType: auth.SourceFile,
+			contains:   []string{"gh auth token", "runs on each container start"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ti := textinput.New()
-			result := RenderWizardCredentials(tt.credentials, tt.sourceType, ti, tt.cursor, tt.editMode, 65)
+			result := RenderWizardCredentials(tt.credentials, tt.warnings, tt.sourceType, ti, tt.cursor, tt.editMode, 65)
 
 			for _, expected := range tt.contains {
 				if !strings.Contains(strings.ToLower(result), strings.ToLower(expected)) {
@@ -268,6 +289,22 @@ func TestRenderWizardSummary(t *testing.T) {
 	}
 }
 
+func TestRenderWizardSummary_SortsCredentials(t *testing.T) {
+	creds := []auth.Credential{
+		{Name: "ZETA_TOKEN", Source: auth.SourceEnv, Value: "ZETA"},
+		{Name: "API_KEY", Source: auth.SourceEnv, Value: "KEY"},
+	}
+
+	result := RenderWizardSummary(nil, creds, "main", "300", "claude", "3", true, "/config.yaml", 65)
+
+	if strings.Index(result, "API_KEY") > strings.Index(result, "ZETA_TOKEN") {
+		t.Error("RenderWizardSummary should list credentials by name")
+	}
+	if creds[0].Name != "ZETA_TOKEN" {
+		t.Error("RenderWizardSummary should not reorder the caller's credentials")
+	}
+}
+
 func TestRenderWizardSummary_EmptyPaths(t *testing.T) {
 	result := RenderWizardSummary([]string{}, []auth.Credential{}, "main", "300", "claude", "3", true, "/config.yaml", 65)
 
@@ -871,6 +908,47 @@ func TestBuildWizardConfig_InvalidInputs(t *testing.T) {
 	}
 }
 
+func TestBuildWizardConfig_KeepsOtherSettings(t *testing.T) {
+	enabled, disabled := true, false
+	cfg := &config.Config{
+		SearchPaths:       []string{"/work"},
+		Theme:             config.ThemeConfig{Orange: "#E07A5F", Dim: "#888888"},
+		NestWorktreeDirs:  &enabled,
+		AutoPushWorktree:  &disabled,
+		AutoAttach:        &disabled,
+		ContainerLabelKey: "com.example.workspace",
+		GitHub: github.Config{
+			DefaultState:        github.IssueStateAll,
+			BranchPrefix:        "gh-",
+			MaxIssues:           10,
+			FetchTimeoutSeconds: 5,
+			IssueTitleMax:       40,
+			RemotePreference:    []string{"upstream"},
+			SavedQueries:        map[string]string{"bugs": "is:open label:bug"},
+		},
+	}
+	m := Model{config: cfg}
+	m.initWizardState(cfg)
+	got := m.buildWizardConfig()
+
+	// Settings the wizard doesn't edit must survive re-running it
+	for _, field := range []struct {
+		name      string
+		got, want any
+	}{
+		{"theme", got.Theme, cfg.Theme},
+		{"nest_worktree_dirs", got.NestWorktreeDirs, cfg.NestWorktreeDirs},
+		{"auto_push_worktree", got.AutoPushWorktree, cfg.AutoPushWorktree},
+		{"auto_attach_after_create", got.AutoAttach, cfg.AutoAttach},
+		{"container_label_key", got.ContainerLabelKey, cfg.ContainerLabelKey},
+		{"github", got.GitHub, cfg.GitHub},
+	} {
+		if !reflect.DeepEqual(field.got, field.want) {
+			t.Errorf("%s = %v after the wizard, want %v", field.name, field.got, field.want)
+		}
+	}
+}
+
 // ============================================================================
 // config.go wizard tests
 // ============================================================================
@@ -933,9 +1011,10 @@ func TestConfigSave(t *testing.T) {
 		t.Fatalf("failed to read config: %v", err)
 	}
 
+	// Search paths are stored expanded unless preserve_tilde is set
 	content := string(data)
-	if !strings.Contains(content, "~/projects") {
-		t.Error("config should contain search path")
+	if !strings.Contains(content, filepath.Join(util.HomeDir(), "projects")) {
+		t.Error("config should contain expanded search path")
 	}
 	if !strings.Contains(content, "max_depth: 3") {
 		t.Error("config should contain max_depth")
@@ -1001,3 +1080,49 @@ func TestWizardStepNames(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateWizardCredential(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("secret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CLAUDE_QUICK_TEST_TOKEN", "secret")
+
+	tests := []struct {
+		name        string
+		cred        auth.Credential
+		wantCmd     bool
+		wantWarning string
+	}{
+		{"existing file", auth.Credential{Source: auth.SourceFile, Value: tokenFile}, true, ""},
+		{"missing file", auth.Credential{Source: auth.SourceFile, Value: tokenFile + ".missing"}, true, "file not found"},
+		{"set env var", auth.Credential{Source: auth.SourceEnv, Value: "CLAUDE_QUICK_TEST_TOKEN"}, true, ""},
+		{"unset env var", auth.Credential{Source: auth.SourceEnv, Value: "CLAUDE_QUICK_TEST_UNSET"}, true, "environment variable is not set"},
+		{"command", auth.Credential{Source: auth.SourceCommand, Value: "gh auth token"}, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := Model{}.validateWizardCredential(tt.cred)
+			if !tt.wantCmd {
+				if cmd != nil {
+					t.Error("expected no validation command")
+				}
+				return
+			}
+			if cmd == nil {
+				t.Fatal("expected a validation command")
+			}
+			msg, ok := cmd().(wizardCredentialValidatedMsg)
+			if !ok {
+				t.Fatalf("expected wizardCredentialValidatedMsg, got %T", cmd())
+			}
+			if msg.key != credentialKey(tt.cred) {
+				t.Errorf("key = %q, want %q", msg.key, credentialKey(tt.cred))
+			}
+			if msg.warning != tt.wantWarning {
+				t.Errorf("warning = %q, want %q", msg.warning, tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/atotto/clipboard"
+)
+
+// CopyToClipboard writes text to the system clipboard.
+// Requires pbcopy on macOS or xclip/xsel/wl-copy on Linux.
+func CopyToClipboard(text string) error {
+	if clipboard.Unsupported {
+		return fmt.Errorf("clipboard not supported: install xclip, xsel, or wl-clipboard")
+	}
+	if err := clipboard.WriteAll(text); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+	return nil
+}
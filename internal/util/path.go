@@ -4,6 +4,7 @@ package util
 import (
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // ExpandPath expands ~ to the user's home directory.
@@ -19,6 +20,19 @@ func ExpandPath(path string) string {
 	return path
 }
 
+// CollapsePath replaces a leading home directory with ~ (the inverse of ExpandPath).
+// Paths outside the home directory are returned unchanged.
+func CollapsePath(path string) string {
+	home := HomeDir()
+	if path == home {
+		return "~"
+	}
+	if rel, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return "~/" + filepath.ToSlash(rel)
+	}
+	return path
+}
+
 // HomeDir returns the user's home directory with fallback to temp directory.
 func HomeDir() string {
 	if homeDir, err := os.UserHomeDir(); err == nil {
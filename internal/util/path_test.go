@@ -108,3 +108,26 @@ func TestHomeDir(t *testing.T) {
 		t.Errorf("HomeDir() = %q, want temp dir %q", result, tempDir)
 	}
 }
+
+func TestCollapsePath(t *testing.T) {
+	home := HomeDir()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"home itself", home, "~"},
+		{"under home", filepath.Join(home, "projects", "app"), "~/projects/app"},
+		{"outside home", "/opt/src", "/opt/src"},
+		{"sibling with home prefix", home + "-other", home + "-other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CollapsePath(tt.input); got != tt.expected {
+				t.Errorf("CollapsePath(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
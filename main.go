@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
@@ -8,19 +9,100 @@ import (
 
 	"github.com/christophergyman/claude-quick/internal/config"
 	"github.com/christophergyman/claude-quick/internal/devcontainer"
+	"github.com/christophergyman/claude-quick/internal/doctor"
 	"github.com/christophergyman/claude-quick/internal/tui"
 )
 
 func main() {
+	projectPath := flag.String("project", "", "start the devcontainer at this path directly, skipping discovery")
+	runDoctor := flag.Bool("doctor", false, "check the environment and configuration, then exit")
+	noColor := flag.Bool("no-color", false, "render plain text without colors (also set by NO_COLOR)")
+	migrateConfig := flag.Bool("migrate-config", false, "copy the legacy ~/.config config next to the executable, then exit")
+	profile := flag.String("profile", "", "use the search paths of this config profile (default: default_profile)")
+	attach := flag.Bool("attach", false, "start <project> and attach to tmux <session> (created if missing) without the TUI; pass both after the flags")
+	initConfig := flag.Bool("init", false, "write a default config next to the executable without the wizard, then exit")
+	force := flag.Bool("force", false, "with --init, replace an existing config")
+	exportWorkspaces := flag.Bool("export-workspaces", false, "print discovered projects as JSON, then exit; pass a path after the flags to write a file instead (.code-workspace writes a VS Code workspace)")
+	flag.Parse()
+
+	if *attach && flag.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: claude-quick --attach <project> <session>")
+		os.Exit(2)
+	}
+	if *force && !*initConfig {
+		fmt.Fprintln(os.Stderr, "Usage: claude-quick --init [--force]")
+		os.Exit(2)
+	}
+	if *exportWorkspaces && (*attach || flag.NArg() > 1) {
+		fmt.Fprintln(os.Stderr, "Usage: claude-quick --export-workspaces [output path]")
+		os.Exit(2)
+	}
+
+	if *initConfig {
+		path, err := config.InitConfig(*force)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Config written to %s\n", path)
+		return
+	}
+
+	if *migrateConfig {
+		path, err := config.MigrateLegacyConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Config copied to %s\nThe old file can now be deleted.\n", path)
+		return
+	}
+
+	// https://no-color.org: any non-empty NO_COLOR disables color
+	if *noColor || os.Getenv("NO_COLOR") != "" {
+		tui.SetNoColor()
+	}
+
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := config.Load(*profile)
+
+	// Health check report; a config load error is reported as a failed check
+	if *runDoctor {
+		if !doctor.Report(os.Stdout, doctor.Run(doctor.Checks(cfg, err))) {
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		os.Exit(1)
 	}
 
+	// Container label, discovery and credential file settings
+	tui.ApplyRuntimeConfig(cfg)
+
+	// One-shot launcher: no TUI, the process becomes the tmux attach
+	if *attach {
+		if err := runAttach(cfg, flag.Arg(0), flag.Arg(1)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// One-shot export: discovery results for other tools, no TUI
+	if *exportWorkspaces {
+		if err := runExport(cfg, flag.Arg(0)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Check if this is first run (no config file exists)
-	if !config.ConfigExists() {
+	// The wizard is skipped for --project, which runs fine on defaults
+	if !config.ConfigExists() && *projectPath == "" {
 		// Launch wizard for first-time setup
 		model := tui.NewWithWizard(cfg)
 		p := tea.NewProgram(model, tea.WithAltScreen())
@@ -42,8 +124,30 @@ func main() {
 	// Create TUI with async discovery - shows spinner while searching for projects
 	// Tmux attachment happens within the TUI via tea.ExecProcess
 	// When user detaches (Ctrl+b d), they return to the TUI dashboard
-	model := tui.NewWithDiscovery(cfg)
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	var model tui.Model
+	if *projectPath != "" {
+		// Fast path: start the given project without walking the search paths
+		instance, err := devcontainer.LoadInstance(*projectPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		model = tui.NewWithProject(*instance, cfg)
+	} else {
+		model = tui.NewWithDiscovery(cfg)
+	}
+
+	// Check the Docker daemon is reachable; warn on the dashboard rather than exit
+	if err := devcontainer.CheckDockerDaemon(); err != nil {
+		model = model.WithWarning(fmt.Sprintf("Docker daemon unreachable: %v", err))
+	}
+
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if cfg.FocusRefresh > 0 {
+		// Focus events drive refresh_on_focus_seconds
+		opts = append(opts, tea.WithReportFocus())
+	}
+	p := tea.NewProgram(model, opts...)
 
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)